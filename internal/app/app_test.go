@@ -5,10 +5,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"ralph/internal/args"
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/testgit"
+	"ralph/internal/shared/workdir"
 	"ralph/internal/version"
 )
 
@@ -72,6 +77,146 @@ func TestRunClean(t *testing.T) {
 	})
 }
 
+func TestRunCleanRequiresForceForUnfinishedStories(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	p := &prd.PRD{ProjectName: "Invites", Stories: []*prd.Story{
+		{ID: "story-1", Title: "Done", Description: "Desc", Priority: 1, Passes: true, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "a", RedHint: "t", Passes: true}}},
+		{ID: "story-2", Title: "Open", Description: "Desc", Priority: 2, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "b", RedHint: "t"}}},
+	}}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := runClean(cfg, false); code != 1 {
+		t.Fatalf("runClean(force=false) = %d, want 1 for unfinished stories", code)
+	}
+	if _, err := os.Stat(cfg.PRDPath()); err != nil {
+		t.Fatalf("prd.json removed without --force: %v", err)
+	}
+
+	if code := runClean(cfg, true); code != 0 {
+		t.Fatalf("runClean(force=true) = %d, want 0", code)
+	}
+	if _, err := os.Stat(cfg.PRDPath()); !os.IsNotExist(err) {
+		t.Fatalf("prd.json still exists after clean --force: %v", err)
+	}
+	if code := runClean(cfg, false); code != 0 {
+		t.Fatalf("runClean on a clean dir = %d, want 0", code)
+	}
+}
+
+func TestRunCleanRequiresForceForWorktree(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	testgit.InitRepo(t, cfg.WorkDir)
+	path := workdir.WorktreePath(cfg.WorkDir)
+	if err := workdir.CreateWorktree(cfg.WorkDir, "", path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "draft.go"), []byte("package draft\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := runClean(cfg, false); code != 1 {
+		t.Fatalf("runClean(force=false) = %d, want 1 with a worktree present", code)
+	}
+	if _, err := os.Stat(filepath.Join(path, "draft.go")); err != nil {
+		t.Fatalf("worktree changes removed without --force: %v", err)
+	}
+
+	if code := runClean(cfg, true); code != 0 {
+		t.Fatalf("runClean(force=true) = %d, want 0", code)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("worktree still exists after clean --force: %v", err)
+	}
+}
+
+func TestRunHistory(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+
+	if code := runHistory(cfg); code != 1 {
+		t.Fatalf("runHistory() without RALPH_PRD_HISTORY_DIR = %d, want 1", code)
+	}
+	cfg.PRDHistoryDir = "history"
+	if code := runHistory(cfg); code != 0 {
+		t.Fatalf("runHistory() on an empty history = %d, want 0", code)
+	}
+	if _, err := prd.SaveHistory(cfg.PRDHistoryPath(), &prd.PRD{ProjectName: "Invites"}, time.Unix(1700000000, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if code := runHistory(cfg); code != 0 {
+		t.Fatalf("runHistory() = %d, want 0", code)
+	}
+}
+
+func TestOverrideRunner(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		want    string
+		wantErr string
+	}{
+		{name: "no flag keeps config", want: config.DefaultRunner},
+		{name: "known runner", flag: "gemini/gemini-2.5-pro", want: "gemini/gemini-2.5-pro"},
+		{name: "unknown runner", flag: "gpt", wantErr: `unknown runner "gpt"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			err := overrideRunner(cfg, tt.flag)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("overrideRunner() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("overrideRunner() error = %v", err)
+			}
+			if cfg.Runner != tt.want {
+				t.Errorf("Runner = %q, want %q", cfg.Runner, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverrideModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		runner  string
+		flag    string
+		want    string
+		wantErr string
+	}{
+		{name: "no flag keeps config", runner: "ollama/llama3", want: "ollama/llama3"},
+		{name: "ollama model", runner: "ollama/llama3", flag: "qwen2.5-coder:7b", want: "ollama/qwen2.5-coder:7b"},
+		{name: "gemini model", runner: "gemini/gemini-2.5-pro", flag: "gemini-2.5-flash", want: "gemini/gemini-2.5-flash"},
+		{name: "runner without a model", runner: "claude", flag: "sonnet", wantErr: `--model needs an ollama/<model> or gemini/<model> runner; "claude" picks its own model`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Runner = tt.runner
+			err := overrideModel(cfg, tt.flag)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("overrideModel() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("overrideModel() error = %v", err)
+			}
+			if cfg.Runner != tt.want {
+				t.Errorf("Runner = %q, want %q", cfg.Runner, tt.want)
+			}
+		})
+	}
+}
+
 func TestApplyRuntimeOptionsSetsAutoApprove(t *testing.T) {
 	cfg := config.DefaultConfig()
 	opts := &args.Options{AutoApprove: true}
@@ -94,6 +239,83 @@ func TestApplyRuntimeOptionsSetsDryRun(t *testing.T) {
 	}
 }
 
+func TestApplyRuntimeOptionsSetsNoBranch(t *testing.T) {
+	cfg := config.DefaultConfig()
+	opts := &args.Options{NoBranch: true}
+
+	applyRuntimeOptions(cfg, opts)
+
+	if !cfg.NoBranch {
+		t.Error("NoBranch should be copied from parsed options")
+	}
+}
+
+func TestApplyRuntimeOptionsSetsAppend(t *testing.T) {
+	cfg := config.DefaultConfig()
+	opts := &args.Options{Append: true}
+
+	applyRuntimeOptions(cfg, opts)
+
+	if !cfg.Append {
+		t.Error("Append should be copied from parsed options")
+	}
+}
+
+func TestApplyRuntimeOptionsSetsRawOutput(t *testing.T) {
+	cfg := config.DefaultConfig()
+	opts := &args.Options{Headless: true, RawOutput: true}
+
+	applyRuntimeOptions(cfg, opts)
+
+	if !cfg.RawOutput {
+		t.Error("RawOutput should be copied from parsed options")
+	}
+}
+
+func TestApplyRuntimeOptionsSetsOpenEditor(t *testing.T) {
+	cfg := config.DefaultConfig()
+	opts := &args.Options{Headless: true, OpenEditor: true}
+
+	applyRuntimeOptions(cfg, opts)
+
+	if !cfg.OpenEditor {
+		t.Error("OpenEditor should be copied from parsed options")
+	}
+}
+
+func TestApplyRuntimeOptionsSetsScaffoldTests(t *testing.T) {
+	cfg := config.DefaultConfig()
+	opts := &args.Options{ScaffoldTests: true}
+
+	applyRuntimeOptions(cfg, opts)
+
+	if !cfg.ScaffoldTests {
+		t.Error("ScaffoldTests should be copied from parsed options")
+	}
+}
+
+func TestApplyRuntimeOptionsSetsBestEffort(t *testing.T) {
+	cfg := config.DefaultConfig()
+	opts := &args.Options{BestEffort: true}
+
+	applyRuntimeOptions(cfg, opts)
+
+	if !cfg.BestEffort {
+		t.Error("BestEffort should be copied from parsed options")
+	}
+}
+
+func TestApplyRuntimeOptionsSetsSpinner(t *testing.T) {
+	cfg := config.DefaultConfig()
+	opts := &args.Options{Spinner: config.SpinnerOff}
+
+	applyRuntimeOptions(cfg, opts)
+
+	if cfg.Spinner != config.SpinnerOff {
+		t.Errorf("Spinner = %q, want %q", cfg.Spinner, config.SpinnerOff)
+	}
+}
+
 func TestRunBareNoTTY(t *testing.T) {
 	r, w, err := os.Pipe()
 	if err != nil {
@@ -112,3 +334,39 @@ func TestRunBareNoTTY(t *testing.T) {
 		t.Errorf("Run() with no args and non-TTY stdin = %d, want 1", code)
 	}
 }
+
+func TestApplyRuntimeOptionsKeepsFileLimitsWithoutFlags(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ReviewRounds, cfg.RecoveryAttempts = 4, 2
+
+	applyRuntimeOptions(cfg, &args.Options{})
+	if cfg.ReviewRounds != 4 || cfg.RecoveryAttempts != 2 {
+		t.Fatalf("ReviewRounds/RecoveryAttempts = %d/%d, want file values 4/2 kept", cfg.ReviewRounds, cfg.RecoveryAttempts)
+	}
+
+	applyRuntimeOptions(cfg, &args.Options{MaxIterations: 6, RetryAttempts: 1})
+	if cfg.ReviewRounds != 6 || cfg.RecoveryAttempts != 1 {
+		t.Fatalf("ReviewRounds/RecoveryAttempts = %d/%d, want flag values 6/1", cfg.ReviewRounds, cfg.RecoveryAttempts)
+	}
+}
+
+func TestApplyRuntimeOptionsSetsLogLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		opts args.Options
+		want config.LogLevel
+	}{
+		{name: "default", want: config.LogLevelNormal},
+		{name: "verbose", opts: args.Options{Verbose: true}, want: config.LogLevelVerbose},
+		{name: "debug", opts: args.Options{Verbose: true, Debug: true}, want: config.LogLevelDebug},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			applyRuntimeOptions(cfg, &tt.opts)
+			if cfg.LogLevel != tt.want {
+				t.Errorf("LogLevel = %d, want %d", cfg.LogLevel, tt.want)
+			}
+		})
+	}
+}
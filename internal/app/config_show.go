@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"ralph/internal/shared/config"
+)
+
+// runConfig prints the resolved configuration for `ralph config`, exiting 1
+// with the error when it does not load or validate.
+func runConfig(workDir, configFile, runnerFlag, modelFlag string) int {
+	cfg, settings, err := config.Describe(workDir, configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if runnerFlag != "" {
+		if err := overrideRunner(cfg, runnerFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid configuration: %v\n", err)
+			return 1
+		}
+		settings = overrideSetting(settings, "Runner", fmt.Sprintf("%q", runnerFlag))
+	}
+	if modelFlag != "" {
+		if err := overrideModel(cfg, modelFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid configuration: %v\n", err)
+			return 1
+		}
+		settings = overrideSetting(settings, "Runner", fmt.Sprintf("%q", cfg.Runner))
+	}
+	writeConfig(os.Stdout, settings, config.DetectRunner(cfg.Runner))
+	return 0
+}
+
+// overrideSetting credits name to a command-line flag such as --runner, which
+// applies after the layers config.Describe reports.
+func overrideSetting(settings []config.Setting, name, value string) []config.Setting {
+	for i := range settings {
+		if settings[i].Name == name {
+			settings[i].Value = value
+			settings[i].Source = config.SourceFlag
+		}
+	}
+	return settings
+}
+
+// writeConfig prints one "Name  value  (source)" line per setting. The Runner
+// line also names the runner kind it resolves to.
+func writeConfig(out io.Writer, settings []config.Setting, runnerKind config.RunnerKind) {
+	width := 0
+	for _, s := range settings {
+		width = max(width, len(s.Name))
+	}
+	for _, s := range settings {
+		line := fmt.Sprintf("%-*s  %s  (%s)", width, s.Name, s.Value, s.Source)
+		if s.Name == "Runner" {
+			line += fmt.Sprintf(" -> %s runner", runnerKind)
+		}
+		fmt.Fprintln(out, line)
+	}
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+)
+
+func TestWriteConfig(t *testing.T) {
+	settings := []config.Setting{
+		{Name: "Runner", Value: `"opencode"`, Source: config.SourceFile},
+		{Name: "PRDFile", Value: `"prd.json"`, Source: config.SourceDefault},
+	}
+	settings = overrideSetting(settings, "PRDFile", `"plan.json"`)
+
+	var out bytes.Buffer
+	writeConfig(&out, settings, config.RunnerOpenCode)
+
+	want := "Runner   \"opencode\"  (file) -> opencode runner\n" +
+		"PRDFile  \"plan.json\"  (flag)\n"
+	if got := out.String(); got != want {
+		t.Fatalf("writeConfig() =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Contains(out.String(), "(default)") {
+		t.Fatal("overridden setting should no longer be credited to the default")
+	}
+}
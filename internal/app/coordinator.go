@@ -2,31 +2,45 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
 
 	"ralph/internal/args"
 	"ralph/internal/clean"
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/logger"
 	sharedprd "ralph/internal/shared/prd"
+	"ralph/internal/shared/runlog"
+	"ralph/internal/shared/runstate"
 	"ralph/internal/shared/workdir"
 	"ralph/internal/status"
 	"ralph/internal/tui"
 	"ralph/internal/update"
 	"ralph/internal/version"
 	"ralph/internal/web"
+	"ralph/internal/workflow"
 )
 
 type Coordinator struct {
-	loadConfig     func() (*config.Config, error)
-	runClean       func(*config.Config) int
+	loadConfig     func(workDir, configFile string) (*config.Config, error)
+	runClean       func(cfg *config.Config, force bool) int
 	runStatus      func(*config.Config) int
+	runOneline     func(*config.Config, bool) int
+	runLockStatus  func(*config.Config) int
+	runValidate    func(*config.Config) int
+	runRunners     func(string) int
 	runTUI         func(*config.Config, string, bool, bool, bool) int
 	runHeadless    func(*config.Config, string, bool) int
 	runUpdate      func(*args.Options) int
@@ -41,9 +55,13 @@ type Coordinator struct {
 
 func newCoordinator() *Coordinator {
 	return &Coordinator{
-		loadConfig:     config.Load,
+		loadConfig:     config.LoadFrom,
 		runClean:       runClean,
 		runStatus:      runStatus,
+		runOneline:     runOneline,
+		runLockStatus:  runLockStatus,
+		runValidate:    runValidate,
+		runRunners:     runRunners,
 		runTUI:         runTUI,
 		runHeadless:    runHeadless,
 		runUpdate:      RunUpdate,
@@ -76,6 +94,9 @@ func (c *Coordinator) Run(opts *args.Options) int {
 		fmt.Println(c.versionInfo())
 		return 0
 	}
+	if opts.Runners {
+		return c.runRunners(opts.RecommendTask)
+	}
 	if opts.Update {
 		if opts.UpdateCheck {
 			return c.runUpdateCheck(opts)
@@ -86,31 +107,154 @@ func (c *Coordinator) Run(opts *args.Options) int {
 	logger.Init(opts.Verbose)
 	logger.Debug("starting ralph", "verbose", opts.Verbose)
 
-	cfg, err := c.loadConfig()
+	if opts.EnvFile != "" {
+		if err := config.LoadEnvFile(opts.EnvFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading env file: %v\n", err)
+			return 1
+		}
+	}
+
+	if opts.PromptFile != "" {
+		prompt, err := readPromptFile(opts.PromptFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		opts.Prompt = prompt
+	}
+
+	workDir, err := resolveWorkDir(opts.WorkDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if opts.ShowConfig {
+		return runConfig(workDir, opts.ConfigFile, opts.Runner, opts.Model)
+	}
+
+	cfg, err := c.loadConfig(workDir, opts.ConfigFile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		fmt.Print(c.helpText())
 		return 1
 	}
+	if err := overrideRunner(cfg, opts.Runner); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+	if err := overrideModel(cfg, opts.Model); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+	if err := logger.Configure(logger.Options{
+		File:        cfg.LogFilePath(),
+		MaxBytes:    int64(cfg.LogMaxMB) << 20,
+		HidePrompts: cfg.HideLogPrompts,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: RALPH_LOG_FILE: %v\n", err)
+		return 1
+	}
+	defer logger.Close()
 	logger.Debug("config loaded", "runner", cfg.Runner)
 
+	if opts.ResumePRD != "" {
+		if err := useResumePRD(cfg, opts.ResumePRD); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
 	if opts.Clean {
-		return c.runClean(cfg)
+		return c.runClean(cfg, opts.Force)
+	}
+	if opts.LockStatus {
+		return c.runLockStatus(cfg)
+	}
+	if opts.History {
+		return runHistory(cfg)
+	}
+	if opts.ValidatePRD {
+		return c.runValidate(cfg)
+	}
+
+	if opts.PickRunner {
+		if err := maybePickRunner(cfg, c.isTerminal(os.Stdin.Fd()), os.Stdin, os.Stdout, commandOnPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	if opts.FromSpec != "" {
+		p, err := importSpec(cfg, opts.FromSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Wrote %s from %s (%d stories)\n", cfg.PRDFile, opts.FromSpec, len(p.Stories))
+		if opts.DryRun {
+			return 0
+		}
+		opts.Resume = true
+	}
+
+	if cfg.UseWorktree && cfg.NoBranch {
+		fmt.Fprintln(os.Stderr, "Error: --no-branch cannot be used with RALPH_USE_WORKTREE (the worktree starts on a detached HEAD)")
+		return 1
+	}
+	if cfg.UseWorktree && !opts.DryRun && !opts.Web && !opts.Status {
+		leaveWorktree, err := enterWorktree(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer leaveWorktree()
 	}
 
 	if err := c.validateResume(cfg, opts.Resume); err != nil {
+		if opts.DryRun && errors.Is(err, errNoResumePRD) {
+			err = fmt.Errorf("--dry-run with --resume only loads an existing PRD, and there is no %s", cfg.PRDFile)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 1
 	}
+	if len(opts.Skip) > 0 {
+		if err := skipStories(cfg, opts.Skip); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Skipping %s\n", strings.Join(opts.Skip, ", "))
+	}
+	if len(opts.Rerun) > 0 {
+		ids, err := rerunStories(cfg, opts.Rerun, opts.RerunDependents)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Rerunning %s\n", strings.Join(ids, ", "))
+	}
 	applyRuntimeOptions(cfg, opts)
+	if cfg.NoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
 
 	if opts.Status {
+		if opts.StatusOneline {
+			return c.runOneline(cfg, opts.ASCII)
+		}
 		return c.runStatus(cfg)
 	}
 	if opts.Web {
 		return c.runWeb(cfg, opts.WebPort)
 	}
 	if opts.Headless {
+		if opts.QueueFile != "" {
+			if err := c.validateGit(cfg.WorkDir); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 1
+			}
+			return c.runQueue(cfg, opts.QueueFile, opts.FailFast)
+		}
 		if opts.Prompt != "" || opts.Resume {
 			if err := c.validateGit(cfg.WorkDir); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -147,7 +291,7 @@ func (c *Coordinator) withDefaults() *Coordinator {
 		c = newCoordinator()
 	}
 	if c.loadConfig == nil {
-		c.loadConfig = config.Load
+		c.loadConfig = config.LoadFrom
 	}
 	if c.runClean == nil {
 		c.runClean = runClean
@@ -155,6 +299,18 @@ func (c *Coordinator) withDefaults() *Coordinator {
 	if c.runStatus == nil {
 		c.runStatus = runStatus
 	}
+	if c.runOneline == nil {
+		c.runOneline = runOneline
+	}
+	if c.runLockStatus == nil {
+		c.runLockStatus = runLockStatus
+	}
+	if c.runValidate == nil {
+		c.runValidate = runValidate
+	}
+	if c.runRunners == nil {
+		c.runRunners = runRunners
+	}
 	if c.runTUI == nil {
 		c.runTUI = runTUI
 	}
@@ -190,12 +346,51 @@ func (c *Coordinator) withDefaults() *Coordinator {
 
 func applyRuntimeOptions(cfg *config.Config, opts *args.Options) {
 	cfg.SkipCleanup = opts.SkipCleanup
+	cfg.NoBranch = opts.NoBranch
+	cfg.NoCommit = opts.NoCommit
+	cfg.Append = opts.Append
 	cfg.DryRun = opts.DryRun
+	cfg.RawOutput = opts.RawOutput
+	switch {
+	case opts.Debug:
+		cfg.LogLevel = config.LogLevelDebug
+	case opts.Verbose:
+		cfg.LogLevel = config.LogLevelVerbose
+	}
+	cfg.ShowInternal = opts.ShowInternal
+	cfg.JSONOutput = opts.JSON
+	cfg.NormalizePriorities = opts.NormalizePriorities
+	cfg.DiffContext = opts.DiffContext
+	cfg.OpenEditor = opts.OpenEditor
+	cfg.ScaffoldTests = opts.ScaffoldTests
+	cfg.BestEffort = opts.BestEffort
+	cfg.NoColor = opts.NoColor || os.Getenv("NO_COLOR") != ""
+	cfg.Spinner = opts.Spinner
+	if opts.MaxIterations > 0 {
+		cfg.ReviewRounds = opts.MaxIterations
+	}
+	if opts.RetryAttempts > 0 {
+		cfg.RecoveryAttempts = opts.RetryAttempts
+	}
+	cfg.MaxStories = opts.MaxStories
+	cfg.OutputDir = opts.OutputDir
+	if opts.Format == config.PRDFormatMarkdown {
+		cfg.PRDFormat = opts.Format
+	}
 	cfg.AutoApprove = opts.AutoApprove || cfg.AutoApprove
 }
 
 func runTUI(cfg *config.Config, prompt string, dryRun, resume, verbose bool) int {
 	model := tui.NewModel(cfg, prompt, dryRun, resume, verbose)
+	if cfg.OutputDir != "" {
+		runLog, err := runlog.Open(cfg.OutputDir, clock.Real{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer runLog.Close()
+		model.SetRunLog(runLog)
+	}
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	finalModel, err := p.Run()
 	if err != nil {
@@ -241,15 +436,152 @@ func runStatus(cfg *config.Config) int {
 	return 0
 }
 
-func runClean(cfg *config.Config) int {
-	if err := clean.RemoveState(cfg); err != nil {
+func runOneline(cfg *config.Config, ascii bool) int {
+	line, err := status.Oneline(cfg, ascii)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Println(line)
+	return 0
+}
+
+func runLockStatus(cfg *config.Config) int {
+	status, err := sharedprd.InspectLock(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Println(string(data))
+	return 0
+}
+
+// overrideRunner applies --runner to the loaded config for this run only. It
+// is validated like RALPH_RUNNER so a typo fails before any runner is built.
+func overrideRunner(cfg *config.Config, name string) error {
+	if name == "" {
+		return nil
+	}
+	cfg.Runner = name
+	return cfg.ValidateRunner()
+}
+
+// overrideModel applies --model to the runner for this run only. Only the
+// ollama/<model> and gemini/<model> runners name a model, so on any other
+// runner the flag is an error rather than silently ignored.
+func overrideModel(cfg *config.Config, model string) error {
+	if model == "" {
+		return nil
+	}
+	switch config.DetectRunner(cfg.Runner) {
+	case config.RunnerOllama:
+		cfg.Runner = config.OllamaRunnerPrefix + model
+	case config.RunnerGemini:
+		cfg.Runner = config.GeminiRunnerPrefix + model
+	default:
+		return fmt.Errorf("--model needs an ollama/<model> or gemini/<model> runner; %q picks its own model", cfg.Runner)
+	}
+	return cfg.ValidateRunner()
+}
+
+// runHistory lists the PRDs kept in RALPH_PRD_HISTORY_DIR, oldest first.
+func runHistory(cfg *config.Config) int {
+	dir := cfg.PRDHistoryPath()
+	if dir == "" {
+		fmt.Fprintln(os.Stderr, "Error: RALPH_PRD_HISTORY_DIR is not set, so no PRD history is kept")
+		return 1
+	}
+	entries, err := sharedprd.ListHistory(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No PRD history in %s\n", displayPath(cfg, dir))
+		return 0
+	}
+	for _, entry := range entries {
+		fmt.Printf("%s  %s  %s (%d stories)\n", entry.GeneratedAt.Format("2006-01-02 15:04:05"), displayPath(cfg, entry.Path), entry.ProjectName, entry.Stories)
+	}
+	return 0
+}
+
+// runValidate lints the PRD without running anything. It exits 1 when the PRD
+// is missing or fails validation and 2 when it validates but has stories the
+// vagueness heuristic flags.
+func runValidate(cfg *config.Config) int {
+	exists, err := sharedprd.Exists(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: checking for PRD %s: %v\n", cfg.PRDFile, err)
+		return 1
+	}
+	if !exists {
+		fmt.Fprintf(os.Stderr, "Error: no %s found to validate\n", cfg.PRDFile)
+		return 1
+	}
+	p, err := sharedprd.Load(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if findings := p.VagueFindings(); len(findings) > 0 {
+		fmt.Printf("%s is valid but may be too vague to implement:\n", cfg.PRDFile)
+		for _, finding := range findings {
+			fmt.Printf("  - %s\n", finding)
+		}
+		return 2
+	}
+	fmt.Printf("%s is valid (%d stories)\n", cfg.PRDFile, len(p.Stories))
+	return 0
+}
+
+// runClean removes Ralph's state and lists what it removed. A PRD with
+// unfinished stories is kept unless force is set, so in-progress work is not
+// discarded by accident; an unreadable PRD does not block the clean.
+func runClean(cfg *config.Config, force bool) int {
+	if !force {
+		if p, err := sharedprd.Load(cfg); err == nil && !p.AllCompleted() {
+			fmt.Fprintf(os.Stderr, "Error: %s has %d unfinished stories; run ralph clean --force to discard them\n", cfg.PRDFile, len(p.Stories)-p.CompletedCount())
+			return 1
+		}
+		worktree := workdir.WorktreePath(cfg.WorkDir)
+		if _, err := os.Stat(worktree); err == nil {
+			fmt.Fprintf(os.Stderr, "Error: %s holds an unfinished RALPH_USE_WORKTREE run; run ralph clean --force to discard its uncommitted changes (commits on its branch are kept)\n", displayPath(cfg, worktree))
+			return 1
+		}
+	}
+	removed, err := clean.RemoveState(cfg)
+	for _, path := range removed {
+		fmt.Printf("Removed %s\n", displayPath(cfg, path))
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 1
 	}
+	if len(removed) == 0 {
+		fmt.Println("No Ralph state to remove.")
+		return 0
+	}
 	fmt.Println("Ralph state removed.")
 	return 0
 }
 
+// displayPath shows path relative to the work dir when it is inside it.
+func displayPath(cfg *config.Config, path string) string {
+	if rel, err := filepath.Rel(cfg.WorkDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// errNoResumePRD is wrapped by validateResume when there is no PRD to resume.
+var errNoResumePRD = errors.New("run ralph with a prompt first to generate a PRD")
+
 func validateResume(cfg *config.Config, resume bool) error {
 	if !resume {
 		return nil
@@ -259,7 +591,7 @@ func validateResume(cfg *config.Config, resume bool) error {
 		return fmt.Errorf("checking for existing PRD %s: %w", cfg.PRDFile, err)
 	}
 	if !exists {
-		return fmt.Errorf("no %s found to resume from (run ralph with a prompt first to generate a PRD)", cfg.PRDFile)
+		return fmt.Errorf("no %s found to resume from (%w)", cfg.PRDFile, errNoResumePRD)
 	}
 	if _, err := sharedprd.Load(cfg); err != nil {
 		return fmt.Errorf("loading existing PRD %s: %w", cfg.PRDFile, err)
@@ -267,6 +599,140 @@ func validateResume(cfg *config.Config, resume bool) error {
 	return nil
 }
 
+// importSpec converts a markdown spec into the PRD file so the run can
+// proceed as a resume, skipping PRD generation. It never overwrites an
+// existing PRD.
+func importSpec(cfg *config.Config, specPath string) (*sharedprd.PRD, error) {
+	exists, err := sharedprd.Exists(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("checking for existing PRD %s: %w", cfg.PRDFile, err)
+	}
+	if exists {
+		return nil, fmt.Errorf("%s already exists; remove it or use --resume instead of --from-spec", cfg.PRDFile)
+	}
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec %s: %w", specPath, err)
+	}
+	p, err := sharedprd.ParseMarkdownSpec(data, cfg.BranchPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("parsing spec %s: %w", specPath, err)
+	}
+	if err := sharedprd.Save(cfg, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// resolveWorkDir turns --work-dir into an absolute directory, or "" for the
+// current directory when the flag is unset.
+func resolveWorkDir(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolving --work-dir %s: %w", path, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("--work-dir %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("--work-dir %s is not a directory", path)
+	}
+	return abs, nil
+}
+
+// useResumePRD points cfg at the PRD passed to --resume. The file must exist
+// inside the work dir, since story commits, lock files and runner prompts
+// all refer to the PRD relative to it.
+func useResumePRD(cfg *config.Config, path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolving PRD %s: %w", path, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return fmt.Errorf("PRD %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("PRD %s is a directory", path)
+	}
+	workDir, err := filepath.Abs(cfg.WorkDir)
+	if err != nil {
+		return fmt.Errorf("resolving work dir %s: %w", cfg.WorkDir, err)
+	}
+	rel, err := filepath.Rel(workDir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("PRD %s is outside the work dir %s", path, workDir)
+	}
+	cfg.PRDFile = rel
+	return nil
+}
+
+// readPromptFile returns the file's contents as the prompt, minus trailing
+// newlines. A blank file is rejected like a missing prompt.
+func readPromptFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading prompt file %s: %w", path, err)
+	}
+	prompt := strings.TrimRight(string(data), "\r\n")
+	if strings.TrimSpace(prompt) == "" {
+		return "", fmt.Errorf("prompt file %s is empty", path)
+	}
+	return prompt, nil
+}
+
+// skipStories marks stories as skipped in the PRD before a resumed run picks
+// its next story.
+func skipStories(cfg *config.Config, ids []string) error {
+	p, err := sharedprd.Load(cfg)
+	if err != nil {
+		return fmt.Errorf("loading PRD %s: %w", cfg.PRDFile, err)
+	}
+	if err := p.SkipStories(ids); err != nil {
+		return err
+	}
+	return sharedprd.Save(cfg, p)
+}
+
+// rerunStories resets the given stories, and with cascade their dependents,
+// so the resumed run implements them again. A run that already finished is
+// moved back to the follow-up checkpoint so a checkpoint resume picks the
+// stories up instead of treating the run as complete.
+func rerunStories(cfg *config.Config, ids []string, cascade bool) ([]string, error) {
+	p, err := sharedprd.Load(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("loading PRD %s: %w", cfg.PRDFile, err)
+	}
+	seen := make(map[string]bool)
+	var reset []string
+	for _, id := range ids {
+		storyIDs, err := p.ResetStory(id, cascade)
+		if err != nil {
+			return nil, err
+		}
+		for _, storyID := range storyIDs {
+			if !seen[storyID] {
+				seen[storyID] = true
+				reset = append(reset, storyID)
+			}
+		}
+	}
+	if err := sharedprd.Save(cfg, p); err != nil {
+		return nil, err
+	}
+	loop := workflow.NewFileReviewLoop(cfg.WorkDir, runstate.LocalRunID)
+	if loop.Checkpoint() == runstate.CheckpointComplete {
+		if err := loop.Apply(workflow.ReviewLoopUpdate{Checkpoint: runstate.CheckpointFollowup}); err != nil {
+			return nil, fmt.Errorf("reopening completed run: %w", err)
+		}
+	}
+	return reset, nil
+}
+
 func RunWeb(cfg *config.Config, port int) int {
 	return runWeb(cfg, port)
 }
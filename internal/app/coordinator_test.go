@@ -5,11 +5,16 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"ralph/internal/args"
 	"ralph/internal/shared/config"
+	sharedprd "ralph/internal/shared/prd"
+	"ralph/internal/shared/runstate"
 	"ralph/internal/update"
+	"ralph/internal/workflow"
 )
 
 func TestCoordinatorRoutesCommands(t *testing.T) {
@@ -24,6 +29,9 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 		wantValidateGit    bool
 		wantRunClean       bool
 		wantRunStatus      bool
+		wantRunOneline     bool
+		wantRunRunners     string
+		wantRunLockStatus  bool
 		wantRunWeb         bool
 		wantRunTUI         bool
 		wantRunHeadless    bool
@@ -48,6 +56,19 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 			wantCode:   0,
 			wantStdout: "version text\n",
 		},
+		{
+			name:              "lock status",
+			opts:              &args.Options{LockStatus: true},
+			wantCode:          12,
+			wantLoadConfig:    true,
+			wantRunLockStatus: true,
+		},
+		{
+			name:           "runners recommend",
+			opts:           &args.Options{Runners: true, RecommendTask: "fix typo"},
+			wantCode:       11,
+			wantRunRunners: "fix typo",
+		},
 		{
 			name:          "update",
 			opts:          &args.Options{Update: true},
@@ -78,6 +99,14 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 			wantValidateResume: true,
 			wantRunStatus:      true,
 		},
+		{
+			name:               "status oneline",
+			opts:               &args.Options{Status: true, StatusOneline: true, ASCII: true},
+			wantCode:           10,
+			wantLoadConfig:     true,
+			wantValidateResume: true,
+			wantRunOneline:     true,
+		},
 		{
 			name:               "web",
 			opts:               &args.Options{Web: true, WebPort: 3333},
@@ -101,6 +130,18 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 			wantVerbose:        true,
 			wantTerminalCheck:  true,
 		},
+		{
+			name:               "dry run",
+			opts:               &args.Options{Prompt: "build a feature", DryRun: true},
+			wantCode:           3,
+			wantLoadConfig:     true,
+			wantValidateResume: true,
+			wantValidateGit:    true,
+			wantRunTUI:         true,
+			wantPrompt:         "build a feature",
+			wantDryRun:         true,
+			wantTerminalCheck:  true,
+		},
 		{
 			name:               "headless",
 			opts:               &args.Options{Headless: true, Prompt: "build a feature", AutoApprove: true},
@@ -132,6 +173,9 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 				validateGit    []string
 				runClean       int
 				runStatus      int
+				runOneline     []bool
+				runRunners     []string
+				runLockStatus  int
 				runWeb         []int
 				runTUI         []struct {
 					prompt  string
@@ -148,11 +192,11 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 			}{}
 
 			c := &Coordinator{
-				loadConfig: func() (*config.Config, error) {
+				loadConfig: func(string, string) (*config.Config, error) {
 					calls.loadConfig++
 					return cfg, nil
 				},
-				runClean: func(*config.Config) int {
+				runClean: func(*config.Config, bool) int {
 					calls.runClean++
 					return 5
 				},
@@ -160,6 +204,18 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 					calls.runStatus++
 					return 4
 				},
+				runOneline: func(_ *config.Config, ascii bool) int {
+					calls.runOneline = append(calls.runOneline, ascii)
+					return 10
+				},
+				runLockStatus: func(*config.Config) int {
+					calls.runLockStatus++
+					return 12
+				},
+				runRunners: func(task string) int {
+					calls.runRunners = append(calls.runRunners, task)
+					return 11
+				},
 				runWeb: func(*config.Config, int) int {
 					calls.runWeb = append(calls.runWeb, tt.opts.WebPort)
 					return 6
@@ -233,6 +289,21 @@ func TestCoordinatorRoutesCommands(t *testing.T) {
 			if got := calls.runStatus > 0; got != tt.wantRunStatus {
 				t.Fatalf("runStatus called = %v, want %v", got, tt.wantRunStatus)
 			}
+			if got := calls.runLockStatus > 0; got != tt.wantRunLockStatus {
+				t.Fatalf("runLockStatus called = %v, want %v", got, tt.wantRunLockStatus)
+			}
+			if tt.wantRunRunners != "" && (len(calls.runRunners) != 1 || calls.runRunners[0] != tt.wantRunRunners) {
+				t.Fatalf("runRunners calls = %v, want [%q]", calls.runRunners, tt.wantRunRunners)
+			}
+			if tt.wantRunRunners == "" && len(calls.runRunners) > 0 {
+				t.Fatalf("runRunners called unexpectedly: %v", calls.runRunners)
+			}
+			if got := len(calls.runOneline) > 0; got != tt.wantRunOneline {
+				t.Fatalf("runOneline called = %v, want %v", got, tt.wantRunOneline)
+			}
+			if tt.wantRunOneline && !calls.runOneline[0] {
+				t.Fatal("runOneline should receive --ascii")
+			}
 			if got := len(calls.runWeb) > 0; got != tt.wantRunWeb {
 				t.Fatalf("runWeb called = %v, want %v", got, tt.wantRunWeb)
 			}
@@ -330,7 +401,7 @@ func TestCoordinatorBootUpdatePrecedesPromptFlow(t *testing.T) {
 	}
 
 	c := &Coordinator{
-		loadConfig: func() (*config.Config, error) {
+		loadConfig: func(string, string) (*config.Config, error) {
 			calls = append(calls, "load-config")
 			return &config.Config{WorkDir: t.TempDir(), PRDFile: "prd.json"}, nil
 		},
@@ -406,7 +477,7 @@ func TestCoordinatorBootUpdateDeclinedSkipsInstall(t *testing.T) {
 	}
 
 	c := &Coordinator{
-		loadConfig: func() (*config.Config, error) {
+		loadConfig: func(string, string) (*config.Config, error) {
 			return &config.Config{WorkDir: t.TempDir(), PRDFile: "prd.json"}, nil
 		},
 		runTUI: func(*config.Config, string, bool, bool, bool) int {
@@ -455,7 +526,7 @@ func TestCoordinatorBootUpdateFailureDoesNotBlockPromptFlow(t *testing.T) {
 
 	runTUICalled := false
 	c := &Coordinator{
-		loadConfig: func() (*config.Config, error) {
+		loadConfig: func(string, string) (*config.Config, error) {
 			return &config.Config{WorkDir: t.TempDir(), PRDFile: "prd.json"}, nil
 		},
 		runTUI: func(*config.Config, string, bool, bool, bool) int {
@@ -508,7 +579,7 @@ func TestCoordinatorExplicitUpdateSkipsBootUpdate(t *testing.T) {
 			calls = append(calls, "run-update")
 			return 0
 		},
-		loadConfig: func() (*config.Config, error) {
+		loadConfig: func(string, string) (*config.Config, error) {
 			t.Fatal("loadConfig should not run for explicit update")
 			return nil, nil
 		},
@@ -572,10 +643,10 @@ func TestCoordinatorSubcommandsSkipBootUpdate(t *testing.T) {
 			}
 
 			c := &Coordinator{
-				loadConfig: func() (*config.Config, error) {
+				loadConfig: func(string, string) (*config.Config, error) {
 					return &config.Config{WorkDir: t.TempDir(), PRDFile: "prd.json"}, nil
 				},
-				runClean:       func(*config.Config) int { return 0 },
+				runClean:       func(*config.Config, bool) int { return 0 },
 				runStatus:      func(*config.Config) int { return 0 },
 				runWeb:         func(*config.Config, int) int { return 0 },
 				runHeadless:    func(*config.Config, string, bool) int { return 0 },
@@ -654,7 +725,7 @@ func TestCoordinatorMetaCommandsSkipBootUpdate(t *testing.T) {
 					calls = append(calls, "run-update-check")
 					return 8
 				},
-				loadConfig: func() (*config.Config, error) {
+				loadConfig: func(string, string) (*config.Config, error) {
 					t.Fatal("loadConfig should not run for meta commands")
 					return nil, nil
 				},
@@ -680,3 +751,463 @@ func TestCoordinatorMetaCommandsSkipBootUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestCoordinatorLoadsEnvFileBeforeConfig(t *testing.T) {
+	t.Setenv("RALPH_TEST_ENV_FILE_KEY", "")
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envFile, []byte("RALPH_TEST_ENV_FILE_KEY=loaded\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := ""
+	c := &Coordinator{
+		loadConfig: func(string, string) (*config.Config, error) {
+			seen = os.Getenv("RALPH_TEST_ENV_FILE_KEY")
+			return config.DefaultConfig(), nil
+		},
+		runClean: func(*config.Config, bool) int { return 5 },
+	}
+	code, _, stderr := captureCoordinatorRun(t, c, &args.Options{Clean: true, EnvFile: envFile})
+	if code != 5 {
+		t.Fatalf("Run() = %d, want 5 (stderr %q)", code, stderr)
+	}
+	if seen != "loaded" {
+		t.Fatalf("env var at config load = %q, want loaded", seen)
+	}
+}
+
+func TestCoordinatorRejectsMalformedEnvFile(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envFile, []byte("not-a-pair\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Coordinator{
+		loadConfig: func(string, string) (*config.Config, error) {
+			t.Fatal("loadConfig should not run after a malformed env file")
+			return nil, nil
+		},
+	}
+	code, _, stderr := captureCoordinatorRun(t, c, &args.Options{Clean: true, EnvFile: envFile})
+	if code != 1 {
+		t.Fatalf("Run() = %d, want 1", code)
+	}
+	if !strings.Contains(stderr, "expected KEY=VALUE") {
+		t.Fatalf("stderr = %q, want malformed line error", stderr)
+	}
+}
+
+func TestCoordinatorFromSpecWritesPRDAndResumes(t *testing.T) {
+	oldCheck := updateCheck
+	defer func() { updateCheck = oldCheck }()
+	updateCheck = func(context.Context, string, string) (bool, string, string, error) {
+		return true, "", "", nil
+	}
+
+	spec := "# Invites\n\n## Story: Invite API\n- POST /invites returns 201\n"
+	for _, tt := range []struct {
+		name       string
+		dryRun     bool
+		wantCode   int
+		wantResume bool
+	}{
+		{name: "implements from spec", wantCode: 3, wantResume: true},
+		{name: "dry run only writes prd", dryRun: true, wantCode: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			specPath := filepath.Join(dir, "spec.md")
+			if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = dir
+
+			var tuiResume []bool
+			c := &Coordinator{
+				loadConfig:     func(string, string) (*config.Config, error) { return cfg, nil },
+				validateGit:    func(string) error { return nil },
+				validateResume: validateResume,
+				isTerminal:     func(uintptr) bool { return true },
+				runTUI: func(_ *config.Config, _ string, _ bool, resume bool, _ bool) int {
+					tuiResume = append(tuiResume, resume)
+					return 3
+				},
+			}
+			code, stdout, stderr := captureCoordinatorRun(t, c, &args.Options{FromSpec: specPath, DryRun: tt.dryRun})
+			if code != tt.wantCode {
+				t.Fatalf("Run() = %d, want %d (stderr %q)", code, tt.wantCode, stderr)
+			}
+			if !strings.Contains(stdout, "Wrote prd.json") || !strings.Contains(stdout, "(1 stories)") {
+				t.Fatalf("stdout = %q, want import summary", stdout)
+			}
+			p, err := sharedprd.Load(cfg)
+			if err != nil {
+				t.Fatalf("imported PRD should load: %v", err)
+			}
+			if p.ProjectName != "Invites" || len(p.Stories) != 1 {
+				t.Fatalf("imported PRD = %+v", p)
+			}
+			if tt.wantResume != (len(tuiResume) == 1 && tuiResume[0]) {
+				t.Fatalf("runTUI resume calls = %v, want resume %v", tuiResume, tt.wantResume)
+			}
+
+			code, _, stderr = captureCoordinatorRun(t, c, &args.Options{FromSpec: specPath, DryRun: true})
+			if code != 1 || !strings.Contains(stderr, "already exists") {
+				t.Fatalf("second import = %d (stderr %q), want refusal to overwrite", code, stderr)
+			}
+		})
+	}
+}
+
+func TestCoordinatorResumesPRDPath(t *testing.T) {
+	oldCheck := updateCheck
+	defer func() { updateCheck = oldCheck }()
+	updateCheck = func(context.Context, string, string) (bool, string, string, error) {
+		return true, "", "", nil
+	}
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "prds"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	saveCfg := config.DefaultConfig()
+	saveCfg.WorkDir = dir
+	saveCfg.PRDFile = filepath.Join("prds", "invites.json")
+	if err := sharedprd.Save(saveCfg, &sharedprd.PRD{ProjectName: "Invites", Stories: []*sharedprd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Priority: 1, Slices: []*sharedprd.Slice{{ID: "slice-1", Behavior: "works", RedHint: "test"}}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "other.json")
+	if err := os.WriteFile(outside, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		wantCode    int
+		wantPRDFile string
+		wantErr     string
+	}{
+		{name: "file in work dir", path: filepath.Join(dir, "prds", "invites.json"), wantCode: 3, wantPRDFile: filepath.Join("prds", "invites.json")},
+		{name: "missing", path: filepath.Join(dir, "missing.json"), wantCode: 1, wantErr: "no such file"},
+		{name: "outside work dir", path: outside, wantCode: 1, wantErr: "outside the work dir"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = dir
+			var gotPRDFile string
+			c := &Coordinator{
+				loadConfig:     func(string, string) (*config.Config, error) { return cfg, nil },
+				validateGit:    func(string) error { return nil },
+				validateResume: validateResume,
+				isTerminal:     func(uintptr) bool { return true },
+				runTUI: func(cfg *config.Config, _ string, _ bool, _ bool, _ bool) int {
+					gotPRDFile = cfg.PRDFile
+					return 3
+				},
+			}
+			code, _, stderr := captureCoordinatorRun(t, c, &args.Options{Resume: true, ResumePRD: tt.path})
+			if code != tt.wantCode {
+				t.Fatalf("Run() = %d, want %d (stderr %q)", code, tt.wantCode, stderr)
+			}
+			if tt.wantErr != "" && !strings.Contains(stderr, tt.wantErr) {
+				t.Fatalf("stderr = %q, want containing %q", stderr, tt.wantErr)
+			}
+			if gotPRDFile != tt.wantPRDFile {
+				t.Fatalf("PRDFile = %q, want %q", gotPRDFile, tt.wantPRDFile)
+			}
+		})
+	}
+}
+
+func TestCoordinatorDryRunResumeRequiresPRD(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	tuiRuns := 0
+	newCoordinator := func() *Coordinator {
+		return &Coordinator{
+			loadConfig:     func(string, string) (*config.Config, error) { return cfg, nil },
+			validateGit:    func(string) error { return nil },
+			validateResume: validateResume,
+			runTUI: func(_ *config.Config, _ string, dryRun, resume, _ bool) int {
+				if dryRun && resume {
+					tuiRuns++
+				}
+				return 0
+			},
+			isTerminal: func(uintptr) bool { return true },
+		}
+	}
+	opts := func() *args.Options { return &args.Options{DryRun: true, Resume: true} }
+
+	code, _, stderr := captureCoordinatorRun(t, newCoordinator(), opts())
+	if code != 1 || !strings.Contains(stderr, "--dry-run with --resume only loads an existing PRD, and there is no prd.json") {
+		t.Fatalf("Run() = %d (stderr %q), want missing PRD error", code, stderr)
+	}
+
+	p := &sharedprd.PRD{ProjectName: "Load", Stories: []*sharedprd.Story{
+		{ID: "story-1", Title: "Keep", Priority: 1, Slices: []*sharedprd.Slice{{ID: "slice-1", Behavior: "works", RedHint: "test"}}},
+	}}
+	if err := sharedprd.Save(cfg, p); err != nil {
+		t.Fatal(err)
+	}
+	code, _, stderr = captureCoordinatorRun(t, newCoordinator(), opts())
+	if code != 0 || tuiRuns != 1 {
+		t.Fatalf("Run() = %d, TUI dry-run resumes = %d (stderr %q), want a load-only resume", code, tuiRuns, stderr)
+	}
+}
+
+func TestCoordinatorSkipMarksStoriesBeforeResume(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = dir
+	p := &sharedprd.PRD{ProjectName: "Skip", Stories: []*sharedprd.Story{
+		{ID: "story-1", Title: "Keep", Priority: 1, Slices: []*sharedprd.Slice{{ID: "slice-1", Behavior: "works", RedHint: "test"}}},
+		{ID: "story-2", Title: "Wrong", Priority: 2, Slices: []*sharedprd.Slice{{ID: "slice-1", Behavior: "works", RedHint: "test"}}},
+	}}
+	if err := sharedprd.Save(cfg, p); err != nil {
+		t.Fatal(err)
+	}
+
+	newCoordinator := func() *Coordinator {
+		return &Coordinator{
+			loadConfig:     func(string, string) (*config.Config, error) { return cfg, nil },
+			validateGit:    func(string) error { return nil },
+			validateResume: validateResume,
+			runHeadless:    func(*config.Config, string, bool) int { return 0 },
+		}
+	}
+	code, stdout, stderr := captureCoordinatorRun(t, newCoordinator(), &args.Options{Headless: true, Resume: true, Skip: []string{"story-2"}})
+	if code != 0 {
+		t.Fatalf("Run() = %d, want 0 (stderr %q)", code, stderr)
+	}
+	if !strings.Contains(stdout, "Skipping story-2") {
+		t.Fatalf("stdout = %q, want skip notice", stdout)
+	}
+	saved, err := sharedprd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.GetStory("story-1").Skip || !saved.GetStory("story-2").Skip {
+		t.Fatalf("saved skips = %v/%v, want only story-2", saved.GetStory("story-1").Skip, saved.GetStory("story-2").Skip)
+	}
+
+	code, _, stderr = captureCoordinatorRun(t, newCoordinator(), &args.Options{Headless: true, Resume: true, Skip: []string{"story-9"}})
+	if code != 1 || !strings.Contains(stderr, `unknown story "story-9"`) {
+		t.Fatalf("Run() = %d (stderr %q), want unknown story error", code, stderr)
+	}
+}
+
+func TestCoordinatorRerunResetsStoriesAndReopensCompletedRun(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = dir
+	slices := func() []*sharedprd.Slice {
+		return []*sharedprd.Slice{{ID: "slice-1", Behavior: "works", RedHint: "test", Passes: true}}
+	}
+	p := &sharedprd.PRD{ProjectName: "Rerun", Stories: []*sharedprd.Story{
+		{ID: "story-1", Title: "Base", Priority: 1, Passes: true, Slices: slices()},
+		{ID: "story-2", Title: "Uses base", Priority: 2, Passes: true, DependsOn: []string{"story-1"}, Slices: slices()},
+		{ID: "story-3", Title: "Other", Priority: 3, Passes: true, Slices: slices()},
+	}}
+	if err := sharedprd.Save(cfg, p); err != nil {
+		t.Fatal(err)
+	}
+	loop := workflow.NewFileReviewLoop(dir, runstate.LocalRunID)
+	if err := loop.Apply(workflow.ReviewLoopUpdate{Checkpoint: runstate.CheckpointComplete}); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Coordinator{
+		loadConfig:     func(string, string) (*config.Config, error) { return cfg, nil },
+		validateGit:    func(string) error { return nil },
+		validateResume: validateResume,
+		runHeadless:    func(*config.Config, string, bool) int { return 0 },
+	}
+	code, stdout, stderr := captureCoordinatorRun(t, c, &args.Options{Headless: true, Resume: true, Rerun: []string{"story-1"}, RerunDependents: true})
+	if code != 0 {
+		t.Fatalf("Run() = %d, want 0 (stderr %q)", code, stderr)
+	}
+	if !strings.Contains(stdout, "Rerunning story-1, story-2") {
+		t.Fatalf("stdout = %q, want rerun notice naming the dependent", stdout)
+	}
+	saved, err := sharedprd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.GetStory("story-1").Passes || saved.GetStory("story-2").Passes || !saved.GetStory("story-3").Passes {
+		t.Fatalf("saved passes = %v/%v/%v, want only story-3 still passing",
+			saved.GetStory("story-1").Passes, saved.GetStory("story-2").Passes, saved.GetStory("story-3").Passes)
+	}
+	if got := loop.Checkpoint(); got != runstate.CheckpointFollowup {
+		t.Fatalf("checkpoint = %q, want %q so the resume runs the reset stories", got, runstate.CheckpointFollowup)
+	}
+}
+
+func TestRunValidateExitCodes(t *testing.T) {
+	story := func(description, behavior string) *sharedprd.Story {
+		return &sharedprd.Story{ID: "story-1", Title: "Invite", Description: description, Priority: 1,
+			Slices: []*sharedprd.Slice{{ID: "slice-1", Behavior: behavior, RedHint: "add a failing test"}}}
+	}
+	tests := []struct {
+		name       string
+		prd        *sharedprd.PRD
+		raw        string
+		wantCode   int
+		wantOutput string
+	}{
+		{name: "missing prd", wantCode: 1, wantOutput: "no prd.json found"},
+		{name: "invalid prd", raw: `{"project_name":"P","stories":[{"id":"story-1","title":"T","priority":1}]}`, wantCode: 1, wantOutput: "must have at least one slice"},
+		{name: "vague prd", prd: &sharedprd.PRD{ProjectName: "P", Stories: []*sharedprd.Story{story("Invites", "invites work properly")}}, wantCode: 2, wantOutput: `vague wording "properly"`},
+		{name: "valid prd", prd: &sharedprd.PRD{ProjectName: "P", Stories: []*sharedprd.Story{story("Invites", "POST /invites returns 201")}}, wantCode: 0, wantOutput: "prd.json is valid (1 stories)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = t.TempDir()
+			if tt.prd != nil {
+				if err := sharedprd.Save(cfg, tt.prd); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.raw != "" {
+				if err := os.WriteFile(cfg.PRDPath(), []byte(tt.raw), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c := &Coordinator{loadConfig: func(string, string) (*config.Config, error) { return cfg, nil }}
+			code, stdout, stderr := captureCoordinatorRun(t, c, &args.Options{ValidatePRD: true})
+			if code != tt.wantCode {
+				t.Fatalf("Run() = %d, want %d (stdout %q, stderr %q)", code, tt.wantCode, stdout, stderr)
+			}
+			if !strings.Contains(stdout+stderr, tt.wantOutput) {
+				t.Fatalf("output = %q, want containing %q", stdout+stderr, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestCoordinatorPromptFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		contents   string
+		wantCode   int
+		wantPrompt string
+		wantErr    string
+	}{
+		{name: "trims trailing newlines", contents: "Add invites.\n\nUse \"quotes\" freely.\n\n", wantCode: 0, wantPrompt: "Add invites.\n\nUse \"quotes\" freely."},
+		{name: "empty file", contents: "\n  \n", wantCode: 1, wantErr: "is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "feature.md")
+			if err := os.WriteFile(path, []byte(tt.contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = dir
+
+			var gotPrompt string
+			c := &Coordinator{
+				loadConfig:  func(string, string) (*config.Config, error) { return cfg, nil },
+				validateGit: func(string) error { return nil },
+				runHeadless: func(_ *config.Config, prompt string, _ bool) int {
+					gotPrompt = prompt
+					return 0
+				},
+			}
+			code, _, stderr := captureCoordinatorRun(t, c, &args.Options{Headless: true, PromptFile: path})
+			if code != tt.wantCode {
+				t.Fatalf("Run() = %d, want %d (stderr %q)", code, tt.wantCode, stderr)
+			}
+			if gotPrompt != tt.wantPrompt {
+				t.Errorf("prompt = %q, want %q", gotPrompt, tt.wantPrompt)
+			}
+			if tt.wantErr != "" && !strings.Contains(stderr, tt.wantErr) {
+				t.Errorf("stderr = %q, want containing %q", stderr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCoordinatorWorkDir(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		workDir     string
+		wantCode    int
+		wantLoadDir string
+		wantErr     string
+	}{
+		{name: "unset uses current directory", workDir: "", wantCode: 0, wantLoadDir: ""},
+		{name: "directory", workDir: dir, wantCode: 0, wantLoadDir: dir},
+		{name: "missing", workDir: filepath.Join(dir, "missing"), wantCode: 1, wantErr: "no such file"},
+		{name: "file", workDir: file, wantCode: 1, wantErr: "is not a directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loadDir string
+			loaded := false
+			var gitDir string
+			c := &Coordinator{
+				loadConfig: func(workDir, _ string) (*config.Config, error) {
+					loaded = true
+					loadDir = workDir
+					cfg := config.DefaultConfig()
+					cfg.WorkDir = workDir
+					return cfg, nil
+				},
+				validateGit: func(workDir string) error {
+					gitDir = workDir
+					return nil
+				},
+				runHeadless: func(*config.Config, string, bool) int { return 0 },
+			}
+			code, _, stderr := captureCoordinatorRun(t, c, &args.Options{Headless: true, Prompt: "build", WorkDir: tt.workDir})
+			if code != tt.wantCode {
+				t.Fatalf("Run() = %d, want %d (stderr %q)", code, tt.wantCode, stderr)
+			}
+			if tt.wantErr != "" {
+				if !strings.Contains(stderr, tt.wantErr) {
+					t.Errorf("stderr = %q, want containing %q", stderr, tt.wantErr)
+				}
+				if loaded {
+					t.Error("loadConfig should not run for an invalid --work-dir")
+				}
+				return
+			}
+			if loadDir != tt.wantLoadDir || gitDir != tt.wantLoadDir {
+				t.Errorf("loadConfig dir = %q, validateGit dir = %q, want %q", loadDir, gitDir, tt.wantLoadDir)
+			}
+		})
+	}
+}
+
+func TestCoordinatorPassesConfigFileToLoader(t *testing.T) {
+	var gotFile string
+	c := &Coordinator{
+		loadConfig: func(workDir, configFile string) (*config.Config, error) {
+			gotFile = configFile
+			return config.DefaultConfig(), nil
+		},
+		validateGit: func(string) error { return nil },
+		runHeadless: func(*config.Config, string, bool) int { return 0 },
+	}
+	code, _, stderr := captureCoordinatorRun(t, c, &args.Options{Headless: true, Prompt: "build", ConfigFile: "/shared/ralph.yaml"})
+	if code != 0 {
+		t.Fatalf("Run() = %d, want 0 (stderr %q)", code, stderr)
+	}
+	if gotFile != "/shared/ralph.yaml" {
+		t.Fatalf("loadConfig configFile = %q, want the --config path", gotFile)
+	}
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ralph/internal/shared/cli"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/constants"
+	sharedprd "ralph/internal/shared/prd"
+	"ralph/internal/shared/workdir"
+)
+
+// queueEntry is one prompt of a --queue batch and how its run ended.
+type queueEntry struct {
+	prompt  string
+	prdFile string
+	code    int
+	ran     bool
+	err     error
+}
+
+// readQueue returns the prompts in path, one per non-blank line. Lines
+// starting with # are comments.
+func readQueue(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading queue %s: %w", path, err)
+	}
+	defer f.Close()
+	var prompts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		prompts = append(prompts, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading queue %s: %w", path, err)
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("queue %s has no prompts", path)
+	}
+	return prompts, nil
+}
+
+// queuePRDFile numbers the PRD file for the nth prompt: prd.json becomes
+// prd-1.json, prd-2.json, and so on.
+func queuePRDFile(prdFile string, n int) string {
+	ext := filepath.Ext(prdFile)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(prdFile, ext), n, ext)
+}
+
+// runQueue runs each prompt of the --queue file as its own headless run with
+// its own PRD file, printing a line per prompt and a batch summary at the
+// end. Every prompt starts from the branch the batch started on. The batch
+// stops early on an interrupt, or at the first prompt that does not complete
+// when failFast is set.
+func (c *Coordinator) runQueue(cfg *config.Config, path string, failFast bool) int {
+	prompts, err := readQueue(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	out := io.Writer(os.Stdout)
+	if cfg.JSONOutput {
+		out = os.Stderr
+	}
+	startBranch, _ := workdir.CurrentBranchName(cfg.WorkDir)
+
+	entries := make([]queueEntry, len(prompts))
+	for i, prompt := range prompts {
+		entries[i] = queueEntry{prompt: prompt, prdFile: queuePRDFile(cfg.PRDFile, i+1)}
+	}
+	for i := range entries {
+		entry := &entries[i]
+		entry.code, entry.err = c.runQueueEntry(cfg, entry, startBranch)
+		entry.ran = true
+		fmt.Fprintln(out, queueEntryLine(i+1, len(entries), entry))
+		if entry.code == constants.ExitInterrupted || (failFast && entry.code != 0) {
+			break
+		}
+	}
+	fmt.Fprintln(out, queueSummaryLine(entries))
+	return queueExitCode(entries)
+}
+
+func (c *Coordinator) runQueueEntry(cfg *config.Config, entry *queueEntry, startBranch string) (int, error) {
+	entryCfg := *cfg
+	entryCfg.PRDFile = entry.prdFile
+	exists, err := sharedprd.Exists(&entryCfg)
+	if err != nil {
+		return 1, fmt.Errorf("checking for existing PRD %s: %w", entry.prdFile, err)
+	}
+	if exists {
+		return 1, fmt.Errorf("%s already exists; remove it or run ralph --resume --headless %s", entry.prdFile, entry.prdFile)
+	}
+	if current, _ := workdir.CurrentBranchName(cfg.WorkDir); startBranch != "" && current != startBranch {
+		if err := workdir.SwitchBranch(cfg.WorkDir, startBranch); err != nil {
+			return 1, fmt.Errorf("switching back to %s: %w", startBranch, err)
+		}
+	}
+	return c.runHeadless(&entryCfg, entry.prompt, false), nil
+}
+
+func queueEntryLine(n, total int, entry *queueEntry) string {
+	status := cli.SummaryStatus(entry.code)
+	line := fmt.Sprintf("Queue %d/%d %s: %q (%s)", n, total, status, entry.prompt, entry.prdFile)
+	if entry.err != nil {
+		line += ": " + entry.err.Error()
+	}
+	return line
+}
+
+func queueSummaryLine(entries []queueEntry) string {
+	counts := map[string]int{}
+	notRun := 0
+	for _, entry := range entries {
+		if !entry.ran {
+			notRun++
+			continue
+		}
+		counts[cli.SummaryStatus(entry.code)]++
+	}
+	return fmt.Sprintf("Queue finished: %d prompts, %d completed, %d partial, %d failed, %d interrupted, %d not run",
+		len(entries), counts["completed"], counts["partial"], counts["failed"], counts["interrupted"], notRun)
+}
+
+// queueExitCode is 0 when every prompt completed, ExitInterrupted when the
+// batch was interrupted, 1 when a prompt failed or never ran, and
+// ExitPartialSuccess when the only shortfall is unfinished stories.
+func queueExitCode(entries []queueEntry) int {
+	failed, partial := false, false
+	for _, entry := range entries {
+		switch {
+		case !entry.ran:
+			failed = true
+		case entry.code == constants.ExitInterrupted:
+			return constants.ExitInterrupted
+		case entry.code == constants.ExitPartialSuccess:
+			partial = true
+		case entry.code != 0:
+			failed = true
+		}
+	}
+	switch {
+	case failed:
+		return 1
+	case partial:
+		return constants.ExitPartialSuccess
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/constants"
+	"ralph/internal/shared/testgit"
+	"ralph/internal/shared/workdir"
+)
+
+func writeQueue(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "backlog.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunQueueRunsEachPromptWithItsOwnPRD(t *testing.T) {
+	repo := t.TempDir()
+	testgit.InitRepo(t, repo)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = repo
+
+	type call struct{ prompt, prdFile, branch string }
+	var calls []call
+	c := &Coordinator{runHeadless: func(entryCfg *config.Config, prompt string, resume bool) int {
+		branch, _ := workdir.CurrentBranchName(repo)
+		calls = append(calls, call{prompt, entryCfg.PRDFile, branch})
+		if resume {
+			t.Errorf("queue prompt %q should not resume", prompt)
+		}
+		// Each run leaves the repo on its own feature branch.
+		cmd := exec.Command("git", "checkout", "-q", "-b", "feature/"+entryCfg.PRDFile)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git checkout: %v\n%s", err, out)
+		}
+		if prompt == "add search" {
+			return constants.ExitPartialSuccess
+		}
+		return 0
+	}}
+
+	code := c.runQueue(cfg, writeQueue(t, "add dark mode", "", "# later", "add search"), false)
+
+	if code != constants.ExitPartialSuccess {
+		t.Errorf("runQueue() = %d, want %d", code, constants.ExitPartialSuccess)
+	}
+	want := []call{{"add dark mode", "prd-1.json", "main"}, {"add search", "prd-2.json", "main"}}
+	if len(calls) != len(want) {
+		t.Fatalf("runs = %+v, want %+v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("run %d = %+v, want %+v", i+1, calls[i], want[i])
+		}
+	}
+	if cfg.PRDFile != "prd.json" {
+		t.Errorf("cfg.PRDFile = %q, queue should not change the base config", cfg.PRDFile)
+	}
+}
+
+func TestRunQueueFailFastStopsAtFirstFailure(t *testing.T) {
+	repo := t.TempDir()
+	testgit.InitRepo(t, repo)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = repo
+	queue := writeQueue(t, "one", "two", "three")
+
+	for _, tt := range []struct {
+		failFast bool
+		wantRuns int
+	}{{true, 1}, {false, 3}} {
+		runs := 0
+		c := &Coordinator{runHeadless: func(*config.Config, string, bool) int {
+			runs++
+			return 1
+		}}
+		if code := c.runQueue(cfg, queue, tt.failFast); code != 1 {
+			t.Errorf("failFast=%v: runQueue() = %d, want 1", tt.failFast, code)
+		}
+		if runs != tt.wantRuns {
+			t.Errorf("failFast=%v: ran %d prompts, want %d", tt.failFast, runs, tt.wantRuns)
+		}
+	}
+}
+
+func TestRunQueueRefusesExistingPRD(t *testing.T) {
+	repo := t.TempDir()
+	testgit.InitRepo(t, repo)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = repo
+	if err := os.WriteFile(filepath.Join(repo, "prd-1.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var prompts []string
+	c := &Coordinator{runHeadless: func(_ *config.Config, prompt string, _ bool) int {
+		prompts = append(prompts, prompt)
+		return 0
+	}}
+	if code := c.runQueue(cfg, writeQueue(t, "one", "two"), false); code != 1 {
+		t.Errorf("runQueue() = %d, want 1", code)
+	}
+	if len(prompts) != 1 || prompts[0] != "two" {
+		t.Errorf("ran %q, want only the prompt without an existing PRD", prompts)
+	}
+}
+
+func TestQueueLines(t *testing.T) {
+	entries := []queueEntry{
+		{prompt: "one", prdFile: "prd-1.json", ran: true},
+		{prompt: "two", prdFile: "prd-2.json", ran: true, code: constants.ExitInterrupted},
+		{prompt: "three", prdFile: "prd-3.json"},
+	}
+	if got, want := queueEntryLine(1, 3, &entries[0]), `Queue 1/3 completed: "one" (prd-1.json)`; got != want {
+		t.Errorf("queueEntryLine() = %q, want %q", got, want)
+	}
+	if got, want := queueSummaryLine(entries), "Queue finished: 3 prompts, 1 completed, 0 partial, 0 failed, 1 interrupted, 1 not run"; got != want {
+		t.Errorf("queueSummaryLine() = %q, want %q", got, want)
+	}
+	if got := queueExitCode(entries); got != constants.ExitInterrupted {
+		t.Errorf("queueExitCode() = %d, want %d", got, constants.ExitInterrupted)
+	}
+	if got := queuePRDFile("plan.json", 4); got != "plan-4.json" {
+		t.Errorf("queuePRDFile() = %q, want plan-4.json", got)
+	}
+	if _, err := readQueue(writeQueue(t, "", "# only comments")); err == nil {
+		t.Error("readQueue() should reject a queue without prompts")
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/runner"
+)
+
+var pickableRunners = []config.RunnerKind{
+	config.RunnerClaude,
+	config.RunnerOpenCode,
+	config.RunnerCursor,
+	config.RunnerPi,
+	config.RunnerCopilot,
+}
+
+func commandOnPath(command string) bool {
+	_, err := exec.LookPath(command)
+	return err == nil
+}
+
+// maybePickRunner offers a one-time runner selection for --interactive-runner-pick
+// and saves the choice to ralph.config.json. It is a no-op without a terminal
+// or when the runner is already set by env or config file.
+func maybePickRunner(cfg *config.Config, interactive bool, in io.Reader, out io.Writer, installed func(string) bool) error {
+	if !interactive || config.RunnerConfigured(cfg.WorkDir) {
+		return nil
+	}
+	choice, err := pickRunner(in, out, installed)
+	if err != nil || choice == "" {
+		return err
+	}
+	if err := config.SaveRunner(cfg.WorkDir, choice); err != nil {
+		return fmt.Errorf("saving runner choice: %w", err)
+	}
+	cfg.Runner = choice
+	fmt.Fprintf(out, "Saved runner %q to %s\n", choice, config.FileName)
+	return nil
+}
+
+func pickRunner(in io.Reader, out io.Writer, installed func(string) bool) (string, error) {
+	var available []string
+	for _, kind := range pickableRunners {
+		command := runner.New(&config.Config{Runner: string(kind)}, clock.Real{}).CommandName()
+		if installed(command) {
+			available = append(available, string(kind))
+		}
+	}
+	if len(available) == 0 {
+		fmt.Fprintln(out, "No supported runner CLI found on PATH; keeping the default runner.")
+		return "", nil
+	}
+
+	fmt.Fprintln(out, "Select an AI runner:")
+	for i, name := range available {
+		fmt.Fprintf(out, "  %d) %s\n", i+1, name)
+	}
+	fmt.Fprintf(out, "Choice [1]: ")
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("reading runner choice: %w", err)
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return available[0], nil
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil || n < 1 || n > len(available) {
+		return "", fmt.Errorf("invalid runner choice %q", line)
+	}
+	return available[n-1], nil
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+)
+
+func fakeInstalled(commands ...string) func(string) bool {
+	return func(command string) bool {
+		for _, c := range commands {
+			if c == command {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestMaybePickRunnerWritesChoiceToConfig(t *testing.T) {
+	t.Setenv("RALPH_RUNNER", "")
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	var out bytes.Buffer
+
+	err := maybePickRunner(cfg, true, strings.NewReader("2\n"), &out, fakeInstalled("claude", "cursor-agent"))
+	if err != nil {
+		t.Fatalf("maybePickRunner() error = %v", err)
+	}
+	if cfg.Runner != "cursor" {
+		t.Errorf("cfg.Runner = %q, want cursor", cfg.Runner)
+	}
+	if strings.Contains(out.String(), "opencode") {
+		t.Errorf("menu listed an uninstalled runner:\n%s", out.String())
+	}
+	data, err := os.ReadFile(filepath.Join(cfg.WorkDir, config.FileName))
+	if err != nil {
+		t.Fatalf("config file not written: %v", err)
+	}
+	if !strings.Contains(string(data), `"runner": "cursor"`) {
+		t.Errorf("config file = %s, want runner cursor", data)
+	}
+}
+
+func TestMaybePickRunnerSkips(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		envRunner   string
+	}{
+		{name: "non-interactive", interactive: false},
+		{name: "runner already set", interactive: true, envRunner: "pi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RALPH_RUNNER", tt.envRunner)
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = t.TempDir()
+
+			err := maybePickRunner(cfg, tt.interactive, strings.NewReader("1\n"), &bytes.Buffer{}, fakeInstalled("opencode"))
+			if err != nil {
+				t.Fatalf("maybePickRunner() error = %v", err)
+			}
+			if cfg.Runner != config.DefaultRunner {
+				t.Errorf("cfg.Runner = %q, want unchanged default", cfg.Runner)
+			}
+			if _, err := os.Stat(filepath.Join(cfg.WorkDir, config.FileName)); !os.IsNotExist(err) {
+				t.Errorf("config file should not be written, stat err = %v", err)
+			}
+		})
+	}
+}
+
+func TestPickRunnerRejectsOutOfRangeChoice(t *testing.T) {
+	_, err := pickRunner(strings.NewReader("9\n"), &bytes.Buffer{}, fakeInstalled("claude"))
+	if err == nil {
+		t.Fatal("pickRunner() error = nil, want invalid choice error")
+	}
+}
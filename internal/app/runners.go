@@ -0,0 +1,120 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/runner"
+)
+
+type taskSize string
+
+const (
+	taskSmall  taskSize = "small"
+	taskMedium taskSize = "medium"
+	taskLarge  taskSize = "large"
+)
+
+// largeTaskHints are words that usually mean a change spans many files or
+// needs sustained multi-step planning.
+var largeTaskHints = []string{
+	"architecture", "redesign", "rewrite", "migrate", "migration", "refactor",
+	"across", "multiple", "system", "framework", "end-to-end", "overhaul",
+}
+
+var smallTaskHints = []string{
+	"typo", "rename", "tweak", "bump", "small", "single", "one-line",
+}
+
+// classifyTask is a static heuristic over the task description: explicit
+// hints win, otherwise the description length decides.
+func classifyTask(task string) taskSize {
+	lower := strings.ToLower(task)
+	for _, hint := range largeTaskHints {
+		if strings.Contains(lower, hint) {
+			return taskLarge
+		}
+	}
+	for _, hint := range smallTaskHints {
+		if strings.Contains(lower, hint) {
+			return taskSmall
+		}
+	}
+	switch words := len(strings.Fields(lower)); {
+	case words <= 8:
+		return taskSmall
+	case words >= 40:
+		return taskLarge
+	default:
+		return taskMedium
+	}
+}
+
+// recommendRunner suggests a runner for a task without calling any backend.
+func recommendRunner(task string) (taskSize, config.RunnerKind, string) {
+	switch size := classifyTask(task); size {
+	case taskSmall:
+		return size, config.RunnerPi, "lightweight agent loop that starts fast; enough for a focused, few-file change"
+	case taskLarge:
+		return size, config.RunnerClaude, "strongest at long multi-step sessions and large context; worth the slower, costlier runs"
+	default:
+		return size, config.RunnerClaude, "the default runner; a balanced choice for a typical feature"
+	}
+}
+
+func writeRunnerRecommendation(out io.Writer, task string) {
+	size, kind, rationale := recommendRunner(task)
+	fmt.Fprintf(out, "Task size: %s\n", size)
+	fmt.Fprintf(out, "Recommended runner: %s (RALPH_RUNNER=%s)\n", kind, kind)
+	fmt.Fprintf(out, "Why: %s\n", rationale)
+}
+
+// writeRunnerList prints every supported runner with its binary, grouped by
+// whether that binary is on PATH, and marks the default.
+func writeRunnerList(out io.Writer, installed func(string) bool) {
+	names := make([]string, 0, len(pickableRunners)+2)
+	for _, kind := range pickableRunners {
+		names = append(names, string(kind))
+	}
+	names = append(names, config.OllamaRunnerPrefix+"<model>", config.GeminiRunnerPrefix+"<model>")
+
+	var onPath, missing []string
+	for _, name := range names {
+		command := runner.New(&config.Config{Runner: name}, clock.Real{}).CommandName()
+		line := fmt.Sprintf("  %-14s %s", name, command)
+		if name == config.DefaultRunner {
+			line += " (default)"
+		}
+		if installed(command) {
+			onPath = append(onPath, line)
+		} else {
+			missing = append(missing, line)
+		}
+	}
+	for _, group := range []struct {
+		title string
+		lines []string
+	}{{"Installed:", onPath}, {"Not on PATH:", missing}} {
+		if len(group.lines) == 0 {
+			continue
+		}
+		fmt.Fprintln(out, group.title)
+		for _, line := range group.lines {
+			fmt.Fprintln(out, line)
+		}
+	}
+	fmt.Fprintln(out, "Select one with RALPH_RUNNER=<name> or \"runner\" in ralph.config.json.")
+}
+
+func runRunners(task string) int {
+	if task == "" {
+		writeRunnerList(os.Stdout, commandOnPath)
+		return 0
+	}
+	writeRunnerRecommendation(os.Stdout, task)
+	return 0
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+)
+
+func TestRecommendRunner(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     string
+		wantSize taskSize
+		want     config.RunnerKind
+	}{
+		{name: "small task gets faster runner", task: "fix typo in README", wantSize: taskSmall, want: config.RunnerPi},
+		{name: "short description is small", task: "add a --quiet flag", wantSize: taskSmall, want: config.RunnerPi},
+		{name: "large task gets stronger runner", task: "migrate the storage layer to sqlite across all services", wantSize: taskLarge, want: config.RunnerClaude},
+		{name: "medium task gets default runner", task: "add pagination to the orders endpoint and return the total count in a response header", wantSize: taskMedium, want: config.RunnerClaude},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, kind, rationale := recommendRunner(tt.task)
+			if size != tt.wantSize {
+				t.Errorf("size = %s, want %s", size, tt.wantSize)
+			}
+			if kind != tt.want {
+				t.Errorf("runner = %s, want %s", kind, tt.want)
+			}
+			if rationale == "" {
+				t.Error("rationale should not be empty")
+			}
+		})
+	}
+}
+
+func TestWriteRunnerRecommendation(t *testing.T) {
+	var out bytes.Buffer
+	writeRunnerRecommendation(&out, "rewrite the auth system")
+
+	for _, want := range []string{"Task size: large", "Recommended runner: claude (RALPH_RUNNER=claude)", "Why: "} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWriteRunnerListGroupsByInstalledAndMarksDefault(t *testing.T) {
+	var out bytes.Buffer
+	writeRunnerList(&out, func(command string) bool { return command == "claude" || command == "pi" })
+
+	got := out.String()
+	installed := strings.Index(got, "Installed:")
+	missing := strings.Index(got, "Not on PATH:")
+	if installed < 0 || missing < installed {
+		t.Fatalf("output should list installed runners before missing ones:\n%s", got)
+	}
+	for _, want := range []string{"claude         claude (default)", "pi             pi", "cursor         cursor-agent", "ollama/<model> ollama", "gemini/<model> gemini"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "cursor") < missing || strings.Index(got, "pi ") > missing {
+		t.Fatalf("runners are grouped under the wrong heading:\n%s", got)
+	}
+	if strings.Count(got, "(default)") != 1 {
+		t.Fatalf("exactly one runner should be marked default:\n%s", got)
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/logger"
+	sharedprd "ralph/internal/shared/prd"
+	"ralph/internal/shared/workdir"
+)
+
+// enterWorktree points cfg at the RALPH_USE_WORKTREE checkout so the runner,
+// the PRD and every commit stay out of the user's own checkout. The worktree
+// is created on first use (on the PRD branch when a PRD already names one,
+// otherwise on a detached HEAD), and the PRD is copied in when it is missing
+// there. The returned func removes the worktree once every story has passed,
+// copying the finished PRD back first; an unfinished run keeps it for
+// --resume.
+func enterWorktree(cfg *config.Config) (func(), error) {
+	mainDir, err := filepath.Abs(cfg.WorkDir)
+	if err != nil {
+		return nil, err
+	}
+	mainCfg := *cfg
+	mainCfg.WorkDir = mainDir
+	path := workdir.WorktreePath(mainDir)
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		branch := ""
+		if p, err := sharedprd.Load(&mainCfg); err == nil {
+			branch = workdir.SanitizeBranchName(p.BranchName)
+		}
+		if err := workdir.CreateWorktree(mainDir, branch, path); err != nil {
+			return nil, fmt.Errorf("creating worktree: %w", err)
+		}
+		fmt.Printf("Running in worktree %s\n", path)
+	} else if err != nil {
+		return nil, err
+	}
+
+	cfg.WorkDir = path
+	if err := copyPRDIfMissing(mainCfg.PRDPath(), cfg.PRDPath()); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		p, err := sharedprd.Load(cfg)
+		if err != nil || !p.AllCompleted() {
+			return
+		}
+		if err := sharedprd.Save(&mainCfg, p); err != nil {
+			logger.Warn("failed to copy PRD out of worktree; keeping it", "path", path, "error", err)
+			return
+		}
+		if err := workdir.RemoveWorktree(mainDir, path); err != nil {
+			logger.Warn("failed to remove worktree", "path", path, "error", err)
+			return
+		}
+		fmt.Printf("Removed worktree %s; commits are on %s\n", path, p.BranchName)
+	}, nil
+}
+
+func copyPRDIfMissing(from, to string) error {
+	if _, err := os.Stat(to); err == nil {
+		return nil
+	}
+	data, err := os.ReadFile(from)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading PRD %s: %w", from, err)
+	}
+	if err := os.WriteFile(to, data, 0o644); err != nil {
+		return fmt.Errorf("copying PRD into worktree: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"os"
+	"testing"
+
+	"ralph/internal/shared/config"
+	sharedprd "ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+	"ralph/internal/shared/testgit"
+	"ralph/internal/shared/workdir"
+)
+
+func TestEnterWorktree(t *testing.T) {
+	repo := t.TempDir()
+	testgit.InitRepo(t, repo)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = repo
+	cfg.PRDFile = "prd.json"
+	p := &sharedprd.PRD{ProjectName: "Isolated", BranchName: "feature/isolated", Stories: []*sharedprd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Priority: 1, Slices: prdtest.Slices("works")},
+	}}
+	if err := sharedprd.Save(cfg, p); err != nil {
+		t.Fatal(err)
+	}
+
+	leave, err := enterWorktree(cfg)
+	if err != nil {
+		t.Fatalf("enterWorktree() error = %v", err)
+	}
+	path := workdir.WorktreePath(repo)
+	if cfg.WorkDir != path {
+		t.Fatalf("cfg.WorkDir = %q, want worktree %q", cfg.WorkDir, path)
+	}
+	if got, err := workdir.CurrentBranchName(path); err != nil || got != "feature/isolated" {
+		t.Fatalf("worktree branch = %q, %v, want the PRD branch", got, err)
+	}
+	inWorktree, err := sharedprd.Load(cfg)
+	if err != nil {
+		t.Fatalf("PRD was not copied into the worktree: %v", err)
+	}
+
+	leave()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("unfinished run removed its worktree: %v", err)
+	}
+
+	inWorktree.Stories[0].Passes = true
+	for _, sl := range inWorktree.Stories[0].Slices {
+		sl.Passes = true
+	}
+	if err := sharedprd.Save(cfg, inWorktree); err != nil {
+		t.Fatal(err)
+	}
+	leave()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("completed run kept its worktree: %v", err)
+	}
+	mainCfg := *cfg
+	mainCfg.WorkDir = repo
+	finished, err := sharedprd.Load(&mainCfg)
+	if err != nil || !finished.AllCompleted() {
+		t.Fatalf("main PRD = %+v, %v, want the completed PRD copied back", finished, err)
+	}
+}
@@ -7,24 +7,66 @@ import (
 )
 
 type Options struct {
-	Prompt       string
-	DryRun       bool
-	Resume       bool
-	Verbose      bool
-	Help         bool
-	Status       bool
-	Clean        bool
-	Version      bool
-	Update       bool
-	UpdateRef    string
-	UpdateCheck  bool
-	Web          bool
-	WebPort      int
-	SkipCleanup  bool
-	Yolo         bool
-	AutoApprove  bool
-	Headless     bool
-	UnknownFlags []string
+	Prompt              string
+	DryRun              bool
+	Resume              bool
+	ResumePRD           string
+	Verbose             bool
+	Debug               bool
+	ShowInternal        bool
+	Help                bool
+	Status              bool
+	StatusOneline       bool
+	ASCII               bool
+	Clean               bool
+	Force               bool
+	LockStatus          bool
+	History             bool
+	ShowConfig          bool
+	ValidatePRD         bool
+	Runners             bool
+	RecommendTask       string
+	Version             bool
+	Update              bool
+	UpdateRef           string
+	UpdateCheck         bool
+	Web                 bool
+	WebPort             int
+	SkipCleanup         bool
+	NoBranch            bool
+	NoCommit            bool
+	Append              bool
+	Yolo                bool
+	AutoApprove         bool
+	Headless            bool
+	RawOutput           bool
+	JSON                bool
+	NormalizePriorities bool
+	DiffContext         bool
+	PickRunner          bool
+	Runner              string
+	Model               string
+	EnvFile             string
+	ConfigFile          string
+	PromptFile          string
+	WorkDir             string
+	OutputDir           string
+	FromSpec            string
+	QueueFile           string
+	FailFast            bool
+	Skip                []string
+	Rerun               []string
+	RerunDependents     bool
+	OpenEditor          bool
+	ScaffoldTests       bool
+	BestEffort          bool
+	NoColor             bool
+	Spinner             string
+	Format              string
+	MaxIterations       int
+	MaxStories          int
+	RetryAttempts       int
+	UnknownFlags        []string
 }
 
 const defaultWebPort = 8080
@@ -65,16 +107,163 @@ func Parse(args []string) *Options {
 			opts.Resume = true
 		case "--verbose", "-v":
 			opts.Verbose = true
+		case "--debug":
+			opts.Debug = true
+			opts.Verbose = true
+		case "--show-internal":
+			opts.ShowInternal = true
 		case "--skip-cleanup":
 			opts.SkipCleanup = true
+		case "--force":
+			opts.Force = true
+		case "--no-branch":
+			opts.NoBranch = true
+		case "--no-commit":
+			opts.NoCommit = true
+		case "--append":
+			opts.Append = true
 		case "--yolo":
 			opts.Yolo = true
 			opts.AutoApprove = true
 		case "--headless":
 			opts.Headless = true
 			opts.AutoApprove = true
+		case "--raw-output":
+			opts.RawOutput = true
+		case "--json":
+			opts.JSON = true
+		case "--normalize-priorities":
+			opts.NormalizePriorities = true
+		case "--diff-context":
+			opts.DiffContext = true
+		case "--interactive-runner-pick":
+			opts.PickRunner = true
+		case "--open-editor":
+			opts.OpenEditor = true
+		case "--no-color":
+			opts.NoColor = true
+		case "--best-effort", "--continue-on-failure":
+			opts.BestEffort = true
+		case "--scaffold-tests":
+			opts.ScaffoldTests = true
+		case "--env-file":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.EnvFile = args[i+1]
+			i++
+		case "--runner":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.Runner = args[i+1]
+			i++
+		case "--model":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.Model = args[i+1]
+			i++
+		case "--config":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.ConfigFile = args[i+1]
+			i++
+		case "--prompt-file":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.PromptFile = args[i+1]
+			i++
+		case "--work-dir":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.WorkDir = args[i+1]
+			i++
+		case "--output-dir":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.OutputDir = args[i+1]
+			i++
+		case "--format":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.Format = args[i+1]
+			i++
+		case "--skip":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.Skip = append(opts.Skip, args[i+1])
+			i++
+		case "--rerun":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.Rerun = append(opts.Rerun, args[i+1])
+			i++
+		case "--stories":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.MaxStories = parsePositiveInt(args[i+1])
+			i++
+		case "--rerun-dependents":
+			opts.RerunDependents = true
+		case "--from-spec":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.FromSpec = args[i+1]
+			i++
+		case "--queue":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.QueueFile = args[i+1]
+			i++
+		case "--fail-fast":
+			opts.FailFast = true
 		case "status":
 			opts.Status = true
+		case "--oneline":
+			opts.StatusOneline = true
+		case "--ascii":
+			opts.ASCII = true
+		case "runners":
+			opts.Runners = true
+		case "--recommend":
+			if i+1 >= len(args) {
+				opts.UnknownFlags = append(opts.UnknownFlags, arg)
+				continue
+			}
+			opts.RecommendTask = args[i+1]
+			i++
+		case "lock-status":
+			opts.LockStatus = true
+		case "history":
+			opts.History = true
+		case "config":
+			opts.ShowConfig = true
+		case "validate":
+			opts.ValidatePRD = true
 		case "clean":
 			opts.Clean = true
 		case "version":
@@ -99,7 +288,13 @@ func Parse(args []string) *Options {
 			opts.WebPort = port
 			i++
 		default:
-			if strings.HasPrefix(arg, "-") {
+			if value, ok := strings.CutPrefix(arg, "--spinner="); ok {
+				opts.Spinner = value
+			} else if value, ok := strings.CutPrefix(arg, "--max-iterations="); ok {
+				opts.MaxIterations = parsePositiveInt(value)
+			} else if value, ok := strings.CutPrefix(arg, "--retry-attempts="); ok {
+				opts.RetryAttempts = parsePositiveInt(value)
+			} else if strings.HasPrefix(arg, "-") {
 				opts.UnknownFlags = append(opts.UnknownFlags, arg)
 			} else {
 				promptParts = append(promptParts, arg)
@@ -107,11 +302,39 @@ func Parse(args []string) *Options {
 		}
 	}
 
+	// A lone .json argument to --resume names the PRD to resume rather
+	// than a prompt.
+	if opts.Resume && len(promptParts) == 1 && strings.HasSuffix(promptParts[0], ".json") {
+		opts.ResumePRD = promptParts[0]
+		promptParts = nil
+	}
 	opts.Prompt = strings.Join(promptParts, " ")
 	return opts
 }
 
+// parsePositiveInt returns -1 for anything but a positive integer so Validate
+// can tell an invalid value from an absent flag.
+func parsePositiveInt(value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return -1
+	}
+	return n
+}
+
 func (o *Options) Validate() error {
+	if o.PromptFile != "" {
+		switch {
+		case o.Prompt != "":
+			return fmt.Errorf("--prompt-file cannot be used with a prompt argument")
+		case o.Resume:
+			return fmt.Errorf("--prompt-file cannot be used with --resume")
+		case o.FromSpec != "":
+			return fmt.Errorf("--prompt-file cannot be used with --from-spec")
+		case o.Web:
+			return fmt.Errorf("--prompt-file cannot be used with web")
+		}
+	}
 	if o.Headless {
 		switch {
 		case o.Yolo:
@@ -121,10 +344,130 @@ func (o *Options) Validate() error {
 		case o.Web:
 			return fmt.Errorf("--headless cannot be used with web")
 		}
-		if !o.Resume && o.Prompt == "" {
-			return fmt.Errorf("--headless requires a prompt or --resume")
+		if !o.Resume && o.Prompt == "" && o.PromptFile == "" && o.FromSpec == "" && o.QueueFile == "" {
+			return fmt.Errorf("--headless requires a prompt, --resume, --from-spec, or --queue")
+		}
+	}
+	if o.QueueFile != "" {
+		switch {
+		case !o.Headless:
+			return fmt.Errorf("--queue requires --headless")
+		case o.Prompt != "" || o.PromptFile != "":
+			return fmt.Errorf("--queue cannot be used with a prompt")
+		case o.Resume:
+			return fmt.Errorf("--queue cannot be used with --resume")
+		case o.FromSpec != "":
+			return fmt.Errorf("--queue cannot be used with --from-spec")
+		case o.OpenEditor:
+			return fmt.Errorf("--queue cannot be used with --open-editor")
+		}
+	}
+	if o.FailFast && o.QueueFile == "" {
+		return fmt.Errorf("--fail-fast requires --queue")
+	}
+	if o.DryRun {
+		switch {
+		case o.SkipCleanup:
+			return fmt.Errorf("--dry-run cannot be used with --skip-cleanup")
+		case o.NoBranch:
+			return fmt.Errorf("--dry-run cannot be used with --no-branch")
+		case o.ScaffoldTests:
+			return fmt.Errorf("--dry-run cannot be used with --scaffold-tests")
+		case o.BestEffort:
+			return fmt.Errorf("--dry-run cannot be used with --best-effort")
+		case len(o.Skip) > 0:
+			return fmt.Errorf("--dry-run cannot be used with --skip")
+		case len(o.Rerun) > 0:
+			return fmt.Errorf("--dry-run cannot be used with --rerun")
 		}
 	}
+	if o.FromSpec != "" {
+		switch {
+		case o.Resume:
+			return fmt.Errorf("--from-spec cannot be used with --resume")
+		case o.Prompt != "":
+			return fmt.Errorf("--from-spec cannot be used with a prompt")
+		case o.Web:
+			return fmt.Errorf("--from-spec cannot be used with web")
+		}
+	}
+	if len(o.Skip) > 0 && !o.Resume && o.FromSpec == "" {
+		return fmt.Errorf("--skip requires --resume or --from-spec")
+	}
+	if len(o.Rerun) > 0 && !o.Resume {
+		return fmt.Errorf("--rerun requires --resume")
+	}
+	if o.RerunDependents && len(o.Rerun) == 0 {
+		return fmt.Errorf("--rerun-dependents requires --rerun")
+	}
+	if o.Append && (o.Resume || o.FromSpec != "") {
+		return fmt.Errorf("--append cannot be used with --resume or --from-spec")
+	}
+	if o.PickRunner && o.ConfigFile != "" {
+		return fmt.Errorf("--interactive-runner-pick cannot be used with --config")
+	}
+	if o.PickRunner && o.Runner != "" {
+		return fmt.Errorf("--interactive-runner-pick cannot be used with --runner")
+	}
+	if o.PickRunner && o.Model != "" {
+		return fmt.Errorf("--interactive-runner-pick cannot be used with --model")
+	}
+	if o.OpenEditor && o.Resume {
+		return fmt.Errorf("--open-editor cannot be used with --resume")
+	}
+	if o.Spinner != "" && (o.Headless || o.Web) {
+		return fmt.Errorf("--spinner only applies to the TUI")
+	}
+	if o.RawOutput && !o.Headless {
+		return fmt.Errorf("--raw-output requires --headless")
+	}
+	if o.JSON && !o.Headless {
+		return fmt.Errorf("--json requires --headless")
+	}
+	if o.JSON && o.RawOutput {
+		return fmt.Errorf("--json cannot be used with --raw-output")
+	}
+	if o.Debug && o.RawOutput {
+		return fmt.Errorf("--debug cannot be used with --raw-output")
+	}
+	if o.OpenEditor && !o.Headless {
+		return fmt.Errorf("--open-editor requires --headless")
+	}
+	switch o.Spinner {
+	case "", "off", "slow", "fast":
+	default:
+		return fmt.Errorf("--spinner must be off, slow, or fast")
+	}
+	if o.MaxIterations < 0 {
+		return fmt.Errorf("--max-iterations must be a positive integer")
+	}
+	if o.RetryAttempts < 0 {
+		return fmt.Errorf("--retry-attempts must be a positive integer")
+	}
+	if o.MaxStories < 0 {
+		return fmt.Errorf("--stories must be a positive integer")
+	}
+	if o.MaxStories > 0 && (o.Resume || o.FromSpec != "") {
+		return fmt.Errorf("--stories cannot be used with --resume or --from-spec")
+	}
+	switch o.Format {
+	case "", "json":
+	case "md":
+		if !o.DryRun {
+			return fmt.Errorf("--format md requires --dry-run")
+		}
+	default:
+		return fmt.Errorf("--format must be json or md")
+	}
+	if o.RecommendTask != "" && !o.Runners {
+		return fmt.Errorf("--recommend requires runners")
+	}
+	if o.Force && !o.Clean {
+		return fmt.Errorf("--force requires clean")
+	}
+	if (o.StatusOneline || o.ASCII) && !o.Status {
+		return fmt.Errorf("--oneline and --ascii require status")
+	}
 	if o.AutoApprove {
 		switch {
 		case o.DryRun:
@@ -141,7 +484,7 @@ func (o *Options) Validate() error {
 			return fmt.Errorf("--yolo cannot be used with update")
 		}
 	}
-	if o.Help || o.Status || o.Clean || o.Version || o.Update || o.Web {
+	if o.Help || o.Status || o.LockStatus || o.History || o.ValidatePRD || o.Clean || o.Version || o.Update || o.Web || o.Runners {
 		return nil
 	}
 	if len(o.UnknownFlags) > 0 {
@@ -157,33 +500,103 @@ Usage:
   ralph                                              # TUI prompt screen (requires a terminal)
   ralph "your feature description"                   # TUI mode
   ralph --headless "your feature description"        # Unattended yolo mode without the TUI
+  ralph --headless --queue backlog.txt [--fail-fast] # Run each line as its own prompt, one after another
   ralph "your feature description" --dry-run         # Generate PRD only
   ralph --dry-run                                    # Prompt in TUI, then generate PRD only
   ralph --resume                                     # Resume from existing prd.json
+  ralph --resume path/to/other-prd.json              # Resume from another PRD file inside the work dir
+  ralph --from-spec spec.md [--dry-run]              # Build prd.json from a markdown spec, then implement it
   ralph status                                       # Show current PRD status
-  ralph clean                                        # Remove Ralph state files in the working directory
+  ralph status --oneline [--ascii]                   # Compact progress for shell prompts, e.g. "ralph: 3/5 ✓"
+  ralph lock-status                                  # JSON report of the PRD lock, its owner PID, and whether it is stale
+  ralph history                                      # List the PRDs kept in RALPH_PRD_HISTORY_DIR, oldest first
+  ralph config                                       # Print the resolved configuration and where each value came from
+  ralph validate                                     # Lint prd.json without running: exit 0 ok, 1 invalid, 2 vague stories
+  ralph runners                                      # List supported runners, their binaries, and the default
+  ralph runners --recommend "TASK"                   # Suggest a runner for a task size (static heuristic)
+  ralph clean [--force]                              # Remove Ralph state files (--force if stories are unfinished)
   ralph version                                      # Print build version and commit
   ralph update [--ref REF] [--check]                 # Install or check for updates
   ralph web [--port PORT]                            # Start local web UI (default port 8080)
 
 Options:
-  --dry-run        Generate PRD only, don't implement
-  --resume         Resume implementation from existing prd.json (--yolo auto-continues without gates)
+  --dry-run        Generate PRD only, don't implement (with --resume: load the existing prd.json only)
+  --format md      With --dry-run: also write the PRD as readable markdown to prd.md (prd.json stays the source of truth)
+  --resume [PATH]  Resume implementation from existing prd.json, or the given .json PRD (--yolo auto-continues without gates)
   --skip-cleanup   Skip post-implementation cleanup phase
+  --no-branch      Commit on the current branch instead of checking out the PRD branch
+  --no-commit      Leave story changes uncommitted for you to review; stories are still marked complete
+  --stories N      Ask for at most N stories and keep only the N highest-priority ones if the runner writes more
+  --append         Merge the newly generated stories into the existing prd.json instead of replacing it
   --yolo           Skip manual clarify and PRD approval gates (not with --dry-run or web)
   --headless       Unattended yolo mode without the TUI (--yolo plus no Bubble Tea)
+  --raw-output     With --headless: print the runner's unparsed stream to stdout
+  --json           With --headless: write the NDJSON event stream to stdout instead of stderr, without phase banners
+  --open-editor    With --headless: edit the generated prd.json in $EDITOR before implementing
+  --queue PATH     With --headless: run each non-blank line of PATH as a separate prompt, with its own prd-N.json
+  --fail-fast      With --queue: stop the batch at the first prompt that does not complete
+  --best-effort, --continue-on-failure
+                   Keep going past a story that exhausts recovery; finish with the rest (exit code 2)
+  --scaffold-tests Have the runner write failing test stubs for each story before implementing it
+  --max-iterations=N  Implementation review rounds before the run gives up (default: 8)
+  --retry-attempts=N  Recovery attempts after a failed story or review (default: 2)
+  --diff-context   Include the uncommitted diff (capped) in recovery prompts
+  --runner NAME    Use this runner for this run only, overriding RALPH_RUNNER and the config file (e.g. --runner gemini/gemini-2.5-pro)
+  --model NAME     Swap the model of an ollama/<model> or gemini/<model> runner for this run only (e.g. --model gemini-2.5-flash); other runners, Claude Code included, pick their own model and reject it
+  --interactive-runner-pick  Choose an installed runner and save it to ralph.config.json (first run, terminal only)
+  --normalize-priorities  Renumber story priorities to 1..N when the PRD is generated or loaded
+  --skip ID        With --resume or --from-spec: mark a story as skipped in prd.json (repeatable)
+  --rerun ID       With --resume: mark a completed story as not done so it runs again (repeatable)
+  --rerun-dependents  With --rerun: also rerun the stories that depend on it
+  --from-spec PATH Build prd.json from a markdown spec (# project, ## Story headings, bullet criteria, test_spec fence) instead of generating it
+  --prompt-file PATH  Read the feature description from a file instead of the command line
+  --env-file PATH  Load KEY=VALUE lines into the environment before config and runners
+  --config PATH    Read this config file (.json, or .yaml/.yml) instead of ralph.config.json/.yaml in the work dir; env vars still win
+  --work-dir PATH  Run against the project in PATH instead of the current directory
+  --output-dir PATH  Write a timestamped ralph-run-*.log of all run output into PATH
+  --spinner=MODE   TUI spinner speed: off (static glyph), slow, or fast
+  --no-color       Disable colors in the TUI and headless phase banners (also NO_COLOR)
   --verbose, -v    Enable debug logging
+  --debug          Like --verbose, plus show every raw runner stdout/stderr line unparsed and unfiltered
+  --show-internal  Show runner lines classified as internal, tagged [internal], without debug logging
   --help, -h       Show this help message
   --port PORT      Web server port (with ralph web; default 8080)
   --ref REF        Git branch or tag for ralph update (default: main)
   --check          With ralph update: compare local commit to remote; exit 2 if update available
 
 Environment:
-  RALPH_RUNNER           Select the AI runner binary (default: claude; pi, cursor, claude, opencode, copilot)
+  RALPH_RUNNER           Select the AI runner binary (default: claude; pi, cursor, claude, opencode, copilot, ollama/<model>, gemini/<model>)
   RALPH_YOLO             Set to 1 to skip manual clarify and PRD approval gates
   RALPH_BRANCH_PREFIX    Branch prefix for generated PRD branch names (default: feature)
   RALPH_DEFAULT_BRANCHES Comma-separated default branch names (default: detect from git, then main, master, develop, trunk)
   RALPH_TEST_COMMAND     Override detected project test command
+  RALPH_PRD_HISTORY_DIR  Keep a copy of every generated PRD here as prd-<unix>.json (relative to the work dir)
+  RALPH_PRD_PROMPT_FILE  text/template used instead of the built-in PRD generation prompt (default: ralph.prompt.tmpl if present)
+  RALPH_COMMIT_COAUTHOR  Set to 1 to stage prd.json in story commits and add a Co-authored-by: Ralph trailer
+  RALPH_USE_WORKTREE     Set to 1 to implement in a git worktree under .ralph/worktree, leaving your checkout untouched
+  RALPH_ROLLBACK_ON_FAIL Set to 1 to git reset --hard a failed or canceled story back to the commit it started from
+  RALPH_SIMPLE_FIRST     Set to 1 to run the simpler story first when priorities tie (see the COMPLEXITY column of ralph status)
+  RALPH_VERIFY           Set to 1 to have the model re-check a finished story's behaviors; an unconfirmed story is retried like a failed one
+  RALPH_RUNNER_TIMEOUT   Per-invocation runner timeout as a Go duration, e.g. 30m (default: unlimited)
+  RALPH_STORY_TIME_BUDGET  Wall-clock limit per story across all its sessions and retries, e.g. 45m; over it the story fails (default: 0, unlimited)
+  RALPH_EMIT_TIMEOUT     How long output waits for a slow UI before it is dropped and counted, e.g. 1s (default: 100ms; 0 drops at once)
+  RALPH_STORY_PROMPT_BUDGET  Max characters per story prompt; trims codebase context first, never slice criteria (default: unlimited)
+  RALPH_MAX_PROMPT_BYTES     Max bytes for the PRD generation prompt; truncates your request, never the instructions (default: 0 = unlimited)
+  RALPH_MAX_CONSECUTIVE_FAILURES  With --best-effort, abort after this many different stories fail in a row (default: 0, never)
+  RALPH_RETRY_BACKOFF    Base delay before each recovery attempt, doubled per attempt up to 5m (default: 0, off)
+  RALPH_REQUESTS_PER_MINUTE  Space runner sessions so each backend starts at most N per minute, shared across concurrent stories (default: 0, unlimited)
+  RALPH_CONCURRENCY      Run up to N independent stories at once (default: 1)
+  RALPH_TUI_LOG_LINES    Output lines the TUI log pane keeps for scrollback (default: 500)
+  RALPH_WEBHOOK_URL      POST a JSON summary here when a run completes or fails (5s timeout; failures only log a warning)
+  RALPH_OPEN_PR          Set to 1 to push the PRD branch and open a pull request with gh when every story is done
+  RALPH_LOG_FILE         Also write JSON-lines logs, including runner prompts, to this file (relative to the work dir)
+  RALPH_LOG_MAX_MB       Rotate RALPH_LOG_FILE to <file>.1 at this size in MB (default: 10)
+  RALPH_LOG_PROMPTS      Set to false to keep prompt contents out of RALPH_LOG_FILE (default: true)
+  RALPH_RUNNER_ENV       Comma-separated KEY=VALUE pairs added to the runner CLI's environment
+  RALPH_EVENT_SOCKET     Stream every event as NDJSON to the Unix socket listening at this path (dropped if nothing listens)
+  RALPH_LOCK_TIMEOUT     How long to wait for the PRD file lock before failing (default: 30s)
+  RALPH_LOCK_RETRY_DELAY How often to retry a busy PRD file lock (default: 100ms)
+  RALPH_RATE_LIMIT_COOLDOWN  Wait before retrying after a provider rate limit (default: 60s)
   RALPH_REPO             Git URL for ralph update (default: https://github.com/tireymorris/ralph.git)
 `
 }
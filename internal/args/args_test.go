@@ -17,16 +17,63 @@ func TestParse(t *testing.T) {
 		{name: "dry run flag", args: []string{"--dry-run"}, expected: Options{DryRun: true}},
 		{name: "yolo flag", args: []string{"--yolo"}, expected: Options{AutoApprove: true}},
 		{name: "resume flag", args: []string{"--resume"}, expected: Options{Resume: true}},
+		{name: "resume with PRD path", args: []string{"--resume", "prds/invites.json"}, expected: Options{Resume: true, ResumePRD: "prds/invites.json"}},
+		{name: "json argument without resume is a prompt", args: []string{"prds/invites.json"}, expected: Options{Prompt: "prds/invites.json"}},
 		{name: "headless flag", args: []string{"--headless", "build"}, expected: Options{Prompt: "build", Headless: true, AutoApprove: true}},
+		{name: "raw output flag", args: []string{"--headless", "--raw-output", "build"}, expected: Options{Prompt: "build", Headless: true, AutoApprove: true, RawOutput: true}},
+		{name: "normalize priorities flag", args: []string{"--normalize-priorities", "build"}, expected: Options{Prompt: "build", NormalizePriorities: true}},
+		{name: "diff context flag", args: []string{"--diff-context", "build"}, expected: Options{Prompt: "build", DiffContext: true}},
+		{name: "interactive runner pick flag", args: []string{"--interactive-runner-pick"}, expected: Options{PickRunner: true}},
+		{name: "scaffold tests flag", args: []string{"--scaffold-tests", "build"}, expected: Options{Prompt: "build", ScaffoldTests: true}},
+		{name: "no color flag", args: []string{"--no-color", "build"}, expected: Options{Prompt: "build", NoColor: true}},
+		{name: "best effort flag", args: []string{"--best-effort", "build"}, expected: Options{Prompt: "build", BestEffort: true}},
+		{name: "continue on failure alias", args: []string{"--continue-on-failure", "build"}, expected: Options{Prompt: "build", BestEffort: true}},
+		{name: "spinner flag", args: []string{"--spinner=off", "build"}, expected: Options{Prompt: "build", Spinner: "off"}},
+		{name: "lock status", args: []string{"lock-status"}, expected: Options{LockStatus: true}},
+		{name: "history", args: []string{"history"}, expected: Options{History: true}},
+		{name: "config", args: []string{"config"}, expected: Options{ShowConfig: true}},
+		{name: "validate", args: []string{"validate"}, expected: Options{ValidatePRD: true}},
+		{name: "json flag", args: []string{"--headless", "--json", "build"}, expected: Options{Prompt: "build", Headless: true, AutoApprove: true, JSON: true}},
+		{name: "format md", args: []string{"--dry-run", "--format", "md", "build"}, expected: Options{Prompt: "build", DryRun: true, Format: "md"}},
+		{name: "iteration and retry overrides", args: []string{"--max-iterations=3", "--retry-attempts=4", "build"}, expected: Options{Prompt: "build", MaxIterations: 3, RetryAttempts: 4}},
+		{name: "invalid retry attempts", args: []string{"--retry-attempts=zero", "build"}, expected: Options{Prompt: "build", RetryAttempts: -1}},
+		{name: "story cap", args: []string{"--stories", "3", "build"}, expected: Options{Prompt: "build", MaxStories: 3}},
+		{name: "invalid story cap", args: []string{"--stories", "0", "build"}, expected: Options{Prompt: "build", MaxStories: -1}},
+		{name: "repeated skip", args: []string{"--resume", "--skip", "story-2", "--skip", "story-3"}, expected: Options{Resume: true, Skip: []string{"story-2", "story-3"}}},
+		{name: "skip missing id", args: []string{"--resume", "--skip"}, expected: Options{Resume: true, UnknownFlags: []string{"--skip"}}},
+		{name: "repeated rerun with dependents", args: []string{"--resume", "--rerun", "story-1", "--rerun", "story-4", "--rerun-dependents"}, expected: Options{Resume: true, Rerun: []string{"story-1", "story-4"}, RerunDependents: true}},
+		{name: "rerun missing id", args: []string{"--resume", "--rerun"}, expected: Options{Resume: true, UnknownFlags: []string{"--rerun"}}},
+		{name: "from spec", args: []string{"--from-spec", "spec.md", "--dry-run"}, expected: Options{FromSpec: "spec.md", DryRun: true}},
+		{name: "queue", args: []string{"--headless", "--queue", "backlog.txt", "--fail-fast"}, expected: Options{Headless: true, AutoApprove: true, QueueFile: "backlog.txt", FailFast: true}},
+		{name: "runners recommend", args: []string{"runners", "--recommend", "fix typo"}, expected: Options{Runners: true, RecommendTask: "fix typo"}},
+		{name: "recommend missing value", args: []string{"runners", "--recommend"}, expected: Options{Runners: true, UnknownFlags: []string{"--recommend"}}},
+		{name: "open editor flag", args: []string{"--headless", "--open-editor", "build"}, expected: Options{Prompt: "build", Headless: true, AutoApprove: true, OpenEditor: true}},
+		{name: "prompt file flag", args: []string{"--headless", "--prompt-file", "feature.md"}, expected: Options{Headless: true, AutoApprove: true, PromptFile: "feature.md"}},
+		{name: "work dir flag", args: []string{"--work-dir", "../app", "build"}, expected: Options{WorkDir: "../app", Prompt: "build"}},
+		{name: "work dir missing value", args: []string{"--work-dir"}, expected: Options{UnknownFlags: []string{"--work-dir"}}},
+		{name: "output dir flag", args: []string{"--output-dir", "logs", "build"}, expected: Options{OutputDir: "logs", Prompt: "build"}},
+		{name: "output dir missing value", args: []string{"--output-dir"}, expected: Options{UnknownFlags: []string{"--output-dir"}}},
+		{name: "env file flag", args: []string{"--env-file", ".env", "build"}, expected: Options{Prompt: "build", EnvFile: ".env"}},
+		{name: "env file flag missing value", args: []string{"--env-file"}, expected: Options{UnknownFlags: []string{"--env-file"}}},
+		{name: "config flag", args: []string{"--config", "../shared/ralph.config.yaml", "build"}, expected: Options{Prompt: "build", ConfigFile: "../shared/ralph.config.yaml"}},
+		{name: "config flag missing value", args: []string{"--config"}, expected: Options{UnknownFlags: []string{"--config"}}},
+		{name: "runner flag", args: []string{"--runner", "ollama/llama3", "build"}, expected: Options{Prompt: "build", Runner: "ollama/llama3"}},
+		{name: "runner flag missing value", args: []string{"--runner"}, expected: Options{UnknownFlags: []string{"--runner"}}},
+		{name: "model flag", args: []string{"--model", "gemini-2.5-flash", "build"}, expected: Options{Prompt: "build", Model: "gemini-2.5-flash"}},
+		{name: "model flag missing value", args: []string{"--model"}, expected: Options{UnknownFlags: []string{"--model"}}},
 		{name: "verbose flag short", args: []string{"-v"}, expected: Options{Verbose: true}},
 		{name: "verbose flag long", args: []string{"--verbose"}, expected: Options{Verbose: true}},
+		{name: "debug implies verbose", args: []string{"--debug"}, expected: Options{Verbose: true, Debug: true}},
+		{name: "show internal", args: []string{"--show-internal"}, expected: Options{ShowInternal: true}},
 		{name: "single prompt word", args: []string{"hello"}, expected: Options{Prompt: "hello"}},
 		{name: "multi word prompt", args: []string{"hello", "world"}, expected: Options{Prompt: "hello world"}},
 		{name: "prompt with flags", args: []string{"Add", "feature", "--dry-run"}, expected: Options{Prompt: "Add feature", DryRun: true}},
 		{name: "unknown flag captured", args: []string{"--unknown", "prompt"}, expected: Options{Prompt: "prompt", UnknownFlags: []string{"--unknown"}}},
 		{name: "multiple unknown flags captured", args: []string{"--foo", "-x", "prompt", "--bar"}, expected: Options{Prompt: "prompt", UnknownFlags: []string{"--foo", "-x", "--bar"}}},
 		{name: "status command", args: []string{"status"}, expected: Options{Status: true}},
+		{name: "status oneline", args: []string{"status", "--oneline", "--ascii"}, expected: Options{Status: true, StatusOneline: true, ASCII: true}},
 		{name: "clean command", args: []string{"clean"}, expected: Options{Clean: true}},
+		{name: "clean force", args: []string{"clean", "--force"}, expected: Options{Clean: true, Force: true}},
 		{name: "version command", args: []string{"version"}, expected: Options{Version: true}},
 		{name: "update command", args: []string{"update"}, expected: Options{Update: true, UpdateRef: "main"}},
 		{name: "update with ref", args: []string{"update", "--ref", "v1.0"}, expected: Options{Update: true, UpdateRef: "v1.0"}},
@@ -35,6 +82,9 @@ func TestParse(t *testing.T) {
 		{name: "web command", args: []string{"web"}, expected: Options{Web: true, WebPort: 8080}},
 		{name: "web with port", args: []string{"web", "--port", "3000"}, expected: Options{Web: true, WebPort: 3000}},
 		{name: "skip cleanup flag", args: []string{"--skip-cleanup", "do thing"}, expected: Options{Prompt: "do thing", SkipCleanup: true}},
+		{name: "no branch flag", args: []string{"--no-branch", "do thing"}, expected: Options{Prompt: "do thing", NoBranch: true}},
+		{name: "no commit flag", args: []string{"--no-commit", "do thing"}, expected: Options{Prompt: "do thing", NoCommit: true}},
+		{name: "append flag", args: []string{"--append", "do thing"}, expected: Options{Prompt: "do thing", Append: true}},
 	}
 
 	for _, tt := range tests {
@@ -43,8 +93,8 @@ func TestParse(t *testing.T) {
 			if got.Status != tt.expected.Status {
 				t.Errorf("Status = %v, want %v", got.Status, tt.expected.Status)
 			}
-			if got.Clean != tt.expected.Clean {
-				t.Errorf("Clean = %v, want %v", got.Clean, tt.expected.Clean)
+			if got.Clean != tt.expected.Clean || got.Force != tt.expected.Force {
+				t.Errorf("Clean/Force = %v/%v, want %v/%v", got.Clean, got.Force, tt.expected.Clean, tt.expected.Force)
 			}
 			if got.Version != tt.expected.Version {
 				t.Errorf("Version = %v, want %v", got.Version, tt.expected.Version)
@@ -67,6 +117,9 @@ func TestParse(t *testing.T) {
 			if got.Resume != tt.expected.Resume {
 				t.Errorf("Resume = %v, want %v", got.Resume, tt.expected.Resume)
 			}
+			if got.ResumePRD != tt.expected.ResumePRD {
+				t.Errorf("ResumePRD = %q, want %q", got.ResumePRD, tt.expected.ResumePRD)
+			}
 			if got.Verbose != tt.expected.Verbose {
 				t.Errorf("Verbose = %v, want %v", got.Verbose, tt.expected.Verbose)
 			}
@@ -82,9 +135,90 @@ func TestParse(t *testing.T) {
 			if got.SkipCleanup != tt.expected.SkipCleanup {
 				t.Errorf("SkipCleanup = %v, want %v", got.SkipCleanup, tt.expected.SkipCleanup)
 			}
+			if got.NoBranch != tt.expected.NoBranch {
+				t.Errorf("NoBranch = %v, want %v", got.NoBranch, tt.expected.NoBranch)
+			}
+			if got.Append != tt.expected.Append {
+				t.Errorf("Append = %v, want %v", got.Append, tt.expected.Append)
+			}
 			if got.AutoApprove != tt.expected.AutoApprove {
 				t.Errorf("AutoApprove = %v, want %v", got.AutoApprove, tt.expected.AutoApprove)
 			}
+			if got.RawOutput != tt.expected.RawOutput {
+				t.Errorf("RawOutput = %v, want %v", got.RawOutput, tt.expected.RawOutput)
+			}
+			if got.PickRunner != tt.expected.PickRunner {
+				t.Errorf("PickRunner = %v, want %v", got.PickRunner, tt.expected.PickRunner)
+			}
+			if got.DiffContext != tt.expected.DiffContext {
+				t.Errorf("DiffContext = %v, want %v", got.DiffContext, tt.expected.DiffContext)
+			}
+			if got.StatusOneline != tt.expected.StatusOneline || got.ASCII != tt.expected.ASCII {
+				t.Errorf("StatusOneline/ASCII = %v/%v, want %v/%v", got.StatusOneline, got.ASCII, tt.expected.StatusOneline, tt.expected.ASCII)
+			}
+			if got.OpenEditor != tt.expected.OpenEditor {
+				t.Errorf("OpenEditor = %v, want %v", got.OpenEditor, tt.expected.OpenEditor)
+			}
+			if got.ScaffoldTests != tt.expected.ScaffoldTests {
+				t.Errorf("ScaffoldTests = %v, want %v", got.ScaffoldTests, tt.expected.ScaffoldTests)
+			}
+			if got.JSON != tt.expected.JSON {
+				t.Errorf("JSON = %v, want %v", got.JSON, tt.expected.JSON)
+			}
+			if got.MaxStories != tt.expected.MaxStories {
+				t.Errorf("MaxStories = %d, want %d", got.MaxStories, tt.expected.MaxStories)
+			}
+			if got.MaxIterations != tt.expected.MaxIterations || got.RetryAttempts != tt.expected.RetryAttempts {
+				t.Errorf("MaxIterations/RetryAttempts = %d/%d, want %d/%d", got.MaxIterations, got.RetryAttempts, tt.expected.MaxIterations, tt.expected.RetryAttempts)
+			}
+			if got.Format != tt.expected.Format {
+				t.Errorf("Format = %q, want %q", got.Format, tt.expected.Format)
+			}
+			if strings.Join(got.Skip, ",") != strings.Join(tt.expected.Skip, ",") {
+				t.Errorf("Skip = %v, want %v", got.Skip, tt.expected.Skip)
+			}
+			if got.FromSpec != tt.expected.FromSpec {
+				t.Errorf("FromSpec = %q, want %q", got.FromSpec, tt.expected.FromSpec)
+			}
+			if got.ValidatePRD != tt.expected.ValidatePRD {
+				t.Errorf("ValidatePRD = %v, want %v", got.ValidatePRD, tt.expected.ValidatePRD)
+			}
+			if got.LockStatus != tt.expected.LockStatus {
+				t.Errorf("LockStatus = %v, want %v", got.LockStatus, tt.expected.LockStatus)
+			}
+			if got.Runners != tt.expected.Runners || got.RecommendTask != tt.expected.RecommendTask {
+				t.Errorf("Runners/RecommendTask = %v/%q, want %v/%q", got.Runners, got.RecommendTask, tt.expected.Runners, tt.expected.RecommendTask)
+			}
+			if got.NoColor != tt.expected.NoColor {
+				t.Errorf("NoColor = %v, want %v", got.NoColor, tt.expected.NoColor)
+			}
+			if got.BestEffort != tt.expected.BestEffort {
+				t.Errorf("BestEffort = %v, want %v", got.BestEffort, tt.expected.BestEffort)
+			}
+			if got.Spinner != tt.expected.Spinner {
+				t.Errorf("Spinner = %q, want %q", got.Spinner, tt.expected.Spinner)
+			}
+			if got.PromptFile != tt.expected.PromptFile {
+				t.Errorf("PromptFile = %q, want %q", got.PromptFile, tt.expected.PromptFile)
+			}
+			if got.WorkDir != tt.expected.WorkDir {
+				t.Errorf("WorkDir = %q, want %q", got.WorkDir, tt.expected.WorkDir)
+			}
+			if got.EnvFile != tt.expected.EnvFile {
+				t.Errorf("EnvFile = %q, want %q", got.EnvFile, tt.expected.EnvFile)
+			}
+			if got.ConfigFile != tt.expected.ConfigFile {
+				t.Errorf("ConfigFile = %q, want %q", got.ConfigFile, tt.expected.ConfigFile)
+			}
+			if got.Runner != tt.expected.Runner {
+				t.Errorf("Runner = %q, want %q", got.Runner, tt.expected.Runner)
+			}
+			if got.Model != tt.expected.Model {
+				t.Errorf("Model = %q, want %q", got.Model, tt.expected.Model)
+			}
+			if got.NormalizePriorities != tt.expected.NormalizePriorities {
+				t.Errorf("NormalizePriorities = %v, want %v", got.NormalizePriorities, tt.expected.NormalizePriorities)
+			}
 			if len(got.UnknownFlags) != len(tt.expected.UnknownFlags) {
 				t.Errorf("UnknownFlags length = %d, want %d", len(got.UnknownFlags), len(tt.expected.UnknownFlags))
 			}
@@ -120,6 +254,24 @@ func TestValidate(t *testing.T) {
 		{name: "headless rejects dry run", opts: Options{Headless: true, DryRun: true, Prompt: "build"}, wantErr: true},
 		{name: "headless rejects web", opts: Options{Headless: true, Web: true, Prompt: "build"}, wantErr: true},
 		{name: "headless requires prompt or resume", opts: Options{Headless: true, AutoApprove: true}, wantErr: true},
+		{name: "headless with from spec", opts: Options{Headless: true, AutoApprove: true, FromSpec: "spec.md"}, wantErr: false},
+		{name: "from spec with dry run", opts: Options{FromSpec: "spec.md", DryRun: true}, wantErr: false},
+		{name: "dry run with resume loads the PRD", opts: Options{DryRun: true, Resume: true}, wantErr: false},
+		{name: "headless queue", opts: Options{Headless: true, QueueFile: "backlog.txt", FailFast: true}, wantErr: false},
+		{name: "raw output with headless is valid", opts: Options{Headless: true, AutoApprove: true, RawOutput: true, Prompt: "build"}, wantErr: false},
+		{name: "raw output requires headless", opts: Options{RawOutput: true, Prompt: "build"}, wantErr: true},
+		{name: "open editor with headless is valid", opts: Options{Headless: true, AutoApprove: true, OpenEditor: true, Prompt: "build"}, wantErr: false},
+		{name: "oneline with status is valid", opts: Options{Status: true, StatusOneline: true, ASCII: true}, wantErr: false},
+		{name: "oneline requires status", opts: Options{StatusOneline: true, Prompt: "build"}, wantErr: true},
+		{name: "spinner slow is valid", opts: Options{Spinner: "slow", Prompt: "build"}, wantErr: false},
+		{name: "unknown spinner mode", opts: Options{Spinner: "medium", Prompt: "build"}, wantErr: true},
+		{name: "headless with prompt file", opts: Options{Headless: true, PromptFile: "feature.md"}, wantErr: false},
+		{name: "validate bypasses validation", opts: Options{ValidatePRD: true, UnknownFlags: []string{"--bogus"}}, wantErr: false},
+		{name: "lock status bypasses validation", opts: Options{LockStatus: true, UnknownFlags: []string{"--bogus"}}, wantErr: false},
+		{name: "runners with recommend is valid", opts: Options{Runners: true, RecommendTask: "fix typo"}, wantErr: false},
+		{name: "runners lists without recommend", opts: Options{Runners: true}, wantErr: false},
+		{name: "recommend requires runners", opts: Options{RecommendTask: "fix typo", Prompt: "build"}, wantErr: true},
+		{name: "open editor requires headless", opts: Options{OpenEditor: true, Prompt: "build"}, wantErr: true},
 		{name: "resume without prompt is valid", opts: Options{Resume: true}, wantErr: false},
 		{name: "resume with yolo is valid", opts: Options{Resume: true, AutoApprove: true}, wantErr: false},
 		{name: "prompt provided is valid", opts: Options{Prompt: "do something"}, wantErr: false},
@@ -137,9 +289,63 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateConflictingFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{name: "dry run with skip cleanup", opts: Options{DryRun: true, SkipCleanup: true, Prompt: "build"}, want: "--dry-run cannot be used with --skip-cleanup"},
+		{name: "dry run with no branch", opts: Options{DryRun: true, NoBranch: true, Prompt: "build"}, want: "--dry-run cannot be used with --no-branch"},
+		{name: "dry run with scaffold tests", opts: Options{DryRun: true, ScaffoldTests: true, Prompt: "build"}, want: "--dry-run cannot be used with --scaffold-tests"},
+		{name: "dry run with best effort", opts: Options{DryRun: true, BestEffort: true, Prompt: "build"}, want: "--dry-run cannot be used with --best-effort"},
+		{name: "open editor with resume", opts: Options{Headless: true, AutoApprove: true, OpenEditor: true, Resume: true}, want: "--open-editor cannot be used with --resume"},
+		{name: "spinner with headless", opts: Options{Headless: true, AutoApprove: true, Spinner: "off", Prompt: "build"}, want: "--spinner only applies to the TUI"},
+		{name: "spinner with web", opts: Options{Web: true, Spinner: "off"}, want: "--spinner only applies to the TUI"},
+		{name: "json without headless", opts: Options{JSON: true, Prompt: "build"}, want: "--json requires --headless"},
+		{name: "json with raw output", opts: Options{Headless: true, AutoApprove: true, JSON: true, RawOutput: true, Prompt: "build"}, want: "--json cannot be used with --raw-output"},
+		{name: "debug with raw output", opts: Options{Headless: true, AutoApprove: true, Debug: true, Verbose: true, RawOutput: true, Prompt: "build"}, want: "--debug cannot be used with --raw-output"},
+		{name: "invalid max iterations", opts: Options{MaxIterations: -1, Prompt: "build"}, want: "--max-iterations must be a positive integer"},
+		{name: "invalid retry attempts", opts: Options{RetryAttempts: -1, Prompt: "build"}, want: "--retry-attempts must be a positive integer"},
+		{name: "invalid story cap", opts: Options{MaxStories: -1, Prompt: "build"}, want: "--stories must be a positive integer"},
+		{name: "story cap with resume", opts: Options{MaxStories: 3, Resume: true}, want: "--stories cannot be used with --resume or --from-spec"},
+		{name: "prompt file with prompt", opts: Options{PromptFile: "feature.md", Prompt: "build"}, want: "--prompt-file cannot be used with a prompt argument"},
+		{name: "prompt file with resume", opts: Options{PromptFile: "feature.md", Resume: true}, want: "--prompt-file cannot be used with --resume"},
+		{name: "skip without resume", opts: Options{Skip: []string{"story-1"}, Prompt: "build"}, want: "--skip requires --resume or --from-spec"},
+		{name: "rerun without resume", opts: Options{Rerun: []string{"story-1"}, Prompt: "build"}, want: "--rerun requires --resume"},
+		{name: "rerun dependents without rerun", opts: Options{Resume: true, RerunDependents: true}, want: "--rerun-dependents requires --rerun"},
+		{name: "dry run with skip", opts: Options{DryRun: true, Skip: []string{"story-1"}, FromSpec: "spec.md"}, want: "--dry-run cannot be used with --skip"},
+		{name: "format md without dry run", opts: Options{Format: "md", Prompt: "build"}, want: "--format md requires --dry-run"},
+		{name: "unknown format", opts: Options{Format: "html", DryRun: true, Prompt: "build"}, want: "--format must be json or md"},
+		{name: "from spec with resume", opts: Options{FromSpec: "spec.md", Resume: true}, want: "--from-spec cannot be used with --resume"},
+		{name: "queue without headless", opts: Options{QueueFile: "backlog.txt"}, want: "--queue requires --headless"},
+		{name: "queue with prompt", opts: Options{Headless: true, QueueFile: "backlog.txt", Prompt: "build"}, want: "--queue cannot be used with a prompt"},
+		{name: "queue with resume", opts: Options{Headless: true, QueueFile: "backlog.txt", Resume: true}, want: "--queue cannot be used with --resume"},
+		{name: "fail fast without queue", opts: Options{Headless: true, Prompt: "build", FailFast: true}, want: "--fail-fast requires --queue"},
+		{name: "from spec with prompt", opts: Options{FromSpec: "spec.md", Prompt: "build"}, want: "--from-spec cannot be used with a prompt"},
+		{name: "force without clean", opts: Options{Force: true, Prompt: "build"}, want: "--force requires clean"},
+		{name: "runner pick with config", opts: Options{PickRunner: true, ConfigFile: "ralph.yaml", Prompt: "build"}, want: "--interactive-runner-pick cannot be used with --config"},
+		{name: "runner pick with runner", opts: Options{PickRunner: true, Runner: "pi", Prompt: "build"}, want: "--interactive-runner-pick cannot be used with --runner"},
+		{name: "runner pick with model", opts: Options{PickRunner: true, Model: "llama3", Prompt: "build"}, want: "--interactive-runner-pick cannot be used with --model"},
+		{name: "append with resume", opts: Options{Append: true, Resume: true}, want: "--append cannot be used with --resume or --from-spec"},
+		{name: "append with from spec", opts: Options{Append: true, FromSpec: "spec.md"}, want: "--append cannot be used with --resume or --from-spec"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want %q", tt.want)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Validate() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
 func TestHelpText(t *testing.T) {
 	text := HelpText()
-	for _, phrase := range []string{"Ralph", "Usage:", "Options:", "Environment:", "RALPH_RUNNER", "RALPH_BRANCH_PREFIX", "RALPH_DEFAULT_BRANCHES", "RALPH_TEST_COMMAND", "default: claude", "copilot", "--dry-run", "--resume", "--verbose", "-v", "--help", "status", "ralph clean", "ralph version", "ralph update", "--ref", "--check", "RALPH_REPO", "ralph web", "--port", "8080", "# TUI prompt screen (requires a terminal)", "ralph --dry-run", "--skip-cleanup", "--yolo", "--headless"} {
+	for _, phrase := range []string{"Ralph", "Usage:", "Options:", "Environment:", "RALPH_RUNNER", "RALPH_BRANCH_PREFIX", "RALPH_DEFAULT_BRANCHES", "RALPH_TEST_COMMAND", "default: claude", "copilot", "--dry-run", "--resume", "ralph --resume path/to/other-prd.json", "--verbose", "-v", "--help", "status", "ralph clean [--force]", "ralph version", "ralph update", "--ref", "--check", "RALPH_REPO", "ralph web", "--port", "8080", "# TUI prompt screen (requires a terminal)", "ralph --dry-run", "--skip-cleanup", "--no-branch", "--no-commit", "--append", "--yolo", "--headless", "--raw-output", "--normalize-priorities", "--diff-context", "--interactive-runner-pick", "--runner NAME", "--model NAME", "ralph.config.json", "--env-file", "--config PATH", "--open-editor", "RALPH_RATE_LIMIT_COOLDOWN", "RALPH_LOCK_TIMEOUT", "RALPH_LOCK_RETRY_DELAY", "status --oneline", "--scaffold-tests", "--spinner=MODE", "runners --recommend", "RALPH_STORY_PROMPT_BUDGET", "RALPH_MAX_PROMPT_BYTES", "ralph lock-status", "ralph history", "ralph config", "RALPH_PRD_HISTORY_DIR", "--best-effort", "--continue-on-failure", "--queue PATH", "--fail-fast", "--no-color", "--from-spec", "RALPH_MAX_CONSECUTIVE_FAILURES", "RALPH_RUNNER_TIMEOUT", "RALPH_STORY_TIME_BUDGET", "RALPH_EMIT_TIMEOUT", "--json", "ralph runners ", "RALPH_RETRY_BACKOFF", "--format md", "--max-iterations=N", "--stories N", "--retry-attempts=N", "--skip ID", "--rerun ID", "--rerun-dependents", "ralph validate", "RALPH_WEBHOOK_URL", "RALPH_EVENT_SOCKET", "RALPH_RUNNER_ENV", "RALPH_LOG_FILE", "RALPH_LOG_MAX_MB", "RALPH_LOG_PROMPTS", "RALPH_OPEN_PR", "--prompt-file PATH", "RALPH_PRD_PROMPT_FILE", "RALPH_COMMIT_COAUTHOR", "RALPH_USE_WORKTREE", "RALPH_ROLLBACK_ON_FAIL", "RALPH_SIMPLE_FIRST", "RALPH_VERIFY", "RALPH_CONCURRENCY", "RALPH_REQUESTS_PER_MINUTE", "RALPH_TUI_LOG_LINES", "--work-dir PATH", "--output-dir PATH", "--debug", "--show-internal"} {
 		if !strings.Contains(text, phrase) {
 			t.Errorf("HelpText() missing %q", phrase)
 		}
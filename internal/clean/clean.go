@@ -2,22 +2,52 @@ package clean
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/workdir"
 )
 
-func RemoveState(cfg *config.Config) error {
+// RemoveState deletes the PRD, its lock and the other state files, plus the
+// .ralph directory (temp files included), and returns the paths that existed
+// and were removed. A RALPH_USE_WORKTREE checkout under .ralph is removed
+// through git first, so the repository keeps no stale worktree entry.
+// Missing files are not an error.
+func RemoveState(cfg *config.Config) ([]string, error) {
+	var removed []string
 	for _, path := range stateFilePaths(cfg) {
-		if err := removeIfExists(path); err != nil {
-			return err
+		ok, err := removeIfExists(path)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed = append(removed, path)
 		}
 	}
 	if err := removeOrphanedPRDTemps(cfg); err != nil {
-		return err
+		return removed, err
+	}
+	dataDir := cfg.ConfigPath(ralphDataDir)
+	exists, err := pathExists(dataDir)
+	if err != nil {
+		return removed, err
+	}
+	if !exists {
+		return removed, nil
 	}
-	return removeTree(cfg.ConfigPath(ralphDataDir))
+	worktree, err := removeWorktree(cfg)
+	if err != nil {
+		return removed, err
+	}
+	if worktree != "" {
+		removed = append(removed, worktree)
+	}
+	if err := removeTree(dataDir); err != nil {
+		return removed, err
+	}
+	return append(removed, dataDir), nil
 }
 
 func removeOrphanedPRDTemps(cfg *config.Config) error {
@@ -27,7 +57,7 @@ func removeOrphanedPRDTemps(cfg *config.Config) error {
 			return err
 		}
 		for _, path := range matches {
-			if err := removeIfExists(path); err != nil {
+			if _, err := removeIfExists(path); err != nil {
 				return err
 			}
 		}
@@ -35,6 +65,24 @@ func removeOrphanedPRDTemps(cfg *config.Config) error {
 	return nil
 }
 
+// removeWorktree runs git worktree remove on the RALPH_USE_WORKTREE checkout
+// and returns its path, or "" when there is none.
+func removeWorktree(cfg *config.Config) (string, error) {
+	workDir, err := filepath.Abs(cfg.WorkDir)
+	if err != nil {
+		return "", err
+	}
+	path := workdir.WorktreePath(workDir)
+	exists, err := pathExists(path)
+	if err != nil || !exists {
+		return "", err
+	}
+	if err := workdir.RemoveWorktree(workDir, path); err != nil {
+		return "", fmt.Errorf("removing worktree %s: %w", path, err)
+	}
+	return path, nil
+}
+
 func removeTree(path string) error {
 	err := os.RemoveAll(path)
 	if err == nil || errors.Is(err, os.ErrNotExist) {
@@ -43,10 +91,13 @@ func removeTree(path string) error {
 	return err
 }
 
-func removeIfExists(path string) error {
+func removeIfExists(path string) (bool, error) {
 	err := os.Remove(path)
-	if err == nil || errors.Is(err, os.ErrNotExist) {
-		return nil
+	if err == nil {
+		return true, nil
 	}
-	return err
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
 }
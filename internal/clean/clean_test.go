@@ -2,12 +2,16 @@ package clean
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"ralph/internal/prompt"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/prd"
+	"ralph/internal/shared/testgit"
+	"ralph/internal/shared/workdir"
 	"ralph/internal/workflow"
 )
 
@@ -112,7 +116,7 @@ func TestArchiveAndCleanStillRemoveModernRalphArtifacts(t *testing.T) {
 	writeSeedFile(t, questionsPath)
 	writeSeedFile(t, tmpPath)
 
-	if err := RemoveState(cfg); err != nil {
+	if _, err := RemoveState(cfg); err != nil {
 		t.Fatalf("RemoveState: %v", err)
 	}
 	assertNotExist(t, questionsPath)
@@ -170,7 +174,7 @@ func TestRemoveState_seededArtifacts(t *testing.T) {
 			cfg := testConfig(t, dir)
 			seedPath := tt.seed(cfg)
 			writeSeedFile(t, seedPath)
-			if err := RemoveState(cfg); err != nil {
+			if _, err := RemoveState(cfg); err != nil {
 				t.Fatalf("RemoveState: %v", err)
 			}
 			if tt.removeAssert != nil {
@@ -190,7 +194,7 @@ func TestRemoveState_allArtifacts(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := RemoveState(cfg); err != nil {
+	if _, err := RemoveState(cfg); err != nil {
 		t.Fatalf("RemoveState: %v", err)
 	}
 	for _, p := range seeded {
@@ -206,9 +210,13 @@ func TestRemoveState_idempotent(t *testing.T) {
 	if _, err := SeedStateArtifacts(cfg); err != nil {
 		t.Fatal(err)
 	}
-	for i := 1; i <= 2; i++ {
-		if err := RemoveState(cfg); err != nil {
-			t.Fatalf("RemoveState call %d: %v", i, err)
+	for i, wantRemoved := range []int{len(stateFilePaths(cfg)) + 1, 0} {
+		removed, err := RemoveState(cfg)
+		if err != nil {
+			t.Fatalf("RemoveState call %d: %v", i+1, err)
+		}
+		if len(removed) != wantRemoved {
+			t.Fatalf("RemoveState call %d removed %v, want %d paths", i+1, removed, wantRemoved)
 		}
 	}
 }
@@ -217,8 +225,37 @@ func TestRemoveState_idempotentOnEmptyDir(t *testing.T) {
 	dir := t.TempDir()
 	cfg := testConfig(t, dir)
 	for i := 1; i <= 2; i++ {
-		if err := RemoveState(cfg); err != nil {
+		if _, err := RemoveState(cfg); err != nil {
 			t.Fatalf("RemoveState call %d: %v", i, err)
 		}
 	}
 }
+
+func TestRemoveState_removesWorktreeThroughGit(t *testing.T) {
+	dir := t.TempDir()
+	testgit.InitRepo(t, dir)
+	cfg := testConfig(t, dir)
+	path := workdir.WorktreePath(dir)
+	if err := workdir.CreateWorktree(dir, "feature/isolated", path); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := RemoveState(cfg)
+	if err != nil {
+		t.Fatalf("RemoveState: %v", err)
+	}
+	if len(removed) != 2 || removed[0] != path {
+		t.Fatalf("RemoveState removed %v, want the worktree and .ralph", removed)
+	}
+	assertNotExist(t, filepath.Join(dir, ralphDataDir))
+	out, err := exec.Command("git", "-C", dir, "worktree", "list", "--porcelain").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git worktree list: %v\n%s", err, out)
+	}
+	if strings.Contains(string(out), path) {
+		t.Fatalf("git still lists the removed worktree:\n%s", out)
+	}
+	if _, err := exec.Command("git", "-C", dir, "rev-parse", "--verify", "feature/isolated").CombinedOutput(); err != nil {
+		t.Fatalf("worktree branch was deleted: %v", err)
+	}
+}
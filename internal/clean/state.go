@@ -16,6 +16,7 @@ func stateFilePaths(cfg *config.Config) []string {
 	return []string{
 		cfg.PRDPath(),
 		prd.LockPath(cfg.PRDPath()),
+		prd.MarkdownPath(cfg.PRDPath()),
 		cfg.ConfigPath(workflow.ClarifyingQuestionsFile),
 		cfg.ConfigPath(prompt.PRDSelfReviewVerdictFile),
 	}
@@ -0,0 +1,30 @@
+// Package estimate gives rough, backend-agnostic token counts for the prompts
+// ralph sends, so a dry run can show roughly how big a real run will be.
+package estimate
+
+import "unicode/utf8"
+
+// Tokenizer counts the tokens a backend would see for text. Backends
+// tokenize differently, so any count is an approximation.
+type Tokenizer interface {
+	Tokens(text string) int
+}
+
+// charsPerToken is the usual rule of thumb for English text and code.
+const charsPerToken = 4
+
+// Heuristic estimates one token per four characters, rounding up.
+type Heuristic struct{}
+
+func (Heuristic) Tokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// Sum is the total estimate for texts.
+func Sum(t Tokenizer, texts ...string) int {
+	total := 0
+	for _, text := range texts {
+		total += t.Tokens(text)
+	}
+	return total
+}
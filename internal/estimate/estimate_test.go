@@ -0,0 +1,24 @@
+package estimate
+
+import "testing"
+
+func TestHeuristicTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+		{"héllo wörld", 3},
+	}
+	for _, tt := range tests {
+		if got := (Heuristic{}).Tokens(tt.text); got != tt.want {
+			t.Errorf("Tokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+	if got := Sum(Heuristic{}, "abcd", "abcde"); got != 3 {
+		t.Errorf("Sum() = %d, want 3", got)
+	}
+}
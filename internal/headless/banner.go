@@ -0,0 +1,128 @@
+package headless
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"ralph/internal/shared/prd"
+	"ralph/internal/workflow"
+	"ralph/internal/workflow/events"
+)
+
+const summaryBarWidth = 20
+
+type runPhase struct {
+	number int
+	name   string
+}
+
+var (
+	phasePRD            = runPhase{1, "PRD"}
+	phaseImplementation = runPhase{2, "Implementation"}
+	phaseCleanup        = runPhase{3, "Cleanup"}
+)
+
+// phaseBanners writes human-readable phase headers and a final progress bar
+// alongside the NDJSON event stream, mirroring the TUI's structure.
+type phaseBanners struct {
+	w         io.Writer
+	banner    lipgloss.Style
+	done      lipgloss.Style
+	pending   lipgloss.Style
+	current   runPhase
+	total     int
+	completed int
+}
+
+func newPhaseBanners(w io.Writer, noColor bool) *phaseBanners {
+	r := lipgloss.NewRenderer(w)
+	if noColor {
+		r.SetColorProfile(termenv.Ascii)
+	}
+	return &phaseBanners{
+		w:       w,
+		banner:  r.NewStyle().Bold(true).Foreground(lipgloss.Color("#A855F7")),
+		done:    r.NewStyle().Foreground(lipgloss.Color("#10B981")),
+		pending: r.NewStyle().Foreground(lipgloss.Color("#4B5563")),
+	}
+}
+
+func (b *phaseBanners) observe(ev events.Event) {
+	switch e := ev.(type) {
+	case events.EventPRDGenerating:
+		b.enter(phasePRD)
+	case events.EventPRDGenerated:
+		b.trackPRDSize(e.PRD)
+	case events.EventPRDLoaded:
+		b.trackPRDSize(e.PRD)
+	case events.EventStoryStarted:
+		b.enter(phaseImplementation)
+	case events.EventStoryCompleted:
+		if e.Success {
+			b.completed++
+		}
+	case events.EventCleanupStarted:
+		b.enter(phaseCleanup)
+	case events.EventCompleted:
+		fmt.Fprintln(b.w, b.summaryBar(e.Unfinished))
+	case events.EventError:
+		if hint := generationErrorHint(e.Err); hint != "" {
+			fmt.Fprintln(b.w, "Hint: "+hint)
+		}
+	}
+}
+
+// generationErrorHint suggests a next step for the PRD generation failures
+// RunGenerate reports with typed errors, or "" for any other error.
+func generationErrorHint(err error) string {
+	var notGenerated *workflow.PRDNotGeneratedError
+	var invalid *prd.ValidationError
+	var loadErr *workflow.PRDLoadError
+	var empty *workflow.PRDEmptyError
+	switch {
+	case errors.As(err, &notGenerated):
+		return "re-run with a more specific prompt, or check that RALPH_RUNNER can write files in the work dir"
+	case errors.As(err, &empty):
+		return "the runner produced no output; check that it is logged in and the model is available, e.g. by running it directly"
+	case errors.As(err, &invalid):
+		return fmt.Sprintf("the runner wrote a PRD that breaks the PRD rules; fix %s and run ralph --resume --headless, or delete it and re-run", filepath.Base(invalid.Path))
+	case errors.As(err, &loadErr):
+		return fmt.Sprintf("%s is not valid PRD JSON; delete it and re-run, or try a different RALPH_RUNNER", loadErr.File)
+	}
+	return ""
+}
+
+func (b *phaseBanners) trackPRDSize(p *prd.PRD) {
+	if p == nil {
+		return
+	}
+	b.total = len(p.Stories)
+	b.completed = p.CompletedCount()
+}
+
+func (b *phaseBanners) enter(phase runPhase) {
+	if b.current == phase {
+		return
+	}
+	b.current = phase
+	fmt.Fprintln(b.w, b.banner.Render(fmt.Sprintf("── Phase %d: %s ──", phase.number, phase.name)))
+}
+
+func (b *phaseBanners) summaryBar(unfinished []string) string {
+	filled := 0
+	if b.total > 0 {
+		filled = b.completed * summaryBarWidth / b.total
+	}
+	bar := b.done.Render(strings.Repeat("█", filled)) + b.pending.Render(strings.Repeat("░", summaryBarWidth-filled))
+	line := fmt.Sprintf("%s %d/%d stories", bar, b.completed, b.total)
+	if len(unfinished) > 0 {
+		line += fmt.Sprintf(" (unfinished: %s)", strings.Join(unfinished, ", "))
+	}
+	return line
+}
@@ -0,0 +1,70 @@
+package headless
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/prd"
+	"ralph/internal/workflow"
+	"ralph/internal/workflow/events"
+)
+
+func TestPhaseBannersWrapImplementation(t *testing.T) {
+	var out bytes.Buffer
+	b := newPhaseBanners(&out, true)
+	story := &prd.Story{ID: "story-1"}
+
+	for _, ev := range []events.Event{
+		events.EventPRDGenerating{},
+		events.EventPRDGenerated{PRD: &prd.PRD{Stories: []*prd.Story{story, {ID: "story-2"}}}},
+		events.EventStoryStarted{Story: story},
+		events.EventStoryCompleted{Story: story, Success: true},
+		events.EventStoryStarted{Story: &prd.Story{ID: "story-2"}},
+		events.EventCleanupStarted{},
+		events.EventCompleted{Unfinished: []string{"story-2"}},
+	} {
+		b.observe(ev)
+	}
+
+	want := strings.Join([]string{
+		"── Phase 1: PRD ──",
+		"── Phase 2: Implementation ──",
+		"── Phase 3: Cleanup ──",
+		"██████████░░░░░░░░░░ 1/2 stories (unfinished: story-2)",
+		"",
+	}, "\n")
+	if out.String() != want {
+		t.Fatalf("banners =\n%q\nwant\n%q", out.String(), want)
+	}
+}
+
+func TestPhaseBannersHintOnGenerationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "not generated", err: &workflow.PRDNotGeneratedError{File: "prd.json"}, want: "Hint: re-run with a more specific prompt"},
+		{name: "empty", err: &workflow.PRDEmptyError{File: "prd.json"}, want: "Hint: the runner produced no output"},
+		{name: "invalid", err: &workflow.PRDLoadError{File: "prd.json", Err: &prd.ValidationError{Path: "/w/prd.json", Err: errors.New("no stories")}}, want: "Hint: the runner wrote a PRD that breaks the PRD rules; fix prd.json"},
+		{name: "unparseable", err: &workflow.PRDLoadError{File: "prd.json", Err: errors.New("unexpected end of JSON input")}, want: "Hint: prd.json is not valid PRD JSON"},
+		{name: "other", err: errors.New("runner crashed")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			newPhaseBanners(&out, true).observe(events.EventError{Err: tt.err})
+			if tt.want == "" {
+				if out.Len() != 0 {
+					t.Fatalf("banners = %q, want no hint", out.String())
+				}
+				return
+			}
+			if !strings.HasPrefix(out.String(), tt.want) {
+				t.Fatalf("banners = %q, want prefix %q", out.String(), tt.want)
+			}
+		})
+	}
+}
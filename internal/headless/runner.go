@@ -2,11 +2,22 @@ package headless
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"sync"
+	"sync/atomic"
+	"syscall"
 
+	"ralph/internal/shared/cli"
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/constants"
+	"ralph/internal/shared/notify"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/runlog"
 	"ralph/internal/shared/runner"
 	"ralph/internal/shared/runstate"
 	"ralph/internal/shared/session"
@@ -17,8 +28,15 @@ type Runner struct {
 	*session.Session
 	cfg      *config.Config
 	stderr   io.Writer
+	stdout   io.Writer
 	mu       sync.Mutex
 	snapshot session.RunSnapshot
+
+	// signals subscribes to interrupt signals; tests replace it to deliver
+	// them without signalling the test process.
+	signals func() (<-chan os.Signal, func())
+	exit    func(int)
+	clock   clock.Clock
 }
 
 func New(cfg *config.Config, r runner.RunnerInterface, stderr io.Writer) *Runner {
@@ -29,25 +47,127 @@ func New(cfg *config.Config, r runner.RunnerInterface, stderr io.Writer) *Runner
 		Session: session.NewWithRunner(cfg, r),
 		cfg:     cfg,
 		stderr:  stderr,
+		stdout:  os.Stdout,
+		signals: notifyInterrupts,
+		exit:    os.Exit,
+		clock:   clock.Real{},
 	}
 }
 
+var errInterrupted = errors.New("interrupted")
+
+func notifyInterrupts() (<-chan os.Signal, func()) {
+	ch := make(chan os.Signal, 2)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	return ch, func() { signal.Stop(ch) }
+}
+
 func Run(cfg *config.Config, prompt string, resume bool) int {
-	return New(cfg, runner.New(cfg), os.Stderr).Run(prompt, resume)
+	return New(cfg, runner.New(cfg, clock.Real{}), os.Stderr).Run(prompt, resume)
 }
 
+// Run executes the run unattended and ends it with the RALPH_SUMMARY line.
 func (r *Runner) Run(prompt string, resume bool) int {
+	code := r.run(prompt, resume)
+	r.writeSummary(code)
+	return code
+}
+
+func (r *Runner) run(prompt string, resume bool) int {
 	r.cfg.AutoApprove = true
 
+	var runLog *runlog.Log
+	if r.cfg.OutputDir != "" {
+		var err error
+		if runLog, err = runlog.Open(r.cfg.OutputDir, r.clock); err != nil {
+			fmt.Fprintf(r.stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer runLog.Close()
+	}
+
+	r.writeRunnerHeader()
 	opts := session.UnattendedOptions{Prompt: prompt, Resume: resume}
 	if err := r.StartUnattended(context.Background(), r.cfg, opts); err != nil {
-		_ = r.writeTerminalEvent(events.EventError{Err: err})
+		_ = r.writeTerminalEvent(events.EventError{Err: err}, runLog)
 		r.Wait()
 		return 1
 	}
 
-	sink := newNDJSONSink(r.cfg.WorkDir, runstate.LocalRunID, r.stderr, r.refreshSnapshot)
-	return r.RunEventLoop(sink)
+	sink := newNDJSONSink(r.cfg.WorkDir, runstate.LocalRunID, r.eventStream(), r.refreshSnapshot)
+	sink.notify = r.notify
+	sink.runLog = runLog
+	if !r.cfg.JSONOutput {
+		sink.banners = newPhaseBanners(r.stdout, r.cfg.NoColor)
+	}
+	interrupted, stop := r.handleInterrupts()
+	code := r.RunEventLoop(sink)
+	stop()
+	if n := r.DroppedEvents(); n > 0 {
+		fmt.Fprintln(r.stderr, events.DroppedEventsLine(n))
+	}
+	if interrupted.Load() {
+		fmt.Fprintln(r.stderr, "Interrupted; PRD state saved. Run ralph --resume --headless to continue.")
+		return constants.ExitInterrupted
+	}
+	return code
+}
+
+// writeRunnerHeader prints which runner and backend version this run uses, on
+// the same human-facing stream as the summary line.
+func (r *Runner) writeRunnerHeader() {
+	w := r.stdout
+	if r.cfg.JSONOutput {
+		w = r.stderr
+	}
+	fmt.Fprintln(w, r.RunnerHeader(context.Background()))
+}
+
+// writeSummary prints the machine-readable summary line on the human-facing
+// stream: stdout, or stderr when --json reserves stdout for NDJSON.
+func (r *Runner) writeSummary(code int) {
+	p, err := prd.Load(r.cfg)
+	if err != nil {
+		p = nil
+	}
+	w := r.stdout
+	if r.cfg.JSONOutput {
+		w = r.stderr
+	}
+	fmt.Fprintln(w, cli.SummaryLine(p, code))
+}
+
+// handleInterrupts cancels the run on the first SIGINT/SIGTERM so the event
+// loop drains and the workflow finishes its in-flight PRD save before Run
+// returns. A second signal exits immediately.
+func (r *Runner) handleInterrupts() (*atomic.Bool, func()) {
+	var interrupted atomic.Bool
+	sigCh, stopSignals := r.signals()
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-sigCh:
+		case <-done:
+			return
+		}
+		interrupted.Store(true)
+		r.Cancel()
+		go func() {
+			// A canceled workflow can return without a terminal event; emit
+			// one once it has finished so the event loop stops.
+			r.Wait()
+			r.EmitEvent(events.EventError{Err: errInterrupted})
+		}()
+		select {
+		case <-sigCh:
+			r.exit(constants.ExitInterrupted)
+		case <-done:
+		}
+	}()
+	return &interrupted, func() {
+		stopSignals()
+		close(done)
+	}
 }
 
 func (r *Runner) Snapshot() session.RunSnapshot {
@@ -56,12 +176,27 @@ func (r *Runner) Snapshot() session.RunSnapshot {
 	return r.snapshot
 }
 
-func (r *Runner) writeTerminalEvent(ev events.Event) error {
-	sink := newNDJSONSink(r.cfg.WorkDir, runstate.LocalRunID, r.stderr, nil)
+func (r *Runner) writeTerminalEvent(ev events.Event, runLog *runlog.Log) error {
+	sink := newNDJSONSink(r.cfg.WorkDir, runstate.LocalRunID, r.eventStream(), nil)
+	sink.notify = r.notify
+	sink.runLog = runLog
 	_, _, err := sink.OnEvent(ev)
 	return err
 }
 
+// eventStream is where the NDJSON events go: stderr by default, or stdout
+// with --json so wrappers can pipe it without the human-readable banners.
+func (r *Runner) eventStream() io.Writer {
+	if r.cfg.JSONOutput {
+		return r.stdout
+	}
+	return r.stderr
+}
+
+func (r *Runner) notify(ev events.Event) {
+	notify.Run(r.cfg, ev)
+}
+
 func (r *Runner) refreshSnapshot() {
 	snapshot := r.RunSnapshot(runstate.PhaseImplement)
 	r.mu.Lock()
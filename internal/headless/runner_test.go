@@ -3,15 +3,23 @@ package headless
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
 	"ralph/internal/prompt"
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/constants"
+	"ralph/internal/shared/prd"
 	"ralph/internal/shared/runner"
 	"ralph/internal/shared/session"
 )
@@ -29,7 +37,7 @@ func TestRunCompletesUnattended(t *testing.T) {
 	commitFile(t, cfg.WorkDir, "main.go", "init source file")
 
 	var stderr bytes.Buffer
-	r := New(cfg, runner.NewMock(cfg), &stderr)
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
 
 	code := r.Run("build a feature", false)
 	if code != 0 {
@@ -142,7 +150,7 @@ func TestRunResumesFromCheckpoint(t *testing.T) {
 	}
 
 	var stderr bytes.Buffer
-	r := New(cfg, runner.NewMock(cfg), &stderr)
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
 
 	code := r.Run("", true)
 	if code != 0 {
@@ -166,7 +174,7 @@ func TestRunSharesSnapshotFromSession(t *testing.T) {
 	commitFile(t, cfg.WorkDir, "main.go", "init source file")
 
 	var stderr bytes.Buffer
-	r := New(cfg, runner.NewMock(cfg), &stderr)
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
 
 	code := r.Run("build a feature", false)
 	if code != 0 {
@@ -337,3 +345,324 @@ func commitFile(t *testing.T, dir, file, message string) {
 		}
 	}
 }
+
+func TestRunBestEffortCompletesWithPartialSuccessCode(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	cfg.BestEffort = true
+	initGitRepo(t, cfg.WorkDir)
+	if err := os.WriteFile(filepath.Join(cfg.WorkDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, cfg.WorkDir, "main.go", "init source file")
+
+	var stderr bytes.Buffer
+	r := New(cfg, &bestEffortRunner{workDir: cfg.WorkDir}, &stderr)
+
+	code := r.Run("build a feature", false)
+	if code != 2 {
+		t.Fatalf("Run() = %d, want 2; stderr=%s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), `"type":"EventCompleted","payload":{"unfinished":["story-2"]}`) {
+		t.Fatalf("stderr = %q, want EventCompleted listing story-2 as unfinished", stderr.String())
+	}
+	if strings.Contains(stderr.String(), `"type":"EventError"`) {
+		t.Fatalf("best-effort run should not emit EventError:\n%s", stderr.String())
+	}
+}
+
+type bestEffortRunner struct {
+	workDir string
+}
+
+func (r *bestEffortRunner) Run(_ context.Context, promptText string, outputCh chan<- runner.OutputLine) error {
+	switch prompt.Kind(promptText) {
+	case prompt.KindPRDGenerate:
+		data := `{"project_name":"Test","stories":[` +
+			`{"id":"story-1","title":"S1","description":"d","slices":[{"id":"slice-1","behavior":"a","red_hint":"add failing test"}],"priority":1},` +
+			`{"id":"story-2","title":"S2","description":"d","slices":[{"id":"slice-1","behavior":"b","red_hint":"add failing test"}],"priority":2}]}`
+		return os.WriteFile(filepath.Join(r.workDir, "prd.json"), []byte(data), 0o644)
+	case prompt.KindPRDSelfReview:
+		return os.WriteFile(filepath.Join(r.workDir, prompt.PRDSelfReviewVerdictFile), []byte(`{"approved":true,"summary":"ok"}`), 0o644)
+	case prompt.KindDiffReview:
+		outputCh <- runner.OutputLine{Text: cleanReviewTranscript}
+		return nil
+	case prompt.KindStoryImplement:
+		if strings.Contains(promptText, "(ID: story-2)") {
+			return errors.New("runner crashed")
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (r *bestEffortRunner) RunnerName() string  { return "test" }
+func (r *bestEffortRunner) CommandName() string { return "test" }
+func (r *bestEffortRunner) IsInternalLog(string) bool {
+	return false
+}
+
+func TestRunPrintsImplementationPhaseBanner(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.Runner = "mock"
+	cfg.SkipCleanup = true
+	cfg.NoColor = true
+	initGitRepo(t, cfg.WorkDir)
+	if err := os.WriteFile(filepath.Join(cfg.WorkDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, cfg.WorkDir, "main.go", "init source file")
+
+	var stderr, stdout bytes.Buffer
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
+	r.stdout = &stdout
+
+	if code := r.Run("build a feature", false); code != 0 {
+		t.Fatalf("Run() = %d, want 0; stderr=%s", code, stderr.String())
+	}
+	out := stdout.String()
+	prdBanner := strings.Index(out, "── Phase 1: PRD ──")
+	implBanner := strings.Index(out, "── Phase 2: Implementation ──")
+	summary := strings.Index(out, "1/1 stories")
+	if prdBanner < 0 || implBanner < prdBanner || summary < implBanner {
+		t.Fatalf("stdout should show PRD banner, then implementation banner, then the summary bar:\n%s", out)
+	}
+	if strings.Contains(stderr.String(), "── Phase") {
+		t.Fatal("phase banners must not be mixed into the NDJSON stream on stderr")
+	}
+}
+
+func TestRunWritesRunLogToOutputDir(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.Runner = "mock"
+	cfg.SkipCleanup = true
+	cfg.OutputDir = filepath.Join(t.TempDir(), "logs")
+	initGitRepo(t, cfg.WorkDir)
+	if err := os.WriteFile(filepath.Join(cfg.WorkDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, cfg.WorkDir, "main.go", "init source file")
+
+	var stderr bytes.Buffer
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
+	r.stdout = &bytes.Buffer{}
+
+	if code := r.Run("build a feature", false); code != 0 {
+		t.Fatalf("Run() = %d, want 0; stderr=%s", code, stderr.String())
+	}
+	logs, err := filepath.Glob(filepath.Join(cfg.OutputDir, "ralph-run-*.log"))
+	if err != nil || len(logs) != 1 {
+		t.Fatalf("run logs = %v (err %v), want exactly one", logs, err)
+	}
+	data, err := os.ReadFile(logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	started := strings.Index(out, "=== story story-1 started")
+	passed := strings.Index(out, "=== story story-1 passed")
+	completed := strings.Index(out, "=== run completed")
+	if started < 0 || passed < started || completed < passed {
+		t.Fatalf("run log should record story start, story result, then the final status:\n%s", out)
+	}
+}
+
+func TestRunEndsWithSummaryLine(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.Runner = "mock"
+	cfg.SkipCleanup = true
+	initGitRepo(t, cfg.WorkDir)
+	if err := os.WriteFile(filepath.Join(cfg.WorkDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, cfg.WorkDir, "main.go", "init source file")
+
+	var stderr, stdout bytes.Buffer
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
+	r.stdout = &stdout
+
+	if code := r.Run("build a feature", false); code != 0 {
+		t.Fatalf("Run() = %d, want 0; stderr=%s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(last, "RALPH_SUMMARY project=") || !strings.Contains(last, " total=1 completed=1 failed=0 iterations=1 status=completed") {
+		t.Fatalf("last stdout line = %q, want the RALPH_SUMMARY line for a completed run", last)
+	}
+}
+
+func TestRunJSONWritesEventsToStdout(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.Runner = "mock"
+	cfg.SkipCleanup = true
+	cfg.JSONOutput = true
+	initGitRepo(t, cfg.WorkDir)
+	if err := os.WriteFile(filepath.Join(cfg.WorkDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, cfg.WorkDir, "main.go", "init source file")
+
+	var stderr, stdout bytes.Buffer
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
+	r.stdout = &stdout
+
+	if code := r.Run("build a feature", false); code != 0 {
+		t.Fatalf("Run() = %d, want 0; stderr=%s", code, stderr.String())
+	}
+	var types []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		var ev struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("stdout line %q is not JSON: %v", line, err)
+		}
+		types = append(types, ev.Type)
+	}
+	if !slices.Contains(types, "EventStoryCompleted") || types[len(types)-1] != "EventCompleted" {
+		t.Fatalf("event types = %v, want story completion and a final EventCompleted", types)
+	}
+	if strings.Contains(stderr.String(), `"type":`) {
+		t.Fatalf("stderr should not duplicate the event stream: %q", stderr.String())
+	}
+}
+
+func TestRunNotifiesWebhookOnCompletion(t *testing.T) {
+	var statuses []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Status string `json:"status"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		statuses = append(statuses, body.Status)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.Runner = "mock"
+	cfg.SkipCleanup = true
+	cfg.WebhookURL = srv.URL
+	initGitRepo(t, cfg.WorkDir)
+	if err := os.WriteFile(filepath.Join(cfg.WorkDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, cfg.WorkDir, "main.go", "init source file")
+
+	var stderr bytes.Buffer
+	r := New(cfg, runner.NewMock(cfg, clock.Real{}), &stderr)
+	if code := r.Run("build a feature", false); code != 0 {
+		t.Fatalf("Run() = %d, want 0 even when the webhook fails; stderr=%s", code, stderr.String())
+	}
+	if !slices.Equal(statuses, []string{"completed"}) {
+		t.Fatalf("webhook statuses = %v, want one completed notification", statuses)
+	}
+}
+
+// interruptRunner generates a one-story PRD and then blocks in the story until
+// released or, when honorCancel is set, until the run is canceled.
+type interruptRunner struct {
+	workDir     string
+	honorCancel bool
+	started     chan struct{}
+	release     chan struct{}
+}
+
+func (r *interruptRunner) Run(ctx context.Context, promptText string, _ chan<- runner.OutputLine) error {
+	switch prompt.Kind(promptText) {
+	case prompt.KindPRDGenerate:
+		data := `{"project_name":"Test","stories":[{"id":"story-1","title":"S1","description":"d","slices":[{"id":"slice-1","behavior":"a","red_hint":"add failing test"}],"priority":1}]}`
+		return os.WriteFile(filepath.Join(r.workDir, "prd.json"), []byte(data), 0o644)
+	case prompt.KindPRDSelfReview:
+		return os.WriteFile(filepath.Join(r.workDir, prompt.PRDSelfReviewVerdictFile), []byte(`{"approved":true,"summary":"ok"}`), 0o644)
+	case prompt.KindStoryImplement:
+		close(r.started)
+		if r.honorCancel {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		<-r.release
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (r *interruptRunner) RunnerName() string        { return "test" }
+func (r *interruptRunner) CommandName() string       { return "test" }
+func (r *interruptRunner) IsInternalLog(string) bool { return false }
+
+func newInterruptTestRunner(t *testing.T, honorCancel bool) (*Runner, *interruptRunner, chan os.Signal, *bytes.Buffer) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	initGitRepo(t, cfg.WorkDir)
+
+	ir := &interruptRunner{workDir: cfg.WorkDir, honorCancel: honorCancel, started: make(chan struct{}), release: make(chan struct{})}
+	var stderr bytes.Buffer
+	r := New(cfg, ir, &stderr)
+	sigCh := make(chan os.Signal, 2)
+	r.signals = func() (<-chan os.Signal, func()) { return sigCh, func() {} }
+	return r, ir, sigCh, &stderr
+}
+
+func TestRunInterruptCancelsAndExits130(t *testing.T) {
+	r, ir, sigCh, stderr := newInterruptTestRunner(t, true)
+	go func() {
+		<-ir.started
+		sigCh <- os.Interrupt
+	}()
+
+	if code := r.Run("build a feature", false); code != constants.ExitInterrupted {
+		t.Fatalf("Run() = %d, want %d; stderr=%s", code, constants.ExitInterrupted, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "PRD state saved") {
+		t.Fatalf("stderr = %q, want interrupt notice", stderr.String())
+	}
+	p, err := prd.Load(r.cfg)
+	if err != nil {
+		t.Fatalf("PRD should still load after interrupt: %v", err)
+	}
+	if p.Iterations != 1 || p.Stories[0].Passes {
+		t.Fatalf("saved PRD = iterations %d passes %v, want the started story recorded and unfinished", p.Iterations, p.Stories[0].Passes)
+	}
+}
+
+func TestRunSecondInterruptForceExits(t *testing.T) {
+	r, ir, sigCh, _ := newInterruptTestRunner(t, false)
+	exited := make(chan int, 1)
+	r.exit = func(code int) {
+		exited <- code
+		close(ir.release)
+	}
+	go func() {
+		<-ir.started
+		sigCh <- os.Interrupt
+		sigCh <- os.Interrupt
+	}()
+
+	r.Run("build a feature", false)
+	select {
+	case code := <-exited:
+		if code != constants.ExitInterrupted {
+			t.Fatalf("exit code = %d, want %d", code, constants.ExitInterrupted)
+		}
+	default:
+		t.Fatal("second interrupt should force-exit")
+	}
+}
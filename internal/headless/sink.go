@@ -4,6 +4,8 @@ import (
 	"io"
 	"os"
 
+	"ralph/internal/shared/constants"
+	"ralph/internal/shared/runlog"
 	"ralph/internal/workflow/events"
 )
 
@@ -12,6 +14,9 @@ type ndjsonSink struct {
 	runID   string
 	w       io.Writer
 	refresh func()
+	banners *phaseBanners
+	notify  func(events.Event)
+	runLog  *runlog.Log
 }
 
 func newNDJSONSink(workDir, runID string, w io.Writer, refresh func()) *ndjsonSink {
@@ -25,19 +30,34 @@ func (s *ndjsonSink) OnEvent(ev events.Event) (stop bool, exitCode int, err erro
 	if err := s.writeEvent(ev); err != nil {
 		return true, 1, err
 	}
+	s.runLog.Record(ev)
 	if s.refresh != nil {
 		s.refresh()
 	}
-	switch ev.(type) {
+	if s.banners != nil {
+		s.banners.observe(ev)
+	}
+	switch e := ev.(type) {
 	case events.EventCompleted:
+		s.notifyTerminal(ev)
+		if e.Partial() {
+			return true, constants.ExitPartialSuccess, nil
+		}
 		return true, 0, nil
 	case events.EventError:
+		s.notifyTerminal(ev)
 		return true, 1, nil
 	default:
 		return false, 0, nil
 	}
 }
 
+func (s *ndjsonSink) notifyTerminal(ev events.Event) {
+	if s.notify != nil {
+		s.notify(ev)
+	}
+}
+
 func (s *ndjsonSink) writeEvent(ev events.Event) error {
 	data, err := events.MarshalEventEnvelope(ev)
 	if err != nil {
@@ -99,6 +99,7 @@ func TestStoryPromptIncludesContext(t *testing.T) {
 		"prd.json",
 		0, 3,
 		nil,
+		nil,
 	)
 
 	if !strings.Contains(result, "CODEBASE CONTEXT") {
@@ -123,6 +124,7 @@ func TestStoryPromptOmitsContextSectionWhenEmpty(t *testing.T) {
 		"prd.json",
 		0, 3,
 		nil,
+		nil,
 	)
 
 	if strings.Contains(result, "CODEBASE CONTEXT") {
@@ -130,6 +132,21 @@ func TestStoryPromptOmitsContextSectionWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestStoryPromptRecentCommits(t *testing.T) {
+	slices := []SliceData{{ID: "slice-1", Behavior: "It works", RedHint: "write failing test"}}
+
+	result := StoryImplementation("story-2", "Add feature", "Implement it", slices, "", "", "prd.json", 1, 3, nil,
+		[]string{"ralph: story-1 slice-2", "ralph: story-1 slice-1"})
+	if !strings.Contains(result, "RECENT COMMITS") || !strings.Contains(result, "- ralph: story-1 slice-2\n- ralph: story-1 slice-1\n") {
+		t.Errorf("Prompt should list recent commits newest first, got:\n%s", result)
+	}
+
+	result = StoryImplementation("story-2", "Add feature", "Implement it", slices, "", "", "prd.json", 1, 3, nil, nil)
+	if strings.Contains(result, "RECENT COMMITS") {
+		t.Error("Prompt should NOT contain 'RECENT COMMITS' section without commits")
+	}
+}
+
 func TestPRDGenerationPromptMentionsContext(t *testing.T) {
 	result := PRDGeneration("Add auth", "prd.json", "feature", false)
 
@@ -11,13 +11,15 @@ var templateFS embed.FS
 
 var templates *template.Template
 
+var templateFuncs = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+	"join": func(items []string, sep string) string {
+		return strings.Join(items, sep)
+	},
+}
+
 func init() {
 	templates = template.Must(
-		template.New("prompts").Funcs(template.FuncMap{
-			"add": func(a, b int) int { return a + b },
-			"join": func(items []string, sep string) string {
-				return strings.Join(items, sep)
-			},
-		}).ParseFS(templateFS, "templates/*.tmpl", "templates/partials/*.tmpl"),
+		template.New("prompts").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.tmpl", "templates/partials/*.tmpl"),
 	)
 }
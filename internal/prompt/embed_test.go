@@ -18,6 +18,7 @@ func TestTemplatesParseAtInit(t *testing.T) {
 		"prd-critique-revision",
 		"prd-clarification-revision",
 		"story-implement",
+		"story-verify",
 		"diff-review",
 		"recovery",
 		"cleanup",
@@ -58,7 +59,7 @@ func TestAllTemplateFilesEmbedded(t *testing.T) {
 }
 
 func TestRecoveryTemplateContainsMarker(t *testing.T) {
-	got := RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil)
+	got := RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil, "")
 	if !strings.Contains(got, RecoveryAgentMarker) {
 		t.Fatalf("rendered recovery prompt must contain RecoveryAgentMarker %q", RecoveryAgentMarker)
 	}
@@ -85,13 +86,16 @@ func TestAllTemplatesExecuteWithMinimalData(t *testing.T) {
 			return PRDClarificationRevision("build x", "prd.json", []QuestionAnswer{{Question: "Q?", Answer: "A"}})
 		}},
 		{"story-implement", func() string {
-			return StoryImplementation("story-1", "Title", "Desc", []SliceData{{ID: "slice-1", Behavior: "done", RedHint: "red"}}, "", "", "prd.json", 0, 1, nil)
+			return StoryImplementation("story-1", "Title", "Desc", []SliceData{{ID: "slice-1", Behavior: "done", RedHint: "red"}}, "", "", "prd.json", 0, 1, nil, nil)
+		}},
+		{"story-verify", func() string {
+			return StoryVerification("story-1", "Title", "Desc", []SliceData{{ID: "slice-1", Behavior: "done"}}, "", "prd.json")
 		}},
 		{"diff-review", func() string {
 			return CriticalDiffReview("", "prd.json", nil)
 		}},
 		{"recovery", func() string {
-			return RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil)
+			return RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil, "")
 		}},
 		{"cleanup", func() string {
 			return Cleanup("", "prd.json", nil)
@@ -14,6 +14,8 @@ const (
 	KindPRDCritiqueRevision      = "prd-critique-revision"
 	KindPRDClarificationRevision = "prd-clarification-revision"
 	KindStoryImplement           = "story-implement"
+	KindTestScaffold             = "test-scaffold"
+	KindStoryVerify              = "story-verify"
 	KindDiffReview               = "diff-review"
 	KindRecovery                 = "recovery"
 	KindCleanup                  = "cleanup"
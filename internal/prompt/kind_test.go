@@ -10,9 +10,11 @@ func TestRenderedPromptsIncludeKindMarker(t *testing.T) {
 	}{
 		{"clarify", ClarifyingQuestions("build x", ".ralph/questions.json", false), KindClarify},
 		{"prd-generate", PRDGeneration("build x", "prd.json", "feature", false), KindPRDGenerate},
-		{"story-implement", StoryImplementation("story-1", "Title", "Desc", []SliceData{{ID: "slice-1", Behavior: "b", RedHint: "r"}}, "", "", "prd.json", 0, 1, nil), KindStoryImplement},
+		{"story-implement", StoryImplementation("story-1", "Title", "Desc", []SliceData{{ID: "slice-1", Behavior: "b", RedHint: "r"}}, "", "", "prd.json", 0, 1, nil, nil), KindStoryImplement},
+		{"test-scaffold", TestScaffold("story-1", "Title", "Desc", nil, "", "", "prd.json"), KindTestScaffold},
+		{"story-verify", StoryVerification("story-1", "Title", "Desc", nil, "", "prd.json"), KindStoryVerify},
 		{"diff-review", CriticalDiffReview("", "prd.json", nil), KindDiffReview},
-		{"recovery", RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil), KindRecovery},
+		{"recovery", RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil, ""), KindRecovery},
 		{"cleanup", Cleanup("", "prd.json", nil), KindCleanup},
 	}
 
@@ -41,7 +43,7 @@ func TestHasKindRejectsUnmarkedPrompts(t *testing.T) {
 }
 
 func TestIsRecoveryPromptUsesKindMarker(t *testing.T) {
-	p := RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil)
+	p := RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil, "")
 	if !IsRecoveryPrompt(p) {
 		t.Fatal("IsRecoveryPrompt() = false, want true for recovery prompt")
 	}
@@ -1,5 +1,12 @@
 package prompt
 
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"unicode/utf8"
+)
+
 // QuestionAnswer holds a clarifying question and the user's answer.
 type QuestionAnswer struct {
 	Question string
@@ -24,19 +31,40 @@ func PRDGeneration(userPrompt, prdFile, branchPrefix string, isEmptyCodebase boo
 }
 
 func PRDGenerationWithAnswers(userPrompt, prdFile, branchPrefix string, isEmptyCodebase bool, qas []QuestionAnswer) string {
+	return mustRender("prd-generate", prdGenerateData(userPrompt, prdFile, branchPrefix, isEmptyCodebase, qas))
+}
+
+// PRDGenerationFromTemplate renders a user-supplied text/template in place of
+// the built-in prd-generate template. The template sees PRDGenerateData, so
+// {{.UserPrompt}}, {{.PRDFile}}, {{.BranchPrefix}} and {{.IsEmptyCodebase}}
+// are available. The result is tagged as a prd-generate prompt.
+func PRDGenerationFromTemplate(text, userPrompt, prdFile, branchPrefix string, isEmptyCodebase bool, qas []QuestionAnswer) (string, error) {
+	tmpl, err := template.New("custom-prd-generate").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse PRD prompt template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, prdGenerateData(userPrompt, prdFile, branchPrefix, isEmptyCodebase, qas)); err != nil {
+		return "", fmt.Errorf("execute PRD prompt template: %w", err)
+	}
+	return wrapWithKind(KindPRDGenerate, buf.String()), nil
+}
+
+func prdGenerateData(userPrompt, prdFile, branchPrefix string, isEmptyCodebase bool, qas []QuestionAnswer) PRDGenerateData {
 	contextGuidance := "- context: follow the planning style guide — record ONLY stack, layout, conventions, and test commands you ACTUALLY observe in the codebase"
 	if isEmptyCodebase {
 		contextGuidance = `Note: The working directory has no existing source code. This is a new project.
 - context: describe ONLY the tech stack specified in the user's request, or state "New project - no existing codebase". Skip convention sections that require an existing repo to observe.
 - Do NOT assume or invent a tech stack the user did not mention`
 	}
-	return mustRender("prd-generate", PRDGenerateData{
+	return PRDGenerateData{
 		UserPrompt:      userPrompt,
 		PRDFile:         prdFile,
 		BranchPrefix:    branchPrefix,
 		ContextGuidance: contextGuidance,
+		IsEmptyCodebase: isEmptyCodebase,
 		Clarifications:  qas,
-	})
+	}
 }
 
 func PRDCritiqueRevision(userPrompt, prdFile, critique string) string {
@@ -73,8 +101,69 @@ func firstPendingSlice(slices []SliceData) []SliceData {
 	return nil
 }
 
-func StoryImplementation(storyID, title, description string, slices []SliceData, featureTestSpec, codebaseContext, prdFile string, completed, total int, dependsOn []string) string {
-	return mustRender("story-implement", StoryImplementData{
+// StoryImplementation renders the prompt for a story's pending slice.
+// recentCommits are the subjects of the branch's latest commits, newest
+// first; the section is left out when there are none.
+func StoryImplementation(storyID, title, description string, slices []SliceData, featureTestSpec, codebaseContext, prdFile string, completed, total int, dependsOn, recentCommits []string) string {
+	return mustRender("story-implement", storyImplementData(storyID, title, description, slices, featureTestSpec, codebaseContext, prdFile, completed, total, dependsOn, recentCommits))
+}
+
+// StoryImplementationWithinBudget renders the story prompt and, when budget is
+// positive and exceeded, trims the codebase context first, then the feature
+// test spec, then the description. The slice and its criteria are never
+// trimmed. The bool reports whether anything was cut.
+func StoryImplementationWithinBudget(budget int, storyID, title, description string, slices []SliceData, featureTestSpec, codebaseContext, prdFile string, completed, total int, dependsOn, recentCommits []string) (string, bool) {
+	data := storyImplementData(storyID, title, description, slices, featureTestSpec, codebaseContext, prdFile, completed, total, dependsOn, recentCommits)
+	rendered := mustRender("story-implement", data)
+	if budget <= 0 {
+		return rendered, false
+	}
+	trimmed := false
+	for _, field := range []*string{&data.Context, &data.FeatureTestSpec, &data.Description} {
+		over := len(rendered) - budget
+		if over <= 0 {
+			break
+		}
+		if *field == "" {
+			continue
+		}
+		*field = trimToFit(*field, len(*field)-over)
+		trimmed = true
+		rendered = mustRender("story-implement", data)
+	}
+	return rendered, trimmed
+}
+
+const budgetTrimNote = "\n[trimmed to fit the prompt budget]"
+
+const userPromptTruncatedNote = "…\n[request truncated to fit RALPH_MAX_PROMPT_BYTES]"
+
+func trimToFit(text string, limit int) string {
+	return cutWithNote(text, limit, budgetTrimNote)
+}
+
+// TruncateUserPrompt cuts the user's request to at most limit bytes, ending
+// it with an ellipsis note so the runner knows the request is incomplete.
+func TruncateUserPrompt(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return cutWithNote(text, limit, userPromptTruncatedNote)
+}
+
+func cutWithNote(text string, limit int, note string) string {
+	if limit <= len(note) {
+		return ""
+	}
+	cut := limit - len(note)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + note
+}
+
+func storyImplementData(storyID, title, description string, slices []SliceData, featureTestSpec, codebaseContext, prdFile string, completed, total int, dependsOn, recentCommits []string) StoryImplementData {
+	return StoryImplementData{
 		StoryID:         storyID,
 		Title:           title,
 		Description:     description,
@@ -85,5 +174,20 @@ func StoryImplementation(storyID, title, description string, slices []SliceData,
 		Completed:       completed,
 		Total:           total,
 		DependsOn:       dependsOn,
+		RecentCommits:   recentCommits,
+	}
+}
+
+// TestScaffold asks the runner to write failing test stubs for every slice of
+// a story without touching production code.
+func TestScaffold(storyID, title, description string, slices []SliceData, featureTestSpec, codebaseContext, prdFile string) string {
+	return mustRender("test-scaffold", TestScaffoldData{
+		StoryID:         storyID,
+		Title:           title,
+		Description:     description,
+		Slices:          slices,
+		FeatureTestSpec: featureTestSpec,
+		Context:         codebaseContext,
+		PRDFile:         prdFile,
 	})
 }
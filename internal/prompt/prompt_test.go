@@ -108,6 +108,26 @@ func TestPRDGenerationWithAnswers(t *testing.T) {
 	})
 }
 
+func TestPRDGenerationFromTemplate(t *testing.T) {
+	qas := []QuestionAnswer{{Question: "Which DB?", Answer: "Postgres"}}
+	got, err := PRDGenerationFromTemplate("{{.UserPrompt}} -> {{.PRDFile}} ({{.BranchPrefix}}, new={{.IsEmptyCodebase}}){{range .Clarifications}} {{.Answer}}{{end}}", "Add auth", "prd.json", "feature", true, qas)
+	if err != nil {
+		t.Fatalf("PRDGenerationFromTemplate() error = %v", err)
+	}
+	if !strings.Contains(got, "Add auth -> prd.json (feature, new=true) Postgres") {
+		t.Errorf("rendered prompt = %q", got)
+	}
+	if Kind(got) != KindPRDGenerate {
+		t.Errorf("Kind() = %q, want %q", Kind(got), KindPRDGenerate)
+	}
+
+	for _, bad := range []string{"{{.UserPrompt", "{{.Missing}}"} {
+		if _, err := PRDGenerationFromTemplate(bad, "Add auth", "prd.json", "feature", false, nil); err == nil {
+			t.Errorf("PRDGenerationFromTemplate(%q) error = nil, want error", bad)
+		}
+	}
+}
+
 func TestPRDGeneration(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -303,6 +323,7 @@ func TestStoryImplementation(t *testing.T) {
 				tt.completed,
 				tt.total,
 				nil,
+				nil,
 			)
 			for _, phrase := range tt.mustInclude {
 				if !strings.Contains(result, phrase) {
@@ -330,6 +351,7 @@ func TestStoryImplementationUsesSliceWording(t *testing.T) {
 		0,
 		1,
 		nil,
+		nil,
 	)
 
 	if !strings.Contains(result, "every slice passes") {
@@ -355,6 +377,7 @@ func TestStoryImplementationRendersOnlyOnePendingSlice(t *testing.T) {
 		0,
 		1,
 		nil,
+		nil,
 	)
 
 	for _, want := range []string{"Slice 1", "first behavior", "first red", "first refactor"} {
@@ -381,6 +404,7 @@ func TestStoryImplementationUsesSingularSliceHeading(t *testing.T) {
 		0,
 		1,
 		nil,
+		nil,
 	)
 
 	if !strings.Contains(result, "Pending slice:") {
@@ -408,6 +432,7 @@ func TestStoryImplementationRequiresPendingSlicesAndPRDUpdates(t *testing.T) {
 		0,
 		1,
 		nil,
+		nil,
 	)
 
 	for _, want := range []string{
@@ -474,6 +499,7 @@ func TestStoryImplementationIncludesWorkingConventions(t *testing.T) {
 		0,
 		1,
 		nil,
+		nil,
 	)
 
 	if !strings.Contains(result, "WORKING CONVENTIONS") {
@@ -622,3 +648,113 @@ func TestPRDClarificationRevisionIncludesAnswers(t *testing.T) {
 		t.Fatal("PRDClarificationRevision() should include clarifications")
 	}
 }
+
+func TestTestScaffold(t *testing.T) {
+	result := TestScaffold(
+		"story-1",
+		"Title",
+		"Desc",
+		[]SliceData{
+			{ID: "slice-1", Behavior: "first behavior", RedHint: "first red"},
+			{ID: "slice-2", Behavior: "second behavior", RedHint: "second red"},
+		},
+		"go test ./...",
+		"Go module",
+		"prd.json",
+	)
+
+	for _, want := range []string{
+		"story-1",
+		"slice-1: first behavior",
+		"slice-2: second behavior",
+		"FEATURE TEST SPEC:\ngo test ./...",
+		"CODEBASE CONTEXT:\nGo module",
+		"Do NOT write or modify production code",
+		"Do NOT edit prd.json",
+	} {
+		if !strings.Contains(result, want) {
+			t.Fatalf("TestScaffold() missing %q in:\n%s", want, result)
+		}
+	}
+}
+
+func TestStoryImplementationWithinBudgetKeepsCriteria(t *testing.T) {
+	slices := []SliceData{{ID: "slice-1", Behavior: "parse empty input", RedHint: "assert zero tokens"}}
+	context := strings.Repeat("verbose codebase context line\n", 200)
+	spec := strings.Repeat("feature spec line\n", 50)
+
+	full := StoryImplementation("story-1", "Parser", "Desc", slices, spec, context, "prd.json", 0, 1, nil, nil)
+	budget := len(full) - len(context)/2
+
+	got, trimmed := StoryImplementationWithinBudget(budget, "story-1", "Parser", "Desc", slices, spec, context, "prd.json", 0, 1, nil, nil)
+	if !trimmed {
+		t.Fatal("expected trimming with a tight budget")
+	}
+	if len(got) > budget {
+		t.Fatalf("prompt length = %d, want <= %d", len(got), budget)
+	}
+	for _, want := range []string{"parse empty input", "assert zero tokens", "Parser", "FEATURE TEST SPEC:\n" + spec, "trimmed to fit the prompt budget"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("budgeted prompt missing %q", want)
+		}
+	}
+	if strings.Contains(got, context) {
+		t.Fatal("codebase context should be trimmed first")
+	}
+}
+
+func TestStoryImplementationWithinBudgetTrimsSpecAfterContext(t *testing.T) {
+	slices := []SliceData{{ID: "slice-1", Behavior: "parse empty input", RedHint: "assert zero tokens"}}
+	spec := strings.Repeat("feature spec line\n", 50)
+	minimal := StoryImplementation("story-1", "Parser", "Desc", slices, "", "", "prd.json", 0, 1, nil, nil)
+
+	got, trimmed := StoryImplementationWithinBudget(len(minimal)+100, "story-1", "Parser", "Desc", slices, spec, strings.Repeat("ctx ", 100), "prd.json", 0, 1, nil, nil)
+	if !trimmed {
+		t.Fatal("expected trimming with a tight budget")
+	}
+	if strings.Contains(got, "ctx ctx") {
+		t.Fatal("codebase context should be dropped before the spec is trimmed")
+	}
+	if strings.Contains(got, spec) {
+		t.Fatal("feature test spec should be trimmed once the context is gone")
+	}
+	if !strings.Contains(got, "parse empty input") || !strings.Contains(got, "assert zero tokens") {
+		t.Fatalf("slice criteria must survive trimming:\n%s", got)
+	}
+}
+
+func TestStoryImplementationWithinBudgetNoopWhenDisabledOrUnder(t *testing.T) {
+	slices := []SliceData{{ID: "slice-1", Behavior: "b", RedHint: "r"}}
+	want := StoryImplementation("story-1", "T", "D", slices, "spec", "ctx", "prd.json", 0, 1, nil, nil)
+	for _, budget := range []int{0, len(want)} {
+		got, trimmed := StoryImplementationWithinBudget(budget, "story-1", "T", "D", slices, "spec", "ctx", "prd.json", 0, 1, nil, nil)
+		if trimmed || got != want {
+			t.Fatalf("budget %d: trimmed=%v, prompt changed", budget, trimmed)
+		}
+	}
+}
+
+func TestTruncateUserPrompt(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  string
+	}{
+		{name: "under limit", text: "add invites", limit: 100, want: "add invites"},
+		{name: "over limit", text: strings.Repeat("a", 200), limit: 100, want: strings.Repeat("a", 100-len(userPromptTruncatedNote)) + userPromptTruncatedNote},
+		{name: "no room for note", text: strings.Repeat("a", 200), limit: 10, want: ""},
+		{name: "keeps runes whole", text: strings.Repeat("é", 100), limit: 62, want: strings.Repeat("é", 4) + userPromptTruncatedNote},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateUserPrompt(tt.text, tt.limit)
+			if got != tt.want {
+				t.Fatalf("TruncateUserPrompt() = %q, want %q", got, tt.want)
+			}
+			if len(got) > tt.limit {
+				t.Fatalf("len = %d, over limit %d", len(got), tt.limit)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ func RecoverFromFailure(
 	errMsg string,
 	findings []RecoveryFinding,
 	changedFiles []string,
+	diff string,
 ) string {
 	findingsJSON := ""
 	if len(findings) > 0 {
@@ -41,6 +42,7 @@ func RecoverFromFailure(
 		Context:      codebaseContext,
 		ErrorMessage: errMsg,
 		FindingsJSON: findingsJSON,
+		Diff:         diff,
 		Escalate:     escalate,
 		Reason:       reason,
 		Attempt:      attempt,
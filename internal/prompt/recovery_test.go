@@ -19,6 +19,7 @@ func TestRecoverFromFailureIncludesFindingsAndReason(t *testing.T) {
 			Summary:  "not imported anywhere",
 		}},
 		[]string{"app/javascript/routes.js"},
+		"",
 	)
 
 	if !strings.Contains(p, RecoveryAgentMarker) {
@@ -36,7 +37,7 @@ func TestRecoverFromFailureIncludesFindingsAndReason(t *testing.T) {
 }
 
 func TestIsRecoveryPrompt(t *testing.T) {
-	if !IsRecoveryPrompt(RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil)) {
+	if !IsRecoveryPrompt(RecoverFromFailure("", "prd.json", RecoveryReasonStoryFailure, 1, 2, "boom", nil, nil, "")) {
 		t.Fatal("expected recovery prompt detection")
 	}
 	if IsRecoveryPrompt("implement story foo") {
@@ -15,6 +15,7 @@ type PRDGenerateData struct {
 	PRDFile         string
 	BranchPrefix    string
 	ContextGuidance string
+	IsEmptyCodebase bool
 	Clarifications  []QuestionAnswer
 }
 
@@ -49,6 +50,27 @@ type StoryImplementData struct {
 	Completed       int
 	Total           int
 	DependsOn       []string
+	RecentCommits   []string
+}
+
+type TestScaffoldData struct {
+	StoryID         string
+	Title           string
+	Description     string
+	Slices          []SliceData
+	FeatureTestSpec string
+	Context         string
+	PRDFile         string
+}
+
+type StoryVerifyData struct {
+	StoryID     string
+	Title       string
+	Description string
+	Slices      []SliceData
+	Context     string
+	PRDFile     string
+	Marker      string
 }
 
 type SliceData struct {
@@ -70,6 +92,7 @@ type RecoveryData struct {
 	Context      string
 	ErrorMessage string
 	FindingsJSON string
+	Diff         string
 	Escalate     bool
 	Reason       RecoveryReason
 	Attempt      int
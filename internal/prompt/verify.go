@@ -0,0 +1,20 @@
+package prompt
+
+// StoryVerifiedMarker starts the line the verification agent prints when every
+// slice behavior of the story holds in the code.
+const StoryVerifiedMarker = "COMPLETED:"
+
+// StoryVerification asks the agent to check a finished story's slice behaviors
+// against the code without changing anything, and to print StoryVerifiedMarker
+// only when all of them hold.
+func StoryVerification(storyID, title, description string, slices []SliceData, codebaseContext, prdFile string) string {
+	return mustRender("story-verify", StoryVerifyData{
+		StoryID:     storyID,
+		Title:       title,
+		Description: description,
+		Slices:      slices,
+		Context:     codebaseContext,
+		PRDFile:     prdFile,
+		Marker:      StoryVerifiedMarker,
+	})
+}
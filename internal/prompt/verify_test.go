@@ -0,0 +1,29 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStoryVerification(t *testing.T) {
+	result := StoryVerification("story-2", "Invite users", "Send invites by email", []SliceData{
+		{ID: "slice-1", Behavior: "returns 201 for a valid invite"},
+		{ID: "slice-2", Behavior: "rejects duplicate invites with 409"},
+	}, "Go service under cmd/api", "prd.json")
+
+	for _, phrase := range []string{
+		"story-2",
+		"Invite users",
+		"Send invites by email",
+		"Slice 1: returns 201 for a valid invite",
+		"Slice 2: rejects duplicate invites with 409",
+		"CODEBASE CONTEXT:",
+		"Do not edit files",
+		"prd.json",
+		StoryVerifiedMarker,
+	} {
+		if !strings.Contains(result, phrase) {
+			t.Errorf("StoryVerification() missing %q", phrase)
+		}
+	}
+}
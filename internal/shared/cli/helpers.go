@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"ralph/internal/shared/constants"
 	"ralph/internal/shared/prd"
 )
 
@@ -25,7 +26,11 @@ func StoryStatus(passes bool) string {
 func PrintStoryList(w io.Writer, p *prd.PRD) {
 	fmt.Fprintln(w, "Stories:")
 	for _, s := range p.Stories {
-		fmt.Fprintf(w, "  %s [P%d] %s\n", StoryStatus(s.Passes), s.Priority, s.Title)
+		status := StoryStatus(s.Passes)
+		if s.Skip && !s.Passes {
+			status = "[" + prd.SkippedGlyph + "]"
+		}
+		fmt.Fprintf(w, "  %s [P%d] %s\n", status, s.Priority, s.Title)
 	}
 	fmt.Fprintln(w)
 }
@@ -56,3 +61,34 @@ func PrintStoryDetails(w io.Writer, p *prd.PRD) {
 		fmt.Fprintln(w)
 	}
 }
+
+// SummaryPrefix starts the one-line run summary so scripts can find it.
+const SummaryPrefix = "RALPH_SUMMARY"
+
+// SummaryLine is the stable, machine-readable last line of a run:
+//
+//	RALPH_SUMMARY project="X" total=5 completed=4 failed=1 iterations=9 status=failed
+//
+// Counts come from the final PRD (nil when none was written) and status from
+// the run's exit code: completed, partial, interrupted or failed.
+func SummaryLine(p *prd.PRD, exitCode int) string {
+	if p == nil {
+		p = &prd.PRD{}
+	}
+	return fmt.Sprintf("%s project=%q total=%d completed=%d failed=%d iterations=%d status=%s",
+		SummaryPrefix, p.ProjectName, len(p.Stories), p.CompletedCount(), len(p.FailedStories()), p.Iterations, SummaryStatus(exitCode))
+}
+
+// SummaryStatus is the RALPH_SUMMARY status for a run's exit code.
+func SummaryStatus(exitCode int) string {
+	switch exitCode {
+	case 0:
+		return "completed"
+	case constants.ExitPartialSuccess:
+		return "partial"
+	case constants.ExitInterrupted:
+		return "interrupted"
+	default:
+		return "failed"
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
+	"ralph/internal/shared/constants"
 	"ralph/internal/shared/prd"
 )
 
@@ -112,3 +114,36 @@ func TestPrintStoryDetailsNoDeps(t *testing.T) {
 		t.Error("output should not contain 'Depends on' when no dependencies")
 	}
 }
+
+func TestSummaryLine(t *testing.T) {
+	started := time.Unix(100, 0)
+	p := &prd.PRD{ProjectName: `Team "Invites"`, Iterations: 4, Stories: []*prd.Story{
+		{ID: "story-1", Passes: true, StartedAt: started},
+		{ID: "story-2", Skip: true},
+		{ID: "story-3", StartedAt: started},
+		{ID: "story-4"},
+	}}
+
+	tests := []struct {
+		name     string
+		p        *prd.PRD
+		exitCode int
+		want     string
+	}{
+		{name: "partial", p: p, exitCode: constants.ExitPartialSuccess,
+			want: `RALPH_SUMMARY project="Team \"Invites\"" total=4 completed=2 failed=1 iterations=4 status=partial`},
+		{name: "failed", p: p, exitCode: 1,
+			want: `RALPH_SUMMARY project="Team \"Invites\"" total=4 completed=2 failed=1 iterations=4 status=failed`},
+		{name: "interrupted before any PRD", exitCode: constants.ExitInterrupted,
+			want: `RALPH_SUMMARY project="" total=0 completed=0 failed=0 iterations=0 status=interrupted`},
+		{name: "completed", p: &prd.PRD{ProjectName: "X", Stories: []*prd.Story{{ID: "story-1", Passes: true}}},
+			want: `RALPH_SUMMARY project="X" total=1 completed=1 failed=0 iterations=0 status=completed`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummaryLine(tt.p, tt.exitCode); got != tt.want {
+				t.Fatalf("SummaryLine() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
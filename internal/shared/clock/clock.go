@@ -0,0 +1,20 @@
+// Package clock abstracts wall-clock access so timing behavior can be tested
+// without real sleeps.
+package clock
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+// Real delegates to the time package.
+type Real struct{}
+
+var _ Clock = Real{}
+
+func (Real) Now() time.Time                         { return time.Now() }
+func (Real) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (Real) Sleep(d time.Duration)                  { time.Sleep(d) }
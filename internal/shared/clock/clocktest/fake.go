@@ -0,0 +1,55 @@
+package clocktest
+
+import (
+	"sync"
+	"time"
+
+	"ralph/internal/shared/clock"
+)
+
+// Fake is a virtual clock: Sleep and After advance Now immediately instead of
+// blocking, and Slept reports the total virtual time waited.
+type Fake struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept time.Duration
+}
+
+var _ clock.Clock = (*Fake)(nil)
+
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	f.Advance(d)
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- f.Advance(d)
+	return ch
+}
+
+// Advance moves the clock forward by d and returns the new time.
+func (f *Fake) Advance(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d > 0 {
+		f.now = f.now.Add(d)
+		f.slept += d
+	}
+	return f.now
+}
+
+func (f *Fake) Slept() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.slept
+}
@@ -0,0 +1,22 @@
+package clocktest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeAdvancesWithoutBlocking(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	f.Sleep(2 * time.Second)
+	got := <-f.After(time.Minute)
+
+	want := start.Add(time.Minute + 2*time.Second)
+	if !got.Equal(want) || !f.Now().Equal(want) {
+		t.Fatalf("After() = %v, Now() = %v, want %v", got, f.Now(), want)
+	}
+	if f.Slept() != time.Minute+2*time.Second {
+		t.Fatalf("Slept() = %v, want %v", f.Slept(), time.Minute+2*time.Second)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"ralph/internal/shared/constants"
 	"ralph/internal/shared/workdir"
 )
 
@@ -22,11 +24,35 @@ const (
 	RunnerPi       RunnerKind = "pi"
 	RunnerOpenCode RunnerKind = "opencode"
 	RunnerCopilot  RunnerKind = "copilot"
+	RunnerOllama   RunnerKind = "ollama"
+	RunnerGemini   RunnerKind = "gemini"
 	RunnerMock     RunnerKind = "mock"
 	RunnerUnknown  RunnerKind = "unknown"
 )
 
+// OllamaRunnerPrefix selects a local model, e.g. RALPH_RUNNER=ollama/llama3.
+const OllamaRunnerPrefix = "ollama/"
+
+// OllamaModel returns the model name of an ollama/<model> runner value.
+func OllamaModel(runner string) string {
+	return strings.TrimPrefix(runner, OllamaRunnerPrefix)
+}
+
+// GeminiRunnerPrefix selects a Gemini CLI model, e.g. RALPH_RUNNER=gemini/gemini-2.5-pro.
+const GeminiRunnerPrefix = "gemini/"
+
+// GeminiModel returns the model name of a gemini/<model> runner value.
+func GeminiModel(runner string) string {
+	return strings.TrimPrefix(runner, GeminiRunnerPrefix)
+}
+
 func DetectRunner(runner string) RunnerKind {
+	if strings.HasPrefix(runner, OllamaRunnerPrefix) && OllamaModel(runner) != "" {
+		return RunnerOllama
+	}
+	if strings.HasPrefix(runner, GeminiRunnerPrefix) && GeminiModel(runner) != "" {
+		return RunnerGemini
+	}
 	switch runner {
 	case string(RunnerClaude):
 		return RunnerClaude
@@ -47,42 +73,166 @@ func DetectRunner(runner string) RunnerKind {
 
 const DefaultTestCommand = ""
 
+// DefaultPRDPromptFile is the optional PRD generation prompt template looked
+// up in the work dir when RALPH_PRD_PROMPT_FILE is unset.
+const DefaultPRDPromptFile = "ralph.prompt.tmpl"
+
+// PRDFormatMarkdown makes dry runs also write a prd.md review copy.
+const PRDFormatMarkdown = "md"
+
+// Spinner modes for the TUI; the empty string keeps the default animation.
+const (
+	SpinnerOff  = "off"
+	SpinnerSlow = "slow"
+	SpinnerFast = "fast"
+)
+
+// LogLevel controls how much runner output reaches the user.
+type LogLevel int
+
+const (
+	// LogLevelNormal hides lines the runners classify as internal.
+	LogLevelNormal LogLevel = iota
+	// LogLevelVerbose (--verbose) shows internal lines and debug logging.
+	LogLevelVerbose
+	// LogLevelDebug (--debug) skips runner parsing and filtering entirely and
+	// forwards every stdout/stderr line as-is.
+	LogLevelDebug
+)
+
 type Config struct {
-	Runner          string        `json:"runner"`
-	PRDFile         string        `json:"prd_file"`
-	WorkDir         string        `json:"-"`
-	TestCommand     string        `json:"test_command"`
-	BranchPrefix    string        `json:"branch_prefix"`
-	DefaultBranches []string      `json:"default_branches,omitempty"`
-	RunnerTimeout   time.Duration `json:"-"`
-	SkipCleanup     bool          `json:"-"`
-	AutoApprove     bool          `json:"-"`
-	DryRun          bool          `json:"-"`
+	Runner              string        `json:"runner"`
+	PRDFile             string        `json:"prd_file"`
+	WorkDir             string        `json:"-"`
+	TestCommand         string        `json:"test_command"`
+	BranchPrefix        string        `json:"branch_prefix"`
+	DefaultBranches     []string      `json:"default_branches,omitempty"`
+	RunnerTimeout       time.Duration `json:"-"`
+	StoryTimeBudget     time.Duration `json:"-"`
+	RateLimitCooldown   time.Duration `json:"-"`
+	RetryBackoff        time.Duration `json:"-"`
+	RecoveryAttempts    int           `json:"-"`
+	ReviewRounds        int           `json:"-"`
+	StoryPromptBudget   int           `json:"-"`
+	MaxPromptBytes      int           `json:"-"`
+	RequestsPerMinute   int           `json:"-"`
+	EmitTimeout         time.Duration `json:"-"`
+	LockTimeout         time.Duration `json:"-"`
+	LockRetryDelay      time.Duration `json:"-"`
+	MaxConsecutiveFails int           `json:"-"`
+	Concurrency         int           `json:"-"`
+	TUILogLines         int           `json:"-"`
+	WebhookURL          string        `json:"-"`
+	PRDPromptFile       string        `json:"-"`
+	PRDHistoryDir       string        `json:"-"`
+	OutputDir           string        `json:"-"`
+	EventSocket         string        `json:"-"`
+	RunnerEnv           []string      `json:"-"`
+	LogFile             string        `json:"-"`
+	LogMaxMB            int           `json:"-"`
+	HideLogPrompts      bool          `json:"-"`
+	CommitCoauthor      bool          `json:"-"`
+	UseWorktree         bool          `json:"-"`
+	RollbackOnFail      bool          `json:"-"`
+	SimpleFirst         bool          `json:"-"`
+	Verify              bool          `json:"-"`
+	OpenPR              bool          `json:"-"`
+	MaxStories          int           `json:"-"`
+	SkipCleanup         bool          `json:"-"`
+	NoBranch            bool          `json:"-"`
+	NoCommit            bool          `json:"-"`
+	Append              bool          `json:"-"`
+	AutoApprove         bool          `json:"-"`
+	DryRun              bool          `json:"-"`
+	RawOutput           bool          `json:"-"`
+	LogLevel            LogLevel      `json:"-"`
+	ShowInternal        bool          `json:"-"`
+	JSONOutput          bool          `json:"-"`
+	NormalizePriorities bool          `json:"-"`
+	DiffContext         bool          `json:"-"`
+	OpenEditor          bool          `json:"-"`
+	ScaffoldTests       bool          `json:"-"`
+	BestEffort          bool          `json:"-"`
+	NoColor             bool          `json:"-"`
+	Spinner             string        `json:"-"`
+	PRDFormat           string        `json:"-"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Runner:       DefaultRunner,
-		PRDFile:      "prd.json",
-		TestCommand:  DefaultTestCommand,
-		BranchPrefix: DefaultBranchPrefix,
+		Runner:            DefaultRunner,
+		PRDFile:           "prd.json",
+		TestCommand:       DefaultTestCommand,
+		BranchPrefix:      DefaultBranchPrefix,
+		RateLimitCooldown: constants.DefaultRateLimitCooldown,
+		EmitTimeout:       constants.DefaultEmitTimeout,
 	}
 }
 
 func Load() (*Config, error) {
+	return LoadDir("")
+}
+
+// LoadDir is Load for the project in dir rather than the current directory.
+// An empty dir means the current directory.
+func LoadDir(dir string) (*Config, error) {
+	return LoadFrom(dir, "")
+}
+
+// LoadFrom is LoadDir with the config file at path (--config) read instead
+// of ralph.config.json or ralph.config.yaml in dir. Env vars still override
+// it. An empty path behaves like LoadDir.
+func LoadFrom(dir, path string) (*Config, error) {
+	stages, err := loadStagesFrom(dir, path)
+	if err != nil {
+		return nil, err
+	}
+	return stages.resolved, nil
+}
+
+// loadStages keeps a copy of the config after each layer LoadFrom applies, so
+// Describe can tell which layer set each field.
+type loadStages struct {
+	defaults, file, env, resolved *Config
+}
+
+func loadStagesFrom(dir, path string) (*loadStages, error) {
 	cfg := DefaultConfig()
 
-	if wd, err := os.Getwd(); err == nil {
-		cfg.WorkDir = wd
+	if dir == "" {
+		if wd, err := os.Getwd(); err == nil {
+			dir = wd
+		}
 	}
+	cfg.WorkDir = dir
+	stages := &loadStages{defaults: cfg.clone()}
 
+	var fileErr error
+	if path != "" {
+		fileErr = applyExplicitFile(cfg, path)
+	} else {
+		fileErr = applyFileOverrides(cfg)
+	}
+	if fileErr != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", fileErr)
+	}
+	stages.file = cfg.clone()
 	if err := applyEnvOverrides(cfg); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	stages.env = cfg.clone()
 
 	applyWorkdirDefaults(cfg)
+	stages.resolved = cfg
 
-	return cfg, nil
+	return stages, nil
+}
+
+func (c *Config) clone() *Config {
+	copied := *c
+	copied.DefaultBranches = slices.Clone(c.DefaultBranches)
+	copied.RunnerEnv = slices.Clone(c.RunnerEnv)
+	return &copied
 }
 
 func applyWorkdirDefaults(cfg *Config) {
@@ -97,6 +247,56 @@ func applyWorkdirDefaults(cfg *Config) {
 	}
 }
 
+// RecoveryAttemptLimit is the --retry-attempts override, or the default
+// constants.MaxRecoveryAttempts.
+func (c *Config) RecoveryAttemptLimit() int {
+	if c.RecoveryAttempts > 0 {
+		return c.RecoveryAttempts
+	}
+	return constants.MaxRecoveryAttempts
+}
+
+// ReviewRoundLimit is the --max-iterations override, or the default
+// constants.MaxImplementationReviewRounds.
+func (c *Config) ReviewRoundLimit() int {
+	if c.ReviewRounds > 0 {
+		return c.ReviewRounds
+	}
+	return constants.MaxImplementationReviewRounds
+}
+
+// LogLines is how many output lines the TUI keeps (RALPH_TUI_LOG_LINES).
+func (c *Config) LogLines() int {
+	if c.TUILogLines > 0 {
+		return c.TUILogLines
+	}
+	return constants.DefaultTUILogLines
+}
+
+// PRDLockTimeout is how long to wait for the PRD file lock
+// (RALPH_LOCK_TIMEOUT).
+func (c *Config) PRDLockTimeout() time.Duration {
+	if c.LockTimeout > 0 {
+		return c.LockTimeout
+	}
+	return time.Duration(constants.FileLockTimeout) * time.Second
+}
+
+// PRDLockRetryDelay is how often a busy PRD file lock is retried
+// (RALPH_LOCK_RETRY_DELAY).
+func (c *Config) PRDLockRetryDelay() time.Duration {
+	if c.LockRetryDelay > 0 {
+		return c.LockRetryDelay
+	}
+	return time.Duration(constants.FileLockRetryDelay) * time.Millisecond
+}
+
+// StoryConcurrency is how many independent stories may run at once
+// (RALPH_CONCURRENCY); anything below 2 means one at a time.
+func (c *Config) StoryConcurrency() int {
+	return max(c.Concurrency, 1)
+}
+
 func (c *Config) ConfigPath(filename string) string {
 	if c.WorkDir == "" {
 		return filename
@@ -108,12 +308,43 @@ func (c *Config) PRDPath() string {
 	return c.ConfigPath(c.PRDFile)
 }
 
+// PRDPromptPath is the custom PRD generation template: RALPH_PRD_PROMPT_FILE
+// when set (relative paths resolve against the work dir), otherwise
+// ralph.prompt.tmpl in the work dir.
+func (c *Config) PRDPromptPath() string {
+	if c.PRDPromptFile == "" {
+		return c.ConfigPath(DefaultPRDPromptFile)
+	}
+	if filepath.IsAbs(c.PRDPromptFile) {
+		return c.PRDPromptFile
+	}
+	return c.ConfigPath(c.PRDPromptFile)
+}
+
+// PRDHistoryPath is RALPH_PRD_HISTORY_DIR with relative paths resolved
+// against the work dir, or "" when no history is kept.
+func (c *Config) PRDHistoryPath() string {
+	if c.PRDHistoryDir == "" || filepath.IsAbs(c.PRDHistoryDir) {
+		return c.PRDHistoryDir
+	}
+	return c.ConfigPath(c.PRDHistoryDir)
+}
+
+// LogFilePath is RALPH_LOG_FILE with relative paths resolved against the work
+// dir, or "" when no log file is written.
+func (c *Config) LogFilePath() string {
+	if c.LogFile == "" || filepath.IsAbs(c.LogFile) {
+		return c.LogFile
+	}
+	return c.ConfigPath(c.LogFile)
+}
+
 func (c *Config) ValidateRunner() error {
 	if c.Runner == "" {
 		return errors.New("runner cannot be empty")
 	}
 	if DetectRunner(c.Runner) == RunnerUnknown {
-		return fmt.Errorf("unknown runner %q (supported runners: claude, cursor, pi, opencode, copilot, mock)", c.Runner)
+		return fmt.Errorf("unknown runner %q (supported runners: claude, cursor, pi, opencode, copilot, ollama/<model>, gemini/<model>, mock)", c.Runner)
 	}
 	return nil
 }
@@ -125,6 +356,24 @@ func (c *Config) Validate() error {
 	if c.PRDFile == "" {
 		return errors.New("prd_file cannot be empty")
 	}
+	if c.RunnerTimeout < 0 {
+		return fmt.Errorf("runner timeout cannot be negative, got %s", c.RunnerTimeout)
+	}
+	if c.StoryTimeBudget < 0 {
+		return fmt.Errorf("story time budget cannot be negative, got %s", c.StoryTimeBudget)
+	}
+	if c.EmitTimeout < 0 {
+		return fmt.Errorf("emit timeout cannot be negative, got %s", c.EmitTimeout)
+	}
+	if c.RetryBackoff < 0 {
+		return fmt.Errorf("retry backoff cannot be negative, got %s", c.RetryBackoff)
+	}
+	if c.LockTimeout < 0 || c.LockRetryDelay < 0 {
+		return fmt.Errorf("lock timeout and retry delay cannot be negative, got %s and %s", c.LockTimeout, c.LockRetryDelay)
+	}
+	if c.ReviewRounds < 0 || c.RecoveryAttempts < 0 {
+		return fmt.Errorf("max_iterations and retry_attempts cannot be negative, got %d and %d", c.ReviewRounds, c.RecoveryAttempts)
+	}
 
 	// Prevent path traversal by requiring a simple filename.
 	if filepath.Base(c.PRDFile) != c.PRDFile {
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"ralph/internal/shared/constants"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -33,6 +35,11 @@ func TestDetectRunner(t *testing.T) {
 		{"opencode", RunnerOpenCode},
 		{"mock", RunnerMock},
 		{"copilot", RunnerCopilot},
+		{"ollama/llama3", RunnerOllama},
+		{"ollama/", RunnerUnknown},
+		{"ollama", RunnerUnknown},
+		{"gemini/gemini-2.5-pro", RunnerGemini},
+		{"gemini/", RunnerUnknown},
 		{"invalid-runner", RunnerUnknown},
 		{"", RunnerUnknown},
 	}
@@ -127,6 +134,84 @@ func TestLoadEnvRunnerTimeoutRejectsInvalidDuration(t *testing.T) {
 	}
 }
 
+func TestLoadEnvRateLimitCooldown(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.RateLimitCooldown != constants.DefaultRateLimitCooldown {
+		t.Errorf("default RateLimitCooldown = %v, want %v", cfg.RateLimitCooldown, constants.DefaultRateLimitCooldown)
+	}
+
+	os.Setenv("RALPH_RATE_LIMIT_COOLDOWN", "2m")
+	defer os.Unsetenv("RALPH_RATE_LIMIT_COOLDOWN")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.RateLimitCooldown != 2*time.Minute {
+		t.Errorf("RateLimitCooldown = %v, want %v", cfg.RateLimitCooldown, 2*time.Minute)
+	}
+
+	os.Setenv("RALPH_RATE_LIMIT_COOLDOWN", "soon")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RALPH_RATE_LIMIT_COOLDOWN") {
+		t.Errorf("Load() error = %v, want mention RALPH_RATE_LIMIT_COOLDOWN", err)
+	}
+}
+
+func TestLoadEnvStoryPromptBudget(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	os.Setenv("RALPH_STORY_PROMPT_BUDGET", "12000")
+	defer os.Unsetenv("RALPH_STORY_PROMPT_BUDGET")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.StoryPromptBudget != 12000 {
+		t.Errorf("StoryPromptBudget = %d, want 12000", cfg.StoryPromptBudget)
+	}
+
+	os.Setenv("RALPH_STORY_PROMPT_BUDGET", "lots")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RALPH_STORY_PROMPT_BUDGET") {
+		t.Errorf("Load() error = %v, want mention RALPH_STORY_PROMPT_BUDGET", err)
+	}
+}
+
+func TestLoadEnvMaxPromptBytes(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	t.Setenv("RALPH_MAX_PROMPT_BYTES", "200000")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.MaxPromptBytes != 200000 {
+		t.Errorf("MaxPromptBytes = %d, want 200000", cfg.MaxPromptBytes)
+	}
+
+	for _, bad := range []string{"lots", "-1"} {
+		t.Setenv("RALPH_MAX_PROMPT_BYTES", bad)
+		if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RALPH_MAX_PROMPT_BYTES") {
+			t.Errorf("Load() with %q error = %v, want mention RALPH_MAX_PROMPT_BYTES", bad, err)
+		}
+	}
+}
+
 func TestLoadEnvYoloEnablesAutoApprove(t *testing.T) {
 	origDir, _ := os.Getwd()
 	tmpDir := t.TempDir()
@@ -147,6 +232,127 @@ func TestLoadEnvYoloEnablesAutoApprove(t *testing.T) {
 	}
 }
 
+func TestLoadEnvCommitCoauthor(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	os.Setenv("RALPH_COMMIT_COAUTHOR", "1")
+	defer os.Unsetenv("RALPH_COMMIT_COAUTHOR")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+
+	if !cfg.CommitCoauthor {
+		t.Error("CommitCoauthor should be true when RALPH_COMMIT_COAUTHOR=1")
+	}
+}
+
+func TestLoadEnvConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "unset", value: "", want: 1},
+		{name: "parallel", value: "3", want: 3},
+		{name: "zero", value: "0", wantErr: true},
+		{name: "not a number", value: "many", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origDir, _ := os.Getwd()
+			os.Chdir(t.TempDir())
+			defer os.Chdir(origDir)
+
+			os.Clearenv()
+			if tt.value != "" {
+				os.Setenv("RALPH_CONCURRENCY", tt.value)
+				defer os.Unsetenv("RALPH_CONCURRENCY")
+			}
+
+			cfg, err := Load()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "RALPH_CONCURRENCY") {
+					t.Fatalf("Load() error = %v, want mention RALPH_CONCURRENCY", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.StoryConcurrency(); got != tt.want {
+				t.Errorf("StoryConcurrency() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEnvTUILogLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "unset", value: "", want: constants.DefaultTUILogLines},
+		{name: "custom", value: "5000", want: 5000},
+		{name: "zero", value: "0", wantErr: true},
+		{name: "not a number", value: "lots", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origDir, _ := os.Getwd()
+			os.Chdir(t.TempDir())
+			defer os.Chdir(origDir)
+
+			os.Clearenv()
+			if tt.value != "" {
+				os.Setenv("RALPH_TUI_LOG_LINES", tt.value)
+				defer os.Unsetenv("RALPH_TUI_LOG_LINES")
+			}
+
+			cfg, err := Load()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "RALPH_TUI_LOG_LINES") {
+					t.Fatalf("Load() error = %v, want mention RALPH_TUI_LOG_LINES", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.LogLines(); got != tt.want {
+				t.Errorf("LogLines() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDirReadsProjectInDir(t *testing.T) {
+	os.Clearenv()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"prd_file": "plan.json"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if cfg.WorkDir != dir {
+		t.Errorf("WorkDir = %q, want %q", cfg.WorkDir, dir)
+	}
+	if got, want := cfg.PRDPath(), filepath.Join(dir, "plan.json"); got != want {
+		t.Errorf("PRDPath() = %q, want %q", got, want)
+	}
+}
+
 func TestLoadSetsWorkDir(t *testing.T) {
 	origDir, _ := os.Getwd()
 	tmpDir := t.TempDir()
@@ -207,6 +413,10 @@ func TestValidateRunner(t *testing.T) {
 		{"pi", false},
 		{"cursor", false},
 		{"copilot", false},
+		{"ollama/qwen2.5-coder:7b", false},
+		{"ollama/", true},
+		{"gemini/gemini-2.5-flash", false},
+		{"gemini/", true},
 		{"pi/", true},
 		{"invalid-runner", true},
 		{"", true},
@@ -243,6 +453,8 @@ func TestValidate(t *testing.T) {
 		{name: "valid default config", config: DefaultConfig()},
 		{name: "invalid runner", config: &Config{Runner: "invalid-runner", PRDFile: "prd.json"}, wantErr: true},
 		{name: "empty prd_file", config: &Config{Runner: DefaultRunner, PRDFile: ""}, wantErr: true},
+		{name: "negative retry backoff", config: &Config{Runner: DefaultRunner, PRDFile: "prd.json", RetryBackoff: -time.Second}, wantErr: true},
+		{name: "negative runner timeout", config: &Config{Runner: DefaultRunner, PRDFile: "prd.json", RunnerTimeout: -time.Second}, wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -284,3 +496,277 @@ func TestDefaultConfigSkipCleanupIsFalse(t *testing.T) {
 		t.Error("SkipCleanup should default to false")
 	}
 }
+
+func TestLoadEnvMaxConsecutiveFailures(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	os.Setenv("RALPH_MAX_CONSECUTIVE_FAILURES", "3")
+	defer os.Unsetenv("RALPH_MAX_CONSECUTIVE_FAILURES")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.MaxConsecutiveFails != 3 {
+		t.Errorf("MaxConsecutiveFails = %d, want 3", cfg.MaxConsecutiveFails)
+	}
+
+	os.Setenv("RALPH_MAX_CONSECUTIVE_FAILURES", "-1")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RALPH_MAX_CONSECUTIVE_FAILURES") {
+		t.Errorf("Load() error = %v, want mention RALPH_MAX_CONSECUTIVE_FAILURES", err)
+	}
+}
+
+func TestLoadEnvRetryBackoff(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	os.Setenv("RALPH_RETRY_BACKOFF", "10s")
+	defer os.Unsetenv("RALPH_RETRY_BACKOFF")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.RetryBackoff != 10*time.Second {
+		t.Errorf("RetryBackoff = %v, want 10s", cfg.RetryBackoff)
+	}
+
+	os.Setenv("RALPH_RETRY_BACKOFF", "later")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RALPH_RETRY_BACKOFF") {
+		t.Errorf("Load() error = %v, want mention RALPH_RETRY_BACKOFF", err)
+	}
+}
+
+func TestLoadEnvEmitTimeout(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.EmitTimeout != constants.DefaultEmitTimeout {
+		t.Errorf("EmitTimeout = %v, want default %v", cfg.EmitTimeout, constants.DefaultEmitTimeout)
+	}
+
+	t.Setenv("RALPH_EMIT_TIMEOUT", "2s")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.EmitTimeout != 2*time.Second {
+		t.Errorf("EmitTimeout = %v, want 2s", cfg.EmitTimeout)
+	}
+
+	for _, bad := range []string{"soon", "-1s"} {
+		t.Setenv("RALPH_EMIT_TIMEOUT", bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() with RALPH_EMIT_TIMEOUT=%q error = nil, want rejection", bad)
+		}
+	}
+}
+
+func TestLoadEnvLockTimeouts(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.PRDLockTimeout() != time.Duration(constants.FileLockTimeout)*time.Second || cfg.PRDLockRetryDelay() != time.Duration(constants.FileLockRetryDelay)*time.Millisecond {
+		t.Errorf("lock wait = %v/%v, want the constants defaults", cfg.PRDLockTimeout(), cfg.PRDLockRetryDelay())
+	}
+
+	t.Setenv("RALPH_LOCK_TIMEOUT", "2m")
+	t.Setenv("RALPH_LOCK_RETRY_DELAY", "250ms")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.PRDLockTimeout() != 2*time.Minute || cfg.PRDLockRetryDelay() != 250*time.Millisecond {
+		t.Errorf("lock wait = %v/%v, want 2m/250ms", cfg.PRDLockTimeout(), cfg.PRDLockRetryDelay())
+	}
+
+	for _, name := range []string{"RALPH_LOCK_TIMEOUT", "RALPH_LOCK_RETRY_DELAY"} {
+		for _, bad := range []string{"soon", "0s", "-1s"} {
+			t.Setenv(name, bad)
+			if _, err := Load(); err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("Load() with %s=%q error = %v, want rejection", name, bad, err)
+			}
+		}
+		t.Setenv(name, "1s")
+	}
+}
+
+func TestLoadEnvLogFile(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	t.Setenv("RALPH_LOG_FILE", "logs/ralph.log")
+	t.Setenv("RALPH_LOG_MAX_MB", "5")
+	t.Setenv("RALPH_LOG_PROMPTS", "false")
+	cfg, err := LoadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v, want nil", err)
+	}
+	if cfg.LogFilePath() != filepath.Join(tmpDir, "logs", "ralph.log") || cfg.LogMaxMB != 5 || !cfg.HideLogPrompts {
+		t.Errorf("log settings = %q/%d/%v, want the work-dir path, 5 MB and prompts hidden", cfg.LogFilePath(), cfg.LogMaxMB, cfg.HideLogPrompts)
+	}
+
+	for name, bad := range map[string]string{"RALPH_LOG_MAX_MB": "0", "RALPH_LOG_PROMPTS": "sometimes"} {
+		t.Setenv(name, bad)
+		if _, err := Load(); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("Load() with %s=%q error = %v, want rejection", name, bad, err)
+		}
+		os.Unsetenv(name)
+	}
+}
+
+func TestLoadEnvRunnerEnv(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	t.Setenv("RALPH_RUNNER_ENV", "OPENAI_BASE_URL=http://localhost:8080/v1, GOOGLE_CLOUD_PROJECT=demo,EMPTY=")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	want := []string{"OPENAI_BASE_URL=http://localhost:8080/v1", "GOOGLE_CLOUD_PROJECT=demo", "EMPTY="}
+	if strings.Join(cfg.RunnerEnv, "|") != strings.Join(want, "|") {
+		t.Errorf("RunnerEnv = %q, want %q", cfg.RunnerEnv, want)
+	}
+
+	for _, bad := range []string{"NOVALUE", "=value"} {
+		t.Setenv("RALPH_RUNNER_ENV", bad)
+		if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RALPH_RUNNER_ENV") {
+			t.Errorf("Load() with RALPH_RUNNER_ENV=%q error = %v, want rejection", bad, err)
+		}
+	}
+}
+
+func TestRecoveryAndReviewLimits(t *testing.T) {
+	cfg := DefaultConfig()
+	if got := cfg.RecoveryAttemptLimit(); got != constants.MaxRecoveryAttempts {
+		t.Errorf("RecoveryAttemptLimit() = %d, want default %d", got, constants.MaxRecoveryAttempts)
+	}
+	if got := cfg.ReviewRoundLimit(); got != constants.MaxImplementationReviewRounds {
+		t.Errorf("ReviewRoundLimit() = %d, want default %d", got, constants.MaxImplementationReviewRounds)
+	}
+
+	cfg.RecoveryAttempts = 5
+	cfg.ReviewRounds = 3
+	if got := cfg.RecoveryAttemptLimit(); got != 5 {
+		t.Errorf("RecoveryAttemptLimit() = %d, want override 5", got)
+	}
+	if got := cfg.ReviewRoundLimit(); got != 3 {
+		t.Errorf("ReviewRoundLimit() = %d, want override 3", got)
+	}
+}
+
+func TestLoadEnvWebhookURL(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	os.Setenv("RALPH_WEBHOOK_URL", "https://hooks.example.com/ralph")
+	defer os.Unsetenv("RALPH_WEBHOOK_URL")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.WebhookURL != "https://hooks.example.com/ralph" {
+		t.Errorf("WebhookURL = %q", cfg.WebhookURL)
+	}
+
+	os.Setenv("RALPH_WEBHOOK_URL", "hooks.example.com")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "RALPH_WEBHOOK_URL") {
+		t.Errorf("Load() error = %v, want mention RALPH_WEBHOOK_URL", err)
+	}
+}
+
+func TestPRDPromptPath(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{name: "default", file: "", want: filepath.Join("/work", DefaultPRDPromptFile)},
+		{name: "relative", file: "prompts/prd.tmpl", want: filepath.Join("/work", "prompts/prd.tmpl")},
+		{name: "absolute", file: "/etc/ralph/prd.tmpl", want: "/etc/ralph/prd.tmpl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{WorkDir: "/work", PRDPromptFile: tt.file}
+			if got := cfg.PRDPromptPath(); got != tt.want {
+				t.Errorf("PRDPromptPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEnvStoryTimeBudget(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	t.Setenv("RALPH_STORY_TIME_BUDGET", "45m")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.StoryTimeBudget != 45*time.Minute {
+		t.Errorf("StoryTimeBudget = %v, want 45m", cfg.StoryTimeBudget)
+	}
+
+	for _, bad := range []string{"forever", "-1m"} {
+		t.Setenv("RALPH_STORY_TIME_BUDGET", bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() with RALPH_STORY_TIME_BUDGET=%q error = nil, want rejection", bad)
+		}
+	}
+}
+
+func TestLoadEnvRequestsPerMinute(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.Clearenv()
+	t.Setenv("RALPH_REQUESTS_PER_MINUTE", "30")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want nil", err)
+	}
+	if cfg.RequestsPerMinute != 30 {
+		t.Errorf("RequestsPerMinute = %d, want 30", cfg.RequestsPerMinute)
+	}
+
+	for _, bad := range []string{"lots", "-1"} {
+		t.Setenv("RALPH_REQUESTS_PER_MINUTE", bad)
+		if _, err := Load(); err == nil {
+			t.Errorf("Load() with RALPH_REQUESTS_PER_MINUTE=%q error = nil, want rejection", bad)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Source names the layer that decided a setting's value.
+type Source string
+
+const (
+	SourceDefault  Source = "default"
+	SourceFile     Source = "file"
+	SourceEnv      Source = "env"
+	SourceDetected Source = "detected" // inferred from the work dir, e.g. the test command
+	SourceFlag     Source = "flag"
+)
+
+// secretMask replaces the value of secretFields in Describe output.
+const secretMask = "********"
+
+// secretFields are Config fields whose values Describe masks. A Slack-style
+// webhook URL carries its own credential.
+var secretFields = map[string]bool{
+	"WebhookURL": true,
+}
+
+// Setting is one resolved Config field as `ralph config` prints it.
+type Setting struct {
+	Name   string
+	Value  string
+	Source Source
+}
+
+// Describe loads the configuration like LoadFrom and reports every Config
+// field with its value and the layer that set it. A field that a later layer
+// sets to the value it already had is credited to the earlier layer. Secret
+// fields are masked once set.
+func Describe(dir, path string) (*Config, []Setting, error) {
+	stages, err := loadStagesFrom(dir, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defaults := reflect.ValueOf(stages.defaults).Elem()
+	file := reflect.ValueOf(stages.file).Elem()
+	env := reflect.ValueOf(stages.env).Elem()
+	resolved := reflect.ValueOf(stages.resolved).Elem()
+
+	fields := resolved.Type()
+	settings := make([]Setting, 0, fields.NumField())
+	for i := range fields.NumField() {
+		name := fields.Field(i).Name
+		if name == "WorkDir" {
+			continue
+		}
+		value := resolved.Field(i).Interface()
+		source := SourceDefault
+		switch {
+		case !reflect.DeepEqual(value, env.Field(i).Interface()):
+			source = SourceDetected
+		case !reflect.DeepEqual(value, file.Field(i).Interface()):
+			source = SourceEnv
+		case !reflect.DeepEqual(value, defaults.Field(i).Interface()):
+			source = SourceFile
+		}
+		settings = append(settings, Setting{Name: name, Value: formatSetting(name, value), Source: source})
+	}
+	return stages.resolved, settings, nil
+}
+
+func formatSetting(name string, value any) string {
+	if secretFields[name] && !reflect.ValueOf(value).IsZero() {
+		return secretMask
+	}
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []string:
+		if len(v) == 0 {
+			return "[]"
+		}
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(value)
+}
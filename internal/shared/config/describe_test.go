@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDescribeReportsEachSettingsSource(t *testing.T) {
+	os.Clearenv()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"runner": "opencode", "prd_file": "plan.json", "branch_prefix": "feature"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RALPH_RUNNER", "pi")
+	t.Setenv("RALPH_WEBHOOK_URL", "https://hooks.example.com/secret")
+
+	cfg, settings, err := Describe(dir, "")
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if cfg.Runner != "pi" || cfg.PRDFile != "plan.json" {
+		t.Fatalf("Describe() config = runner %q, prd %q; want pi and plan.json", cfg.Runner, cfg.PRDFile)
+	}
+
+	got := make(map[string]Setting, len(settings))
+	for _, s := range settings {
+		got[s.Name] = s
+	}
+	want := map[string]Setting{
+		"Runner":       {Name: "Runner", Value: `"pi"`, Source: SourceEnv},
+		"PRDFile":      {Name: "PRDFile", Value: `"plan.json"`, Source: SourceFile},
+		"BranchPrefix": {Name: "BranchPrefix", Value: `"feature"`, Source: SourceDefault},
+		"TestCommand":  {Name: "TestCommand", Value: `"go test ./..."`, Source: SourceDetected},
+		"WebhookURL":   {Name: "WebhookURL", Value: secretMask, Source: SourceEnv},
+		"EmitTimeout":  {Name: "EmitTimeout", Value: "100ms", Source: SourceDefault},
+	}
+	for name, w := range want {
+		if got[name] != w {
+			t.Errorf("setting %s = %+v, want %+v", name, got[name], w)
+		}
+	}
+	if _, ok := got["WorkDir"]; ok {
+		t.Error("Describe() should leave out WorkDir")
+	}
+}
+
+func TestDescribeRejectsInvalidConfig(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("RALPH_RUNNER", "bogus")
+
+	if _, _, err := Describe(t.TempDir(), ""); err == nil {
+		t.Fatal("Describe() should fail for an unknown runner")
+	}
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -14,6 +16,24 @@ func applyEnvOverrides(cfg *Config) error {
 	if os.Getenv("RALPH_YOLO") == "1" {
 		cfg.AutoApprove = true
 	}
+	if os.Getenv("RALPH_COMMIT_COAUTHOR") == "1" {
+		cfg.CommitCoauthor = true
+	}
+	if os.Getenv("RALPH_USE_WORKTREE") == "1" {
+		cfg.UseWorktree = true
+	}
+	if os.Getenv("RALPH_ROLLBACK_ON_FAIL") == "1" {
+		cfg.RollbackOnFail = true
+	}
+	if os.Getenv("RALPH_SIMPLE_FIRST") == "1" {
+		cfg.SimpleFirst = true
+	}
+	if os.Getenv("RALPH_VERIFY") == "1" {
+		cfg.Verify = true
+	}
+	if os.Getenv("RALPH_OPEN_PR") == "1" {
+		cfg.OpenPR = true
+	}
 	if rawTimeout := os.Getenv("RALPH_RUNNER_TIMEOUT"); rawTimeout != "" {
 		timeout, err := time.ParseDuration(rawTimeout)
 		if err != nil {
@@ -21,6 +41,130 @@ func applyEnvOverrides(cfg *Config) error {
 		}
 		cfg.RunnerTimeout = timeout
 	}
+	if rawBudget := os.Getenv("RALPH_STORY_TIME_BUDGET"); rawBudget != "" {
+		budget, err := time.ParseDuration(rawBudget)
+		if err != nil {
+			return fmt.Errorf("RALPH_STORY_TIME_BUDGET must be a Go duration: %w", err)
+		}
+		cfg.StoryTimeBudget = budget
+	}
+	if rawEmit := os.Getenv("RALPH_EMIT_TIMEOUT"); rawEmit != "" {
+		timeout, err := time.ParseDuration(rawEmit)
+		if err != nil {
+			return fmt.Errorf("RALPH_EMIT_TIMEOUT must be a Go duration: %w", err)
+		}
+		cfg.EmitTimeout = timeout
+	}
+	if rawLock := os.Getenv("RALPH_LOCK_TIMEOUT"); rawLock != "" {
+		timeout, err := time.ParseDuration(rawLock)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("RALPH_LOCK_TIMEOUT must be a positive Go duration: %q", rawLock)
+		}
+		cfg.LockTimeout = timeout
+	}
+	if rawDelay := os.Getenv("RALPH_LOCK_RETRY_DELAY"); rawDelay != "" {
+		delay, err := time.ParseDuration(rawDelay)
+		if err != nil || delay <= 0 {
+			return fmt.Errorf("RALPH_LOCK_RETRY_DELAY must be a positive Go duration: %q", rawDelay)
+		}
+		cfg.LockRetryDelay = delay
+	}
+	if rawCooldown := os.Getenv("RALPH_RATE_LIMIT_COOLDOWN"); rawCooldown != "" {
+		cooldown, err := time.ParseDuration(rawCooldown)
+		if err != nil {
+			return fmt.Errorf("RALPH_RATE_LIMIT_COOLDOWN must be a Go duration: %w", err)
+		}
+		cfg.RateLimitCooldown = cooldown
+	}
+	if rawBackoff := os.Getenv("RALPH_RETRY_BACKOFF"); rawBackoff != "" {
+		backoff, err := time.ParseDuration(rawBackoff)
+		if err != nil {
+			return fmt.Errorf("RALPH_RETRY_BACKOFF must be a Go duration: %w", err)
+		}
+		cfg.RetryBackoff = backoff
+	}
+	if rawBudget := os.Getenv("RALPH_STORY_PROMPT_BUDGET"); rawBudget != "" {
+		budget, err := strconv.Atoi(rawBudget)
+		if err != nil || budget < 0 {
+			return fmt.Errorf("RALPH_STORY_PROMPT_BUDGET must be a non-negative character count: %q", rawBudget)
+		}
+		cfg.StoryPromptBudget = budget
+	}
+	if rawMax := os.Getenv("RALPH_MAX_PROMPT_BYTES"); rawMax != "" {
+		maxBytes, err := strconv.Atoi(rawMax)
+		if err != nil || maxBytes < 0 {
+			return fmt.Errorf("RALPH_MAX_PROMPT_BYTES must be a non-negative byte count: %q", rawMax)
+		}
+		cfg.MaxPromptBytes = maxBytes
+	}
+	if rawRate := os.Getenv("RALPH_REQUESTS_PER_MINUTE"); rawRate != "" {
+		rate, err := strconv.Atoi(rawRate)
+		if err != nil || rate < 0 {
+			return fmt.Errorf("RALPH_REQUESTS_PER_MINUTE must be a non-negative request count: %q", rawRate)
+		}
+		cfg.RequestsPerMinute = rate
+	}
+	if rawMax := os.Getenv("RALPH_MAX_CONSECUTIVE_FAILURES"); rawMax != "" {
+		maxFails, err := strconv.Atoi(rawMax)
+		if err != nil || maxFails < 0 {
+			return fmt.Errorf("RALPH_MAX_CONSECUTIVE_FAILURES must be a non-negative story count: %q", rawMax)
+		}
+		cfg.MaxConsecutiveFails = maxFails
+	}
+	if rawConcurrency := os.Getenv("RALPH_CONCURRENCY"); rawConcurrency != "" {
+		concurrency, err := strconv.Atoi(rawConcurrency)
+		if err != nil || concurrency < 1 {
+			return fmt.Errorf("RALPH_CONCURRENCY must be a positive story count: %q", rawConcurrency)
+		}
+		cfg.Concurrency = concurrency
+	}
+	if rawLines := os.Getenv("RALPH_TUI_LOG_LINES"); rawLines != "" {
+		lines, err := strconv.Atoi(rawLines)
+		if err != nil || lines < 1 {
+			return fmt.Errorf("RALPH_TUI_LOG_LINES must be a positive line count: %q", rawLines)
+		}
+		cfg.TUILogLines = lines
+	}
+	if rawURL := os.Getenv("RALPH_WEBHOOK_URL"); rawURL != "" {
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("RALPH_WEBHOOK_URL must be an http(s) URL: %q", rawURL)
+		}
+		cfg.WebhookURL = rawURL
+	}
+	if path := os.Getenv("RALPH_PRD_PROMPT_FILE"); path != "" {
+		cfg.PRDPromptFile = path
+	}
+	if dir := os.Getenv("RALPH_PRD_HISTORY_DIR"); dir != "" {
+		cfg.PRDHistoryDir = dir
+	}
+	if path := os.Getenv("RALPH_LOG_FILE"); path != "" {
+		cfg.LogFile = path
+	}
+	if rawMax := os.Getenv("RALPH_LOG_MAX_MB"); rawMax != "" {
+		maxMB, err := strconv.Atoi(rawMax)
+		if err != nil || maxMB < 1 {
+			return fmt.Errorf("RALPH_LOG_MAX_MB must be a positive size in megabytes: %q", rawMax)
+		}
+		cfg.LogMaxMB = maxMB
+	}
+	if rawPrompts := os.Getenv("RALPH_LOG_PROMPTS"); rawPrompts != "" {
+		logPrompts, err := strconv.ParseBool(rawPrompts)
+		if err != nil {
+			return fmt.Errorf("RALPH_LOG_PROMPTS must be true or false: %q", rawPrompts)
+		}
+		cfg.HideLogPrompts = !logPrompts
+	}
+	if raw := os.Getenv("RALPH_RUNNER_ENV"); raw != "" {
+		env, err := parseRunnerEnv(raw)
+		if err != nil {
+			return err
+		}
+		cfg.RunnerEnv = env
+	}
+	if path := os.Getenv("RALPH_EVENT_SOCKET"); path != "" {
+		cfg.EventSocket = path
+	}
 	if prefix := os.Getenv("RALPH_BRANCH_PREFIX"); prefix != "" {
 		cfg.BranchPrefix = prefix
 	}
@@ -46,3 +190,20 @@ func splitCommaList(raw string) []string {
 	}
 	return out
 }
+
+// parseRunnerEnv splits RALPH_RUNNER_ENV's comma-separated KEY=VALUE pairs.
+func parseRunnerEnv(raw string) ([]string, error) {
+	var env []string
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, _, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("RALPH_RUNNER_ENV entries must be KEY=VALUE: %q", pair)
+		}
+		env = append(env, pair)
+	}
+	return env, nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadEnvFile reads KEY=VALUE lines from path into the process environment so
+// both config loading and spawned runner CLIs see them. Blank lines and lines
+// starting with # are skipped; a leading "export " and matching surrounding
+// quotes on the value are stripped.
+func LoadEnvFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening env file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNo)
+		}
+		if err := os.Setenv(key, unquoteEnvValue(strings.TrimSpace(value))); err != nil {
+			return fmt.Errorf("%s:%d: setting %s: %w", path, lineNo, key, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading env file: %w", err)
+	}
+	return nil
+}
+
+func unquoteEnvValue(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
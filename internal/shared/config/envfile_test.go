@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeEnvFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write env file: %v", err)
+	}
+	return path
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "plain values with comments and blanks",
+			content: "# credentials\n\nRALPH_TEST_ENV_A=one\n  RALPH_TEST_ENV_B = two  \n",
+			want:    map[string]string{"RALPH_TEST_ENV_A": "one", "RALPH_TEST_ENV_B": "two"},
+		},
+		{
+			name:    "export prefix and quotes",
+			content: "export RALPH_TEST_ENV_A=\"quoted value\"\nRALPH_TEST_ENV_B='single'\n",
+			want:    map[string]string{"RALPH_TEST_ENV_A": "quoted value", "RALPH_TEST_ENV_B": "single"},
+		},
+		{
+			name:    "value containing equals",
+			content: "RALPH_TEST_ENV_A=a=b\n",
+			want:    map[string]string{"RALPH_TEST_ENV_A": "a=b"},
+		},
+		{
+			name:    "missing equals",
+			content: "RALPH_TEST_ENV_A\n",
+			wantErr: ":1: expected KEY=VALUE",
+		},
+		{
+			name:    "empty key",
+			content: "# ok\n=value\n",
+			wantErr: ":2: expected KEY=VALUE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RALPH_TEST_ENV_A", "")
+			t.Setenv("RALPH_TEST_ENV_B", "")
+			err := LoadEnvFile(writeEnvFile(t, tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadEnvFile() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadEnvFile() error = %v", err)
+			}
+			for key, want := range tt.want {
+				if got := os.Getenv(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadEnvFileMissing(t *testing.T) {
+	if err := LoadEnvFile(filepath.Join(t.TempDir(), "absent.env")); err == nil {
+		t.Fatal("LoadEnvFile() should fail for a missing file")
+	}
+}
+
+func TestLoadEnvFileVisibleToChildProcess(t *testing.T) {
+	t.Setenv("RALPH_TEST_ENV_A", "")
+	if err := LoadEnvFile(writeEnvFile(t, "RALPH_TEST_ENV_A=from-env-file\n")); err != nil {
+		t.Fatalf("LoadEnvFile() error = %v", err)
+	}
+	env := exec.Command("claude").Environ()
+	found := false
+	for _, kv := range env {
+		if kv == "RALPH_TEST_ENV_A=from-env-file" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("runner child environment should include RALPH_TEST_ENV_A from the env file")
+	}
+}
+
+func TestLoadEnvFileFeedsConfigLoad(t *testing.T) {
+	t.Setenv("RALPH_BRANCH_PREFIX", "")
+	if err := LoadEnvFile(writeEnvFile(t, "RALPH_BRANCH_PREFIX=envfile\n")); err != nil {
+		t.Fatalf("LoadEnvFile() error = %v", err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.BranchPrefix != "envfile" {
+		t.Fatalf("BranchPrefix = %q, want envfile", cfg.BranchPrefix)
+	}
+}
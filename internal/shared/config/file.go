@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the optional per-project config file read from the work dir.
+const FileName = "ralph.config.json"
+
+// applyFileOverrides reads FileName or YAMLFileName from the work dir; having
+// both is an error so it is never ambiguous which one is in effect.
+func applyFileOverrides(cfg *Config) error {
+	hasYAML, err := applyYAMLFile(cfg)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(cfg.ConfigPath(FileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err == nil && hasYAML {
+		return fmt.Errorf("both %s and %s exist; keep only one", FileName, YAMLFileName)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", FileName, err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parsing %s: %w", FileName, err)
+	}
+	return nil
+}
+
+// applyExplicitFile reads the config file passed with --config in place of
+// the work dir lookup. A .yaml or .yml extension selects the YAML format;
+// anything else is parsed as JSON.
+func applyExplicitFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("config file %s does not exist", path)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return applyYAMLData(cfg, path, data)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// RunnerConfigured reports whether the runner was chosen explicitly, either
+// through RALPH_RUNNER or the config file in workDir.
+func RunnerConfigured(workDir string) bool {
+	if os.Getenv("RALPH_RUNNER") != "" {
+		return true
+	}
+	if data, err := os.ReadFile(filepath.Join(workDir, YAMLFileName)); err == nil {
+		file, err := decodeYAMLFile(data)
+		return err == nil && file.Runner != nil
+	}
+	fields, err := readFileFields(workDir)
+	if err != nil {
+		return false
+	}
+	_, ok := fields["runner"]
+	return ok
+}
+
+// SaveRunner persists runner to the config file in workDir, keeping any
+// other keys already present. An existing YAMLFileName is updated in place;
+// otherwise FileName is written.
+func SaveRunner(workDir, runner string) error {
+	if DetectRunner(runner) == RunnerUnknown {
+		return fmt.Errorf("unknown runner %q", runner)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, YAMLFileName)); err == nil {
+		return saveYAMLRunner(workDir, runner)
+	}
+	fields, err := readFileFields(workDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+	encoded, err := json.Marshal(runner)
+	if err != nil {
+		return err
+	}
+	fields["runner"] = encoded
+	data, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(workDir, FileName), append(data, '\n'), 0o644)
+}
+
+func readFileFields(workDir string) (map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(filepath.Join(workDir, FileName))
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", FileName, err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,307 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadReadsConfigFile(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+	os.Clearenv()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, FileName), []byte(`{"runner":"pi","branch_prefix":"topic"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Runner != "pi" || cfg.BranchPrefix != "topic" {
+		t.Errorf("Runner = %q, BranchPrefix = %q, want pi/topic from file", cfg.Runner, cfg.BranchPrefix)
+	}
+}
+
+func TestLoadEnvOverridesConfigFile(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+	os.Clearenv()
+	t.Setenv("RALPH_RUNNER", "opencode")
+
+	if err := os.WriteFile(filepath.Join(tmpDir, FileName), []byte(`{"runner":"pi"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Runner != "opencode" {
+		t.Errorf("Runner = %q, want env value opencode", cfg.Runner)
+	}
+}
+
+func TestLoadRejectsMalformedConfigFile(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+	os.Clearenv()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, FileName), []byte(`{"runner":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() error = nil, want parse error for malformed config file")
+	}
+}
+
+func TestSaveRunnerPreservesOtherKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, FileName), []byte(`{"branch_prefix":"topic"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveRunner(tmpDir, "cursor"); err != nil {
+		t.Fatalf("SaveRunner() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("saved file is not JSON: %v", err)
+	}
+	if got["runner"] != "cursor" || got["branch_prefix"] != "topic" {
+		t.Errorf("saved config = %v, want runner=cursor and branch_prefix kept", got)
+	}
+}
+
+func TestSaveRunnerRejectsUnknownRunner(t *testing.T) {
+	if err := SaveRunner(t.TempDir(), "nope"); err == nil {
+		t.Fatal("SaveRunner() error = nil, want unknown runner error")
+	}
+}
+
+func TestRunnerConfigured(t *testing.T) {
+	os.Clearenv()
+	tmpDir := t.TempDir()
+	if RunnerConfigured(tmpDir) {
+		t.Fatal("RunnerConfigured() = true with no env or file")
+	}
+
+	if err := SaveRunner(tmpDir, "pi"); err != nil {
+		t.Fatal(err)
+	}
+	if !RunnerConfigured(tmpDir) {
+		t.Fatal("RunnerConfigured() = false after saving runner to file")
+	}
+
+	t.Setenv("RALPH_RUNNER", "claude")
+	if !RunnerConfigured(t.TempDir()) {
+		t.Fatal("RunnerConfigured() = false with RALPH_RUNNER set")
+	}
+}
+
+func TestLoadReadsYAMLConfigFile(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+	os.Clearenv()
+
+	yaml := "# project settings\n" +
+		"runner: pi\n" +
+		"prd_file: \"plan.json\"\n" +
+		"max_iterations: 4\n" +
+		"retry_attempts: 2 # per story\n" +
+		"default_branches:\n" +
+		"  - main\n" +
+		"  - 'trunk'\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, YAMLFileName), []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Runner != "pi" || cfg.PRDFile != "plan.json" || cfg.ReviewRounds != 4 || cfg.RecoveryAttempts != 2 {
+		t.Errorf("cfg = {Runner:%q PRDFile:%q ReviewRounds:%d RecoveryAttempts:%d}, want pi/plan.json/4/2 from file",
+			cfg.Runner, cfg.PRDFile, cfg.ReviewRounds, cfg.RecoveryAttempts)
+	}
+	if len(cfg.DefaultBranches) != 2 || cfg.DefaultBranches[0] != "main" || cfg.DefaultBranches[1] != "trunk" {
+		t.Errorf("DefaultBranches = %v, want [main trunk]", cfg.DefaultBranches)
+	}
+}
+
+func TestLoadEnvOverridesYAMLConfigFile(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+	os.Clearenv()
+	t.Setenv("RALPH_RUNNER", "opencode")
+	t.Setenv("RALPH_BRANCH_PREFIX", "env")
+
+	if err := os.WriteFile(filepath.Join(tmpDir, YAMLFileName), []byte("runner: pi\nbranch_prefix: topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Runner != "opencode" || cfg.BranchPrefix != "env" {
+		t.Errorf("Runner = %q, BranchPrefix = %q, want env values opencode/env", cfg.Runner, cfg.BranchPrefix)
+	}
+}
+
+func TestLoadRejectsInvalidYAMLConfigFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		yaml   string
+		errMsg string
+	}{
+		{name: "missing colon", yaml: "runner pi\n", errMsg: "line 1: cannot unmarshal !!str `runner pi`"},
+		{name: "unknown key", yaml: "runner: pi\nretries: 3\n", errMsg: "line 2: field retries not found"},
+		{name: "model is not a runner alias", yaml: "model: pi\n", errMsg: "line 1: field model not found"},
+		{name: "non-integer", yaml: "max_iterations: many\n", errMsg: "cannot unmarshal !!str `many` into int"},
+		{name: "negative", yaml: "retry_attempts: -1\n", errMsg: "retry_attempts must be a non-negative integer"},
+		{name: "duplicate key", yaml: "runner: pi\nrunner: cursor\n", errMsg: "mapping key \"runner\" already defined"},
+		{name: "stray list item", yaml: "  - main\n", errMsg: "cannot unmarshal !!seq"},
+		{name: "unterminated quote", yaml: "prd_file: \"plan.json\n", errMsg: "found unexpected end of stream"},
+		{name: "scalar branches", yaml: "default_branches: main\n", errMsg: "cannot unmarshal !!str `main` into []string"},
+		{name: "invalid merged config", yaml: "prd_file: ../plan.json\n", errMsg: "prd_file must be a simple filename"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origDir, _ := os.Getwd()
+			tmpDir := t.TempDir()
+			os.Chdir(tmpDir)
+			defer os.Chdir(origDir)
+			os.Clearenv()
+
+			if err := os.WriteFile(filepath.Join(tmpDir, YAMLFileName), []byte(tt.yaml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Fatalf("Load() error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsBothConfigFiles(t *testing.T) {
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+	os.Clearenv()
+
+	os.WriteFile(filepath.Join(tmpDir, FileName), []byte(`{"runner":"pi"}`), 0o644)
+	os.WriteFile(filepath.Join(tmpDir, YAMLFileName), []byte("runner: pi\n"), 0o644)
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "keep only one") {
+		t.Fatalf("Load() error = %v, want error naming both config files", err)
+	}
+}
+
+func TestSaveRunnerUpdatesYAMLConfigFile(t *testing.T) {
+	os.Clearenv()
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, YAMLFileName)
+	if err := os.WriteFile(path, []byte("# settings\nrunner: pi\nbranch_prefix: topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveRunner(tmpDir, "cursor"); err != nil {
+		t.Fatalf("SaveRunner() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# settings\nrunner: cursor\nbranch_prefix: topic\n"; string(data) != want {
+		t.Errorf("saved YAML = %q, want %q", data, want)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, FileName)); !os.IsNotExist(err) {
+		t.Errorf("SaveRunner created %s alongside %s", FileName, YAMLFileName)
+	}
+	if !RunnerConfigured(tmpDir) {
+		t.Error("RunnerConfigured() = false after saving runner to YAML file")
+	}
+}
+
+func TestLoadFromReadsExplicitConfigFile(t *testing.T) {
+	os.Clearenv()
+	workDir := t.TempDir()
+	shared := t.TempDir()
+	os.WriteFile(filepath.Join(workDir, FileName), []byte(`{"runner":"cursor"}`), 0o644)
+	jsonPath := filepath.Join(shared, "team.json")
+	yamlPath := filepath.Join(shared, "team.yml")
+	os.WriteFile(jsonPath, []byte(`{"runner":"pi","branch_prefix":"topic"}`), 0o644)
+	os.WriteFile(yamlPath, []byte("runner: pi\nbranch_prefix: topic\n"), 0o644)
+
+	for _, path := range []string{jsonPath, yamlPath} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			cfg, err := LoadFrom(workDir, path)
+			if err != nil {
+				t.Fatalf("LoadFrom() error = %v", err)
+			}
+			if cfg.Runner != "pi" || cfg.BranchPrefix != "topic" {
+				t.Errorf("Runner = %q, BranchPrefix = %q, want pi/topic from %s, not the work dir file", cfg.Runner, cfg.BranchPrefix, path)
+			}
+			if cfg.WorkDir != workDir {
+				t.Errorf("WorkDir = %q, want %q", cfg.WorkDir, workDir)
+			}
+		})
+	}
+
+	t.Run("env wins", func(t *testing.T) {
+		t.Setenv("RALPH_RUNNER", "opencode")
+		cfg, err := LoadFrom(workDir, yamlPath)
+		if err != nil {
+			t.Fatalf("LoadFrom() error = %v", err)
+		}
+		if cfg.Runner != "opencode" {
+			t.Errorf("Runner = %q, want env value opencode", cfg.Runner)
+		}
+	})
+}
+
+func TestLoadFromRejectsMissingOrInvalidFile(t *testing.T) {
+	os.Clearenv()
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.yaml")
+	os.WriteFile(bad, []byte("colour: blue\n"), 0o644)
+
+	tests := []struct {
+		name   string
+		path   string
+		errMsg string
+	}{
+		{name: "missing", path: filepath.Join(dir, "nope.json"), errMsg: "config file " + filepath.Join(dir, "nope.json") + " does not exist"},
+		{name: "unknown yaml key", path: bad, errMsg: "field colour not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadFrom(dir, tt.path); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Fatalf("LoadFrom() error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFileName is the YAML alternative to FileName.
+const YAMLFileName = "ralph.config.yaml"
+
+// yamlFile holds the keys YAMLFileName accepts: those of the JSON file plus
+// max_iterations and retry_attempts. Pointers tell a missing key from a zero
+// value so only the keys present override cfg.
+type yamlFile struct {
+	Runner          *string  `yaml:"runner"`
+	PRDFile         *string  `yaml:"prd_file"`
+	TestCommand     *string  `yaml:"test_command"`
+	BranchPrefix    *string  `yaml:"branch_prefix"`
+	DefaultBranches []string `yaml:"default_branches"`
+	MaxIterations   *int     `yaml:"max_iterations"`
+	RetryAttempts   *int     `yaml:"retry_attempts"`
+}
+
+// applyYAMLFile reads YAMLFileName when present.
+func applyYAMLFile(cfg *Config) (bool, error) {
+	data, err := os.ReadFile(cfg.ConfigPath(YAMLFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("reading %s: %w", YAMLFileName, err)
+	}
+	return true, applyYAMLData(cfg, YAMLFileName, data)
+}
+
+// applyYAMLData applies the YAML config in data; name is only used in errors.
+func applyYAMLData(cfg *Config, name string, data []byte) error {
+	file, err := decodeYAMLFile(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", name, err)
+	}
+	for _, count := range []struct {
+		key string
+		n   *int
+	}{{"max_iterations", file.MaxIterations}, {"retry_attempts", file.RetryAttempts}} {
+		if count.n != nil && *count.n < 0 {
+			return fmt.Errorf("parsing %s: %s must be a non-negative integer, got %d", name, count.key, *count.n)
+		}
+	}
+
+	if file.Runner != nil {
+		cfg.Runner = *file.Runner
+	}
+	if file.PRDFile != nil {
+		cfg.PRDFile = *file.PRDFile
+	}
+	if file.TestCommand != nil {
+		cfg.TestCommand = *file.TestCommand
+	}
+	if file.BranchPrefix != nil {
+		cfg.BranchPrefix = *file.BranchPrefix
+	}
+	if file.DefaultBranches != nil {
+		cfg.DefaultBranches = file.DefaultBranches
+	}
+	if file.MaxIterations != nil {
+		cfg.ReviewRounds = *file.MaxIterations
+	}
+	if file.RetryAttempts != nil {
+		cfg.RecoveryAttempts = *file.RetryAttempts
+	}
+	return nil
+}
+
+// decodeYAMLFile parses data, rejecting keys yamlFile does not know. An empty
+// file sets nothing.
+func decodeYAMLFile(data []byte) (yamlFile, error) {
+	var file yamlFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return yamlFile{}, err
+	}
+	return file, nil
+}
+
+// saveYAMLRunner sets the runner key in YAMLFileName, keeping the other keys
+// and their comments.
+func saveYAMLRunner(workDir, runner string) error {
+	path := filepath.Join(workDir, YAMLFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if _, err := decodeYAMLFile(data); err != nil {
+		return fmt.Errorf("parsing %s: %w", YAMLFileName, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing %s: %w", YAMLFileName, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("parsing %s: expected a mapping of keys to values", YAMLFileName)
+	}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Value: runner}
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "runner" {
+			root.Content[i+1] = value
+			replaced = true
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "runner"}, value)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), 0o644)
+}
@@ -1,5 +1,7 @@
 package constants
 
+import "time"
+
 const (
 	// EventChannelBuffer is sized for burst output from AI runners.
 	EventChannelBuffer = 10000
@@ -34,7 +36,26 @@ const (
 	// MaxPRDSelfReviewRounds caps agent self-review rounds after PRD generation.
 	MaxPRDSelfReviewRounds = 3
 
+	// MaxPRDEditAttempts caps how often --open-editor reopens an invalid PRD.
+	MaxPRDEditAttempts = 3
+
+	// ExitPartialSuccess is the exit code for a --best-effort run that
+	// completed with unfinished stories.
+	ExitPartialSuccess = 2
+
+	// ExitInterrupted is the exit code for a headless run stopped by
+	// SIGINT/SIGTERM (128 + SIGINT, as shells report it).
+	ExitInterrupted = 130
+
+	// DefaultTUILogLines is how many output lines the TUI log pane keeps
+	// when RALPH_TUI_LOG_LINES is unset.
+	DefaultTUILogLines = 500
+
 	// CopilotMaxAutopilotContinues overrides Copilot CLI's default autopilot limit (5) for
 	// multi-step Ralph implementation stories.
 	CopilotMaxAutopilotContinues = 50
 )
+
+// DefaultEmitTimeout is how long an event waits for room in a full event
+// channel before it is dropped, when RALPH_EMIT_TIMEOUT is unset.
+const DefaultEmitTimeout = 100 * time.Millisecond
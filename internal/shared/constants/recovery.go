@@ -4,6 +4,10 @@ import "time"
 
 const MaxRecoveryAttempts = 2
 
+// MaxTransientRetries caps how often a story slice is rerun after a transient
+// runner failure before the error counts against recovery.
+const MaxTransientRetries = 3
+
 const MaxImplementationReviewRounds = 8
 
 const MaxCleanupRounds = 3
@@ -13,3 +17,14 @@ const RunnerRecoveryCooldown = 750 * time.Millisecond
 const MinRunnerInvokeDuration = 500 * time.Millisecond
 
 const RunnerFastFailRetryDelay = 1 * time.Second
+
+// DefaultRateLimitCooldown replaces the normal recovery cooldown after the
+// runner reports a provider rate limit; RALPH_RATE_LIMIT_COOLDOWN overrides it.
+const DefaultRateLimitCooldown = 60 * time.Second
+
+// MaxRetryBackoff caps the exponential RALPH_RETRY_BACKOFF delay between
+// recovery attempts.
+const MaxRetryBackoff = 5 * time.Minute
+
+// MaxRecoveryDiffBytes caps the uncommitted diff embedded in recovery prompts by --diff-context.
+const MaxRecoveryDiffBytes = 16 * 1024
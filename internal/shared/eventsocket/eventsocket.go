@@ -0,0 +1,115 @@
+// Package eventsocket streams workflow events as NDJSON to a Unix domain
+// socket named by RALPH_EVENT_SOCKET, so an external dashboard can follow a
+// run live alongside the TUI or headless output.
+package eventsocket
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/workflow/events"
+)
+
+// dialTimeout bounds how long a run waits for the consumer to accept, and
+// writeTimeout how long a stalled consumer can hold up an event.
+const (
+	dialTimeout  = time.Second
+	writeTimeout = time.Second
+)
+
+// Writer sends each event as one line of the same envelope --json writes.
+// It dials the socket on the first event of a run and closes it after the
+// run's terminal event. If the consumer is not listening or goes away, the
+// rest of that run's events are dropped with a single warning; the run itself
+// is never affected. A nil *Writer ignores every call.
+type Writer struct {
+	path string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	broken bool
+}
+
+// New returns a Writer for the socket at path, or nil when path is empty.
+func New(path string) *Writer {
+	if path == "" {
+		return nil
+	}
+	return &Writer{path: path}
+}
+
+// Record writes ev to the socket.
+func (w *Writer) Record(ev events.Event) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	terminal := isTerminal(ev)
+	if terminal {
+		defer w.closeLocked()
+	}
+	if w.broken {
+		return
+	}
+	if w.conn == nil {
+		conn, err := net.DialTimeout("unix", w.path, dialTimeout)
+		if err != nil {
+			w.drop("cannot connect to RALPH_EVENT_SOCKET; not streaming events", err)
+			return
+		}
+		w.conn = conn
+	}
+	data, err := events.MarshalEventEnvelope(ev)
+	if err != nil {
+		logger.Debug("skipping event for RALPH_EVENT_SOCKET", "event_type", fmt.Sprintf("%T", ev), "error", err)
+		return
+	}
+	_ = w.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := w.conn.Write(append(data, '\n')); err != nil {
+		w.drop("RALPH_EVENT_SOCKET consumer went away; dropping the rest of this run's events", err)
+	}
+}
+
+// Close closes the connection if one is open.
+func (w *Writer) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeLocked()
+}
+
+// closeLocked ends the current run's connection. The next event dials again,
+// so a later run in the same session gets a fresh stream.
+func (w *Writer) closeLocked() error {
+	w.broken = false
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+func (w *Writer) drop(msg string, err error) {
+	logger.Warn(msg, "path", w.path, "error", err)
+	if w.conn != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	w.broken = true
+}
+
+func isTerminal(ev events.Event) bool {
+	switch ev.(type) {
+	case events.EventCompleted, events.EventError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package eventsocket
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/workflow/events"
+)
+
+func listen(t *testing.T) (string, net.Listener) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "ralph-sock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "events.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	return path, ln
+}
+
+// readRun accepts one connection and returns every line sent before the
+// writer closed it.
+func readRun(t *testing.T, ln net.Listener) <-chan []string {
+	t.Helper()
+	lines := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			lines <- nil
+			return
+		}
+		defer conn.Close()
+		var got []string
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			got = append(got, scanner.Text())
+		}
+		lines <- got
+	}()
+	return lines
+}
+
+func TestWriterStreamsEachRunAndClosesOnCompletion(t *testing.T) {
+	path, ln := listen(t)
+	w := New(path)
+
+	for run := 1; run <= 2; run++ {
+		lines := readRun(t, ln)
+		w.Record(events.EventOutput{Output: events.Output{Text: "hello"}})
+		w.Record(events.EventCompleted{})
+
+		got := <-lines
+		if len(got) != 2 || !strings.Contains(got[0], `"type":"EventOutput"`) || !strings.Contains(got[0], "hello") || !strings.Contains(got[1], `"type":"EventCompleted"`) {
+			t.Fatalf("run %d lines = %q, want the output and completed envelopes", run, got)
+		}
+	}
+}
+
+func TestWriterDropsEventsWhenConsumerIsGone(t *testing.T) {
+	path, ln := listen(t)
+	w := New(path)
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+	w.Record(events.EventOutput{Output: events.Output{Text: "first"}})
+	conn := <-accepted
+	conn.Close()
+
+	for i := 0; i < 100; i++ {
+		w.Record(events.EventOutput{Output: events.Output{Text: strings.Repeat("x", 1024)}})
+	}
+	if !w.broken {
+		t.Fatal("writer still streaming after the consumer closed the connection")
+	}
+	w.Record(events.EventCompleted{})
+	if w.broken || w.conn != nil {
+		t.Fatal("terminal event did not reset the writer for the next run")
+	}
+}
+
+func TestWriterWithoutListener(t *testing.T) {
+	w := New(filepath.Join(t.TempDir(), "missing.sock"))
+	w.Record(events.EventOutput{Output: events.Output{Text: "hello"}})
+	if !w.broken {
+		t.Fatal("writer did not give up after failing to dial")
+	}
+
+	var none *Writer
+	none.Record(events.EventCompleted{})
+	if New("") != nil || none.Close() != nil {
+		t.Fatal("a nil Writer should ignore every call")
+	}
+}
@@ -1,7 +1,9 @@
 package gitdiff
 
 import (
+	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
@@ -155,6 +157,27 @@ func CommitRecoveryChanges(workDir, message string) (bool, error) {
 }
 
 func CommitChangedFiles(workDir, message string) (bool, error) {
+	return CommitStory(workDir, message, StoryCommitOptions{})
+}
+
+// CoauthorTrailer is appended to story commits when StoryCommitOptions.Coauthor is set.
+const CoauthorTrailer = "Co-authored-by: Ralph <ralph@local>"
+
+// StoryCommitOptions adds attribution and progress tracking to story commits.
+type StoryCommitOptions struct {
+	// PRDFile, relative to the work dir, is staged alongside code changes so
+	// story progress lands in history. It never causes a commit on its own
+	// unless PRDOnly is set.
+	PRDFile  string
+	Coauthor bool
+	// PRDOnly commits just PRDFile, with or without code changes, for the
+	// commit that records a finished story.
+	PRDOnly bool
+}
+
+// CommitStory commits changed deliverable files like CommitChangedFiles, then
+// applies opts. Without code changes nothing is committed.
+func CommitStory(workDir, message string, opts StoryCommitOptions) (bool, error) {
 	if err := ensureGitRepo(workDir); err != nil {
 		return false, err
 	}
@@ -164,15 +187,26 @@ func CommitChangedFiles(workDir, message string) (bool, error) {
 		return false, err
 	}
 
+	prdFile := filepath.ToSlash(filepath.Clean(opts.PRDFile))
 	var toCommit []string
+	includePRD := false
 	for _, f := range files {
-		if shouldAutoCommit(f) {
+		switch {
+		case shouldAutoCommit(f):
 			toCommit = append(toCommit, f)
+		case opts.PRDFile != "" && f == prdFile:
+			includePRD = true
 		}
 	}
-	if len(toCommit) == 0 {
+	if opts.PRDOnly {
+		toCommit = nil
+	}
+	if len(toCommit) == 0 && (!opts.PRDOnly || !includePRD) {
 		return false, nil
 	}
+	if includePRD {
+		toCommit = append(toCommit, prdFile)
+	}
 
 	addArgs := append([]string{"add", "--"}, toCommit...)
 	addCmd := exec.Command("git", addArgs...)
@@ -185,6 +219,9 @@ func CommitChangedFiles(workDir, message string) (bool, error) {
 		}
 	}
 
+	if opts.Coauthor {
+		message = strings.TrimRight(message, "\n") + "\n\n" + CoauthorTrailer
+	}
 	commitCmd := exec.Command("git", "commit", "-m", message)
 	commitCmd.Dir = workDir
 	out, err := commitCmd.CombinedOutput()
@@ -202,3 +239,34 @@ func CommitChangedFiles(workDir, message string) (bool, error) {
 
 	return true, nil
 }
+
+// RecentCommits returns the subject lines of the last n commits on HEAD,
+// newest first. A repo with no commits yet has none.
+func RecentCommits(workDir string, n int) ([]string, error) {
+	if err := ensureGitRepo(workDir); err != nil {
+		return nil, err
+	}
+	headCmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD")
+	headCmd.Dir = workDir
+	if err := headCmd.Run(); err != nil {
+		return nil, nil
+	}
+	args := []string{"log", fmt.Sprintf("-n%d", n), "--format=%s"}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, &GitError{
+			WorkDir: workDir,
+			Command: "git " + strings.Join(args, " "),
+			Output:  strings.TrimSpace(string(out)),
+		}
+	}
+	var subjects []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
+}
@@ -91,6 +91,100 @@ func TestCommitChangedFilesSkipsPRDState(t *testing.T) {
 	}
 }
 
+func TestCommitStoryStagesPRDAndAddsCoauthorTrailer(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+
+	for name, content := range map[string]string{"prd.json": "{}", "prd.json.lock": "1", "feature.go": "package main\n"} {
+		if err := os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	committed, err := CommitStory(workDir, "ralph: story-1/slice-1", StoryCommitOptions{PRDFile: "prd.json", Coauthor: true})
+	if err != nil {
+		t.Fatalf("CommitStory() err = %v", err)
+	}
+	if !committed {
+		t.Fatal("CommitStory() committed = false, want true")
+	}
+
+	logCmd := exec.Command("git", "log", "-1", "--format=%B")
+	logCmd.Dir = workDir
+	out, err := logCmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(out); !strings.Contains(got, "ralph: story-1/slice-1\n\n"+CoauthorTrailer) {
+		t.Fatalf("git log message = %q, want co-author trailer", got)
+	}
+
+	show := exec.Command("git", "show", "--name-only", "--pretty=format:", "HEAD")
+	show.Dir = workDir
+	out, err = show.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := strings.Fields(string(out))
+	if strings.Join(files, " ") != "feature.go prd.json" {
+		t.Fatalf("committed files = %v, want feature.go and prd.json", files)
+	}
+}
+
+func TestCommitStorySkipsPRDOnlyChanges(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+
+	if err := os.WriteFile(filepath.Join(workDir, "prd.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	committed, err := CommitStory(workDir, "ralph: story-1/slice-1", StoryCommitOptions{PRDFile: "prd.json", Coauthor: true})
+	if err != nil {
+		t.Fatalf("CommitStory() err = %v", err)
+	}
+	if committed {
+		t.Fatal("CommitStory() committed = true with only prd.json changed, want false")
+	}
+}
+
+func TestCommitStoryPRDOnlyCommitsJustThePRD(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+
+	for name, content := range map[string]string{"prd.json": "{}", "feature.go": "package main\n"} {
+		if err := os.WriteFile(filepath.Join(workDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	committed, err := CommitStory(workDir, "ralph: story-1 complete", StoryCommitOptions{PRDFile: "prd.json", PRDOnly: true})
+	if err != nil {
+		t.Fatalf("CommitStory() err = %v", err)
+	}
+	if !committed {
+		t.Fatal("CommitStory() committed = false, want true")
+	}
+
+	show := exec.Command("git", "show", "--name-only", "--pretty=format:", "HEAD")
+	show.Dir = workDir
+	out, err := show.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files := strings.Fields(string(out)); strings.Join(files, " ") != "prd.json" {
+		t.Fatalf("committed files = %v, want only prd.json", files)
+	}
+
+	committed, err = CommitStory(workDir, "ralph: story-1 complete", StoryCommitOptions{PRDFile: "prd.json", PRDOnly: true})
+	if err != nil {
+		t.Fatalf("second CommitStory() err = %v", err)
+	}
+	if committed {
+		t.Fatal("second CommitStory() committed = true with the PRD unchanged, want false")
+	}
+}
+
 func TestCommitTrackedChangesDoesNotRetrackUntrackedFile(t *testing.T) {
 	workDir := t.TempDir()
 	initGitRepoForCommit(t, workDir)
@@ -179,3 +273,34 @@ func TestCommitChangedFilesSkipsRalphState(t *testing.T) {
 		t.Fatal("CommitChangedFiles() committed = true, want false for ralph-only changes")
 	}
 }
+
+func TestRecentCommitsListsNewestFirst(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+	for _, msg := range []string{"ralph: story-1 complete", "ralph: story-2 complete"} {
+		cmd := exec.Command("git", "commit", "--allow-empty", "-m", msg)
+		cmd.Dir = workDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git commit: %v\n%s", err, out)
+		}
+	}
+
+	got, err := RecentCommits(workDir, 2)
+	if err != nil {
+		t.Fatalf("RecentCommits() error = %v", err)
+	}
+	if want := []string{"ralph: story-2 complete", "ralph: story-1 complete"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("RecentCommits() = %q, want %q", got, want)
+	}
+
+	empty := t.TempDir()
+	if out, err := exec.Command("git", "-C", empty, "init").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if got, err := RecentCommits(empty, 5); err != nil || len(got) != 0 {
+		t.Fatalf("RecentCommits() on an unborn branch = %q, %v; want none", got, err)
+	}
+	if _, err := RecentCommits(t.TempDir(), 5); err == nil {
+		t.Fatal("RecentCommits() outside a git repo should fail")
+	}
+}
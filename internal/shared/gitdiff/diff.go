@@ -0,0 +1,94 @@
+package gitdiff
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const diffTruncatedMarker = "\n... (diff truncated)"
+
+// WorkingDiff returns the uncommitted diff against HEAD, cut to maxBytes so it
+// can be embedded in a prompt. A non-positive maxBytes disables the cap.
+func WorkingDiff(workDir string, maxBytes int) (string, error) {
+	if err := ensureGitRepo(workDir); err != nil {
+		return "", err
+	}
+	cmd := exec.Command("git", "diff", "HEAD")
+	cmd.Dir = workDir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", &GitError{
+			WorkDir: workDir,
+			Command: "git diff HEAD",
+			Output:  strings.TrimSpace(fmt.Sprint(err)),
+		}
+	}
+	return truncateDiff(strings.TrimRight(string(out), "\n"), maxBytes), nil
+}
+
+// DiffStat totals git diff --numstat between base and the working tree: the
+// files changed and the lines inserted and deleted. Like story commits it
+// leaves out prd.json and Ralph's own state; untracked files are not counted,
+// and binary files count as changed without lines.
+func DiffStat(workDir, base string) (files, insertions, deletions int, err error) {
+	if err := ensureGitRepo(workDir); err != nil {
+		return 0, 0, 0, err
+	}
+	cmd := exec.Command("git", "diff", "--numstat", base)
+	cmd.Dir = workDir
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, 0, 0, &GitError{
+			WorkDir: workDir,
+			Command: "git diff --numstat " + base,
+			Output:  strings.TrimSpace(fmt.Sprint(err)),
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !shouldAutoCommit(fields[2]) {
+			continue
+		}
+		files++
+		added, _ := strconv.Atoi(fields[0])
+		removed, _ := strconv.Atoi(fields[1])
+		insertions += added
+		deletions += removed
+	}
+	return files, insertions, deletions, nil
+}
+
+// WorkingTreeSnapshot records the tracked working tree as a commit with git
+// stash create, which leaves the tree and the stash list alone, so a later
+// DiffStat against it counts only what changed since. It returns HEAD when
+// nothing is uncommitted.
+func WorkingTreeSnapshot(workDir string) (string, error) {
+	if err := ensureGitRepo(workDir); err != nil {
+		return "", err
+	}
+	for _, args := range [][]string{{"stash", "create"}, {"rev-parse", "HEAD"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workDir
+		out, err := cmd.Output()
+		if err != nil {
+			return "", &GitError{
+				WorkDir: workDir,
+				Command: "git " + strings.Join(args, " "),
+				Output:  strings.TrimSpace(fmt.Sprint(err)),
+			}
+		}
+		if sha := strings.TrimSpace(string(out)); sha != "" {
+			return sha, nil
+		}
+	}
+	return "", nil
+}
+
+func truncateDiff(diff string, maxBytes int) string {
+	if maxBytes <= 0 || len(diff) <= maxBytes {
+		return diff
+	}
+	return diff[:maxBytes] + diffTruncatedMarker
+}
@@ -0,0 +1,129 @@
+package gitdiff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWorkingDiffIncludesUncommittedChanges(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+
+	if err := os.WriteFile(filepath.Join(workDir, "base.txt"), []byte("base\nchanged\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := WorkingDiff(workDir, 0)
+	if err != nil {
+		t.Fatalf("WorkingDiff() error = %v", err)
+	}
+	if !strings.Contains(diff, "+changed") || !strings.Contains(diff, "base.txt") {
+		t.Fatalf("WorkingDiff() = %q, want base.txt change", diff)
+	}
+}
+
+func TestWorkingDiffCleanTreeIsEmpty(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+
+	diff, err := WorkingDiff(workDir, 0)
+	if err != nil {
+		t.Fatalf("WorkingDiff() error = %v", err)
+	}
+	if diff != "" {
+		t.Fatalf("WorkingDiff() = %q, want empty", diff)
+	}
+}
+
+func gitOutput(t *testing.T, workDir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+func TestDiffStatCountsChangesSinceBase(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+	base := strings.TrimSpace(gitOutput(t, workDir, "rev-parse", "HEAD"))
+
+	if files, ins, del, err := DiffStat(workDir, base); err != nil || files != 0 || ins != 0 || del != 0 {
+		t.Fatalf("DiffStat() on a clean tree = %d, %d, %d, %v; want zeros", files, ins, del, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(workDir, "base.txt"), []byte("changed\nadded\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "new.txt"), []byte("one\ntwo\nthree\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitOutput(t, workDir, "add", "new.txt")
+	gitOutput(t, workDir, "commit", "-q", "-m", "add new.txt")
+
+	files, ins, del, err := DiffStat(workDir, base)
+	if err != nil {
+		t.Fatalf("DiffStat() error = %v", err)
+	}
+	if files != 2 || ins != 5 || del != 1 {
+		t.Fatalf("DiffStat() = %d files (+%d/-%d), want 2 files (+5/-1) across the commit and the working tree", files, ins, del)
+	}
+}
+
+func TestWorkingTreeSnapshotExcludesEarlierUncommittedChanges(t *testing.T) {
+	workDir := t.TempDir()
+	initGitRepoForCommit(t, workDir)
+	head := strings.TrimSpace(gitOutput(t, workDir, "rev-parse", "HEAD"))
+
+	if base, err := WorkingTreeSnapshot(workDir); err != nil || base != head {
+		t.Fatalf("WorkingTreeSnapshot() on a clean tree = %q, %v; want HEAD %q", base, err, head)
+	}
+
+	if err := os.WriteFile(filepath.Join(workDir, "base.txt"), []byte("base\nearlier\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	base, err := WorkingTreeSnapshot(workDir)
+	if err != nil {
+		t.Fatalf("WorkingTreeSnapshot() error = %v", err)
+	}
+	if got := gitOutput(t, workDir, "stash", "list"); got != "" {
+		t.Fatalf("stash list = %q, want the snapshot kept off it", got)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "base.txt"), []byte("base\nearlier\nlater\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, ins, del, err := DiffStat(workDir, base)
+	if err != nil {
+		t.Fatalf("DiffStat() error = %v", err)
+	}
+	if files != 1 || ins != 1 || del != 0 {
+		t.Fatalf("DiffStat() = %d files (+%d/-%d), want only the later line (1 file, +1/-0)", files, ins, del)
+	}
+}
+
+func TestTruncateDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     string
+		maxBytes int
+		want     string
+	}{
+		{name: "under cap", diff: "abc", maxBytes: 10, want: "abc"},
+		{name: "no cap", diff: "abcdef", maxBytes: 0, want: "abcdef"},
+		{name: "over cap", diff: "abcdef", maxBytes: 3, want: "abc" + diffTruncatedMarker},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateDiff(tt.diff, tt.maxBytes); got != tt.want {
+				t.Fatalf("truncateDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultMaxFileBytes is the log file size that triggers rotation when
+// Options.MaxBytes is zero.
+const DefaultMaxFileBytes = 10 << 20
+
+// Options sets up the persistent log file written alongside the console log.
+type Options struct {
+	// File is where JSON lines are appended; empty means no log file.
+	File string
+	// MaxBytes rotates the file to File+".1" before it grows past this size.
+	MaxBytes int64
+	// HidePrompts keeps runner prompt contents out of the file.
+	HidePrompts bool
+}
+
+var (
+	fileMu      sync.Mutex
+	fileLogger  *slog.Logger
+	fileWriter  *rotatingFile
+	hidePrompts bool
+)
+
+// Configure starts writing every log record, debug included, as JSON lines
+// to opts.File as well as to the console. Calling it again replaces the
+// previous file; an empty File turns the file off.
+func Configure(opts Options) error {
+	console := get().Handler()
+	if fh, ok := console.(fanoutHandler); ok {
+		console = fh[0]
+	}
+
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	closeFileLocked()
+	if opts.File == "" {
+		defaultLogger = slog.New(console)
+		return nil
+	}
+	w, err := openRotatingFile(opts.File, opts.MaxBytes)
+	if err != nil {
+		return err
+	}
+	fileWriter = w
+	fileLogger = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	hidePrompts = opts.HidePrompts
+	defaultLogger = slog.New(fanoutHandler{console, fileLogger.Handler()})
+	return nil
+}
+
+// Close flushes and closes the log file set up by Configure, if any.
+func Close() error {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	return closeFileLocked()
+}
+
+func closeFileLocked() error {
+	if fileWriter == nil {
+		return nil
+	}
+	err := fileWriter.Close()
+	fileWriter, fileLogger = nil, nil
+	return err
+}
+
+// Prompt records a prompt sent to the runner in the log file only; prompts
+// are too long for the console. With Options.HidePrompts just its size is
+// kept.
+func Prompt(kind, prompt string) {
+	fileMu.Lock()
+	l, hide := fileLogger, hidePrompts
+	fileMu.Unlock()
+	if l == nil {
+		return
+	}
+	if hide {
+		l.Debug("runner prompt", "kind", kind, "bytes", len(prompt))
+		return
+	}
+	l.Debug("runner prompt", "kind", kind, "bytes", len(prompt), "prompt", prompt)
+}
+
+// fanoutHandler sends each record to every handler that accepts its level.
+type fanoutHandler []slog.Handler
+
+func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		out[i] = handler.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (h fanoutHandler) WithGroup(name string) slog.Handler {
+	out := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		out[i] = handler.WithGroup(name)
+	}
+	return out
+}
+
+// rotatingFile appends to path and, when a write would take it past
+// maxBytes, renames it to path+".1" (replacing the previous backup) and
+// starts a new file.
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+func openRotatingFile(path string, maxBytes int64) (*rotatingFile, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxFileBytes
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("create log directory for %s: %w", path, err)
+	}
+	w := &rotatingFile{path: path, maxBytes: maxBytes}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingFile) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+	w.file, w.size = f, info.Size()
+	return nil
+}
+
+func (w *rotatingFile) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingFile) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("close log file for rotation: %w", err)
+	}
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	return w.open()
+}
+
+func (w *rotatingFile) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
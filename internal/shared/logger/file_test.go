@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func configureForTest(t *testing.T, opts Options) *bytes.Buffer {
+	t.Helper()
+	var console bytes.Buffer
+	restore := SetForTest(slog.New(slog.NewTextHandler(&console, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	if err := Configure(opts); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	t.Cleanup(func() {
+		Close()
+		restore()
+	})
+	return &console
+}
+
+func readLogLines(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		lines = append(lines, rec)
+	}
+	return lines
+}
+
+func TestConfigureWritesJSONLinesAlongsideConsole(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "ralph.log")
+	console := configureForTest(t, Options{File: path})
+
+	Debug("debug detail", "story_id", "story-1")
+	Info("story started", "story_id", "story-1")
+
+	lines := readLogLines(t, path)
+	if len(lines) != 2 || lines[0]["msg"] != "debug detail" || lines[1]["story_id"] != "story-1" {
+		t.Fatalf("log file = %v, want both records including debug", lines)
+	}
+	if got := console.String(); !strings.Contains(got, "story started") || strings.Contains(got, "debug detail") {
+		t.Fatalf("console = %q, want only the info record", got)
+	}
+}
+
+func TestConfigureRotatesAtMaxBytes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ralph.log")
+	configureForTest(t, Options{File: path, MaxBytes: 300})
+
+	for i := 0; i < 10; i++ {
+		Info("filling the log file", "padding", strings.Repeat("x", 50))
+	}
+
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Fatalf("no rotated backup: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 300 {
+		t.Fatalf("log file size = %d, want at most 300 after rotation", info.Size())
+	}
+}
+
+func TestPromptRespectsHidePrompts(t *testing.T) {
+	tests := []struct {
+		name        string
+		hidePrompts bool
+		wantPrompt  bool
+	}{
+		{name: "logged by default", wantPrompt: true},
+		{name: "hidden", hidePrompts: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ralph.log")
+			console := configureForTest(t, Options{File: path, HidePrompts: tt.hidePrompts})
+
+			Prompt("story-implement", "secret token abc123")
+
+			lines := readLogLines(t, path)
+			if len(lines) != 1 || lines[0]["kind"] != "story-implement" {
+				t.Fatalf("log file = %v, want one prompt record", lines)
+			}
+			if _, ok := lines[0]["prompt"]; ok != tt.wantPrompt {
+				t.Errorf("prompt text logged = %v, want %v", ok, tt.wantPrompt)
+			}
+			if console.Len() != 0 {
+				t.Errorf("console = %q, want prompts kept out of it", console.String())
+			}
+		})
+	}
+}
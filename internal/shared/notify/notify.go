@@ -0,0 +1,115 @@
+// Package notify posts run outcomes to a webhook so long unattended runs can
+// ping someone when they finish.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+	"ralph/internal/workflow/events"
+)
+
+// Timeout bounds each webhook request so a slow endpoint cannot hold up the
+// end of a run.
+const Timeout = 5 * time.Second
+
+// Run outcomes reported in Payload.Status.
+const (
+	StatusCompleted = "completed"
+	StatusPartial   = "partial"
+	StatusFailed    = "failed"
+)
+
+// Payload is the JSON body posted to the webhook.
+type Payload struct {
+	Status     string   `json:"status"`
+	Project    string   `json:"project,omitempty"`
+	Completed  int      `json:"completed"`
+	Failed     int      `json:"failed"`
+	Total      int      `json:"total"`
+	Unfinished []string `json:"unfinished,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+var client = &http.Client{Timeout: Timeout}
+
+// PayloadFor builds the webhook body for terminal run events. It reports false
+// for every other event. p may be nil when the PRD could not be loaded.
+func PayloadFor(ev events.Event, p *prd.PRD) (Payload, bool) {
+	var payload Payload
+	switch e := ev.(type) {
+	case events.EventCompleted:
+		payload.Status = StatusCompleted
+		if e.Partial() {
+			payload.Status = StatusPartial
+		}
+		payload.Unfinished = e.Unfinished
+		payload.Failed = len(e.Unfinished)
+	case events.EventError:
+		payload.Status = StatusFailed
+		if e.Err != nil {
+			payload.Error = e.Err.Error()
+		}
+	default:
+		return Payload{}, false
+	}
+	if p != nil {
+		payload.Project = p.ProjectName
+		payload.Completed = p.CompletedCount()
+		payload.Total = len(p.Stories)
+		if payload.Status == StatusFailed {
+			payload.Failed = payload.Total - payload.Completed
+		}
+	}
+	return payload, true
+}
+
+// Notify posts the payload for ev to url. Non-terminal events and an empty
+// url are no-ops.
+func Notify(url string, ev events.Event, p *prd.PRD) error {
+	if url == "" {
+		return nil
+	}
+	payload, ok := PayloadFor(ev, p)
+	if !ok {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Run notifies RALPH_WEBHOOK_URL about a terminal event, loading the PRD for
+// the project name and story counts. Failures are logged and never affect
+// the run's outcome.
+func Run(cfg *config.Config, ev events.Event) {
+	if cfg == nil || cfg.WebhookURL == "" {
+		return
+	}
+	if _, ok := PayloadFor(ev, nil); !ok {
+		return
+	}
+	p, err := prd.Load(cfg)
+	if err != nil {
+		p = nil
+	}
+	if err := Notify(cfg.WebhookURL, ev, p); err != nil {
+		logger.Warn("webhook notification failed", "error", err)
+	}
+}
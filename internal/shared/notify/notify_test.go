@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/workflow/events"
+)
+
+func testPRD() *prd.PRD {
+	return &prd.PRD{ProjectName: "Invites", Stories: []*prd.Story{
+		{ID: "story-1", Title: "One", Passes: true, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "b", RedHint: "r", Passes: true}}},
+		{ID: "story-2", Title: "Two", Slices: []*prd.Slice{{ID: "slice-1", Behavior: "b", RedHint: "r"}}},
+	}}
+}
+
+func TestPayloadFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		ev     events.Event
+		want   Payload
+		wantOK bool
+	}{
+		{name: "completed", ev: events.EventCompleted{}, wantOK: true,
+			want: Payload{Status: StatusCompleted, Project: "Invites", Completed: 1, Total: 2}},
+		{name: "partial", ev: events.EventCompleted{Unfinished: []string{"story-2"}}, wantOK: true,
+			want: Payload{Status: StatusPartial, Project: "Invites", Completed: 1, Failed: 1, Total: 2, Unfinished: []string{"story-2"}}},
+		{name: "failed", ev: events.EventError{Err: errors.New("tests failed")}, wantOK: true,
+			want: Payload{Status: StatusFailed, Project: "Invites", Completed: 1, Failed: 1, Total: 2, Error: "tests failed"}},
+		{name: "non-terminal", ev: events.EventStoryStarted{}, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PayloadFor(tt.ev, testPRD())
+			if ok != tt.wantOK {
+				t.Fatalf("PayloadFor() ok = %v, want %v", ok, tt.wantOK)
+			}
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if ok && string(gotJSON) != string(wantJSON) {
+				t.Errorf("PayloadFor() = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestNotifyPostsJSON(t *testing.T) {
+	var got Payload
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	if err := Notify(srv.URL, events.EventCompleted{}, testPRD()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if contentType != "application/json" || got.Status != StatusCompleted || got.Project != "Invites" {
+		t.Fatalf("posted %q %+v, want completed JSON payload", contentType, got)
+	}
+}
+
+func TestNotifyReportsFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	if err := Notify(srv.URL, events.EventError{Err: errors.New("boom")}, nil); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("Notify() error = %v, want status error", err)
+	}
+	if err := Notify("", events.EventCompleted{}, nil); err != nil {
+		t.Fatalf("Notify() with no URL error = %v, want nil", err)
+	}
+}
+
+func TestRunSkipsNonTerminalEvents(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ }))
+	defer srv.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.WebhookURL = srv.URL
+	if err := prd.Save(cfg, testPRD()); err != nil {
+		t.Fatal(err)
+	}
+
+	Run(cfg, events.EventStoryStarted{})
+	Run(cfg, events.EventCompleted{})
+	if calls != 1 {
+		t.Fatalf("webhook calls = %d, want 1 for the terminal event only", calls)
+	}
+}
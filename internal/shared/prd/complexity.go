@@ -0,0 +1,46 @@
+package prd
+
+import "sort"
+
+// complexityDescriptionChars is how much description text adds one point of
+// complexity; long descriptions usually mean more to build.
+const complexityDescriptionChars = 200
+
+// EstimateComplexity scores each story by ID with a rough measure of how much
+// work it is: one point to start, one per slice, one per 200 characters of
+// description, and two per VagueFindings entry, since vague stories tend to
+// need more back and forth. Scores only compare stories within a PRD.
+func (p *PRD) EstimateComplexity() map[string]int {
+	scores := make(map[string]int, len(p.Stories))
+	for _, story := range p.Stories {
+		if story != nil {
+			scores[story.ID] = story.Complexity()
+		}
+	}
+	return scores
+}
+
+// Complexity is the story's EstimateComplexity score.
+func (s *Story) Complexity() int {
+	return 1 + len(s.Slices) + len(s.Description)/complexityDescriptionChars + 2*len(s.vagueFindings())
+}
+
+// NextSimplestReadyStoryExcept is NextReadyStoryExcept with priority ties
+// broken by ascending complexity, so RALPH_SIMPLE_FIRST runs the easy stories
+// of a priority level first.
+func (p *PRD) NextSimplestReadyStoryExcept(skip map[string]bool) *Story {
+	ready := p.readyStoriesExcept(skip)
+	if len(ready) == 0 {
+		return nil
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		if ready[i].Priority != ready[j].Priority {
+			return ready[i].Priority < ready[j].Priority
+		}
+		if ci, cj := ready[i].Complexity(), ready[j].Complexity(); ci != cj {
+			return ci < cj
+		}
+		return ready[i].ID < ready[j].ID
+	})
+	return ready[0]
+}
@@ -0,0 +1,46 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateComplexity(t *testing.T) {
+	specific := &Slice{ID: "slice-1", Behavior: "returns 201 for a valid invite"}
+	p := &PRD{Stories: []*Story{
+		{ID: "small", Description: "Add the route", Slices: []*Slice{specific}},
+		{ID: "long", Description: strings.Repeat("x", 450), Slices: []*Slice{specific, specific}},
+		{ID: "vague", Slices: []*Slice{{ID: "slice-1", Behavior: "login works"}}},
+	}}
+
+	got := p.EstimateComplexity()
+	want := map[string]int{
+		"small": 2, // base + one slice
+		"long":  5, // base + two slices + two 200-char blocks
+		"vague": 6, // base + one slice + empty description + vague behavior
+	}
+	for id, score := range want {
+		if got[id] != score {
+			t.Errorf("complexity[%s] = %d, want %d", id, got[id], score)
+		}
+	}
+}
+
+func TestNextSimplestReadyStoryBreaksPriorityTiesByComplexity(t *testing.T) {
+	specific := &Slice{ID: "slice-1", Behavior: "returns 201 for a valid invite"}
+	p := &PRD{Stories: []*Story{
+		{ID: "story-a", Priority: 1, Description: "Desc", Slices: []*Slice{specific, specific, specific}},
+		{ID: "story-b", Priority: 1, Description: "Desc", Slices: []*Slice{specific}},
+		{ID: "story-c", Priority: 2, Description: "Desc"},
+	}}
+
+	if got := p.NextReadyStory(); got.ID != "story-a" {
+		t.Fatalf("NextReadyStory().ID = %q, want story-a (priority, then ID)", got.ID)
+	}
+	if got := p.NextSimplestReadyStoryExcept(nil); got.ID != "story-b" {
+		t.Fatalf("NextSimplestReadyStoryExcept().ID = %q, want story-b (simpler at the same priority)", got.ID)
+	}
+	if got := p.NextSimplestReadyStoryExcept(map[string]bool{"story-a": true, "story-b": true}); got.ID != "story-c" {
+		t.Fatalf("NextSimplestReadyStoryExcept() = %q, want story-c once priority 1 is skipped", got.ID)
+	}
+}
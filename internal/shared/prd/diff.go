@@ -0,0 +1,118 @@
+package prd
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Diff describes, one line per change, how other differs from p: added and
+// removed stories, changed titles, descriptions, priorities and dependencies,
+// and pass, skip and slice transitions. Bookkeeping such as Version,
+// Iterations and timestamps is ignored. It returns nil when nothing changed.
+func (p *PRD) Diff(other *PRD) []string {
+	var lines []string
+	before := make(map[string]*Story, len(p.Stories))
+	for _, story := range p.Stories {
+		if story != nil {
+			before[story.ID] = story
+		}
+	}
+	after := make(map[string]bool, len(other.Stories))
+	for _, story := range other.Stories {
+		if story == nil {
+			continue
+		}
+		after[story.ID] = true
+		old, ok := before[story.ID]
+		if !ok {
+			lines = append(lines, fmt.Sprintf("added story %s: %s", story.ID, story.Title))
+			continue
+		}
+		lines = append(lines, old.diff(story)...)
+	}
+	for _, story := range p.Stories {
+		if story != nil && !after[story.ID] {
+			lines = append(lines, fmt.Sprintf("removed story %s: %s", story.ID, story.Title))
+		}
+	}
+	return lines
+}
+
+func (s *Story) diff(other *Story) []string {
+	var lines []string
+	changed := func(format string, args ...any) {
+		lines = append(lines, s.ID+": "+fmt.Sprintf(format, args...))
+	}
+	if s.Title != other.Title {
+		changed("title %q -> %q", s.Title, other.Title)
+	}
+	if s.Description != other.Description {
+		changed("description changed")
+	}
+	if s.Priority != other.Priority {
+		changed("priority %d -> %d", s.Priority, other.Priority)
+	}
+	if fmt.Sprint(s.DependsOn) != fmt.Sprint(other.DependsOn) {
+		changed("depends on %v -> %v", s.DependsOn, other.DependsOn)
+	}
+	switch {
+	case !s.Passes && other.Passes:
+		changed("marked passing")
+	case s.Passes && !other.Passes:
+		changed("reset to pending, will be retried")
+	}
+	if s.Skip != other.Skip {
+		if other.Skip {
+			changed("skipped")
+		} else {
+			changed("no longer skipped")
+		}
+	}
+
+	oldSlices := make(map[string]*Slice, len(s.Slices))
+	for _, sl := range s.Slices {
+		if sl != nil {
+			oldSlices[sl.ID] = sl
+		}
+	}
+	newSlices := make(map[string]bool, len(other.Slices))
+	for _, sl := range other.Slices {
+		if sl == nil {
+			continue
+		}
+		newSlices[sl.ID] = true
+		old, ok := oldSlices[sl.ID]
+		switch {
+		case !ok:
+			changed("added slice %s: %s", sl.ID, sl.Behavior)
+		case old.Behavior != sl.Behavior:
+			changed("slice %s behavior %q -> %q", sl.ID, old.Behavior, sl.Behavior)
+		}
+		if ok && old.Passes != sl.Passes {
+			if sl.Passes {
+				changed("slice %s marked passing", sl.ID)
+			} else {
+				changed("slice %s reset to pending", sl.ID)
+			}
+		}
+	}
+	for _, sl := range s.Slices {
+		if sl != nil && !newSlices[sl.ID] {
+			changed("removed slice %s", sl.ID)
+		}
+	}
+	return lines
+}
+
+// Clone returns a deep copy of p.
+func (p *PRD) Clone() *PRD {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil
+	}
+	var clone PRD
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil
+	}
+	return &clone
+}
@@ -0,0 +1,77 @@
+package prd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := func() *PRD {
+		return &PRD{Version: 3, Stories: []*Story{
+			{ID: "story-1", Title: "Login", Description: "Form", Priority: 1, Passes: true, Slices: []*Slice{
+				{ID: "slice-1", Behavior: "renders", Passes: true},
+			}},
+			{ID: "story-2", Title: "Logout", Priority: 2, Slices: []*Slice{
+				{ID: "slice-1", Behavior: "clears session"},
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(p *PRD)
+		want   []string
+	}{
+		{name: "unchanged apart from bookkeeping", mutate: func(p *PRD) {
+			p.Version = 9
+			p.Iterations = 4
+		}},
+		{name: "story fields", mutate: func(p *PRD) {
+			s := p.Stories[1]
+			s.Title = "Sign out"
+			s.Description = "Clear cookies"
+			s.Priority = 5
+			s.DependsOn = []string{"story-1"}
+		}, want: []string{
+			`story-2: title "Logout" -> "Sign out"`,
+			"story-2: description changed",
+			"story-2: priority 2 -> 5",
+			"story-2: depends on [] -> [story-1]",
+		}},
+		{name: "pass and skip transitions", mutate: func(p *PRD) {
+			p.Stories[0].Passes = false
+			p.Stories[0].Slices[0].Passes = false
+			p.Stories[1].Skip = true
+		}, want: []string{
+			"story-1: reset to pending, will be retried",
+			"story-1: slice slice-1 reset to pending",
+			"story-2: skipped",
+		}},
+		{name: "added and removed", mutate: func(p *PRD) {
+			p.Stories[1].Slices = append(p.Stories[1].Slices, &Slice{ID: "slice-2", Behavior: "redirects"})
+			p.Stories[0] = &Story{ID: "story-3", Title: "Profile", Priority: 3}
+		}, want: []string{
+			"added story story-3: Profile",
+			"story-2: added slice slice-2: redirects",
+			"removed story story-1: Login",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base()
+			tt.mutate(other)
+			if got := base().Diff(other); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloneIsDeep(t *testing.T) {
+	p := &PRD{Version: 2, Stories: []*Story{{ID: "story-1", Slices: []*Slice{{ID: "slice-1"}}}}}
+	clone := p.Clone()
+	clone.Stories[0].Slices[0].Passes = true
+	if p.Stories[0].Slices[0].Passes || clone.Version != 2 {
+		t.Fatal("Clone() should copy the PRD without sharing stories or slices")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 func (p *PRD) Snapshot(path string) error {
@@ -45,3 +46,42 @@ func (p *PRD) UnmarkStories(ids []string) error {
 	}
 	return nil
 }
+
+// ResetStory marks a story as not done so the next run implements it again:
+// it clears Passes, Skip, every slice pass and the completion time. With
+// cascade, stories that depend on it, directly or transitively, are reset too.
+// It returns the reset story IDs in PRD order.
+func (p *PRD) ResetStory(id string, cascade bool) ([]string, error) {
+	if p.GetStory(id) == nil {
+		return nil, fmt.Errorf("cannot rerun unknown story %q", id)
+	}
+	reset := map[string]bool{id: true}
+	for changed := cascade; changed; {
+		changed = false
+		for _, story := range p.Stories {
+			if reset[story.ID] {
+				continue
+			}
+			for _, dep := range story.DependsOn {
+				if reset[dep] {
+					reset[story.ID] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var ids []string
+	for _, story := range p.Stories {
+		if !reset[story.ID] {
+			continue
+		}
+		story.Passes = false
+		story.Skip = false
+		story.CompletedAt = time.Time{}
+		story.ResetSlicePasses()
+		ids = append(ids, story.ID)
+	}
+	return ids, nil
+}
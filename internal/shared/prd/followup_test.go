@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestUnmarkStories_knownID(t *testing.T) {
@@ -86,3 +88,44 @@ func TestSnapshot_writesLoadableJSON(t *testing.T) {
 		t.Errorf("loaded story: %+v", loaded.Stories)
 	}
 }
+
+func TestResetStory(t *testing.T) {
+	newPRD := func() *PRD {
+		done := func(id string, deps ...string) *Story {
+			return &Story{ID: id, Passes: true, DependsOn: deps, CompletedAt: time.Unix(100, 0), Slices: []*Slice{{ID: "slice-1", Passes: true}}}
+		}
+		return &PRD{Stories: []*Story{done("story-1"), done("story-2", "story-1"), done("story-3", "story-2"), done("story-4")}}
+	}
+
+	tests := []struct {
+		name    string
+		cascade bool
+		want    []string
+	}{
+		{name: "single story", want: []string{"story-1"}},
+		{name: "cascade to transitive dependents", cascade: true, want: []string{"story-1", "story-2", "story-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPRD()
+			got, err := p.ResetStory("story-1", tt.cascade)
+			if err != nil {
+				t.Fatalf("ResetStory() error = %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("ResetStory() = %v, want %v", got, tt.want)
+			}
+			for _, story := range p.Stories {
+				wantReset := slices.Contains(tt.want, story.ID)
+				isReset := !story.Passes && !story.Slices[0].Passes && story.CompletedAt.IsZero()
+				if isReset != wantReset {
+					t.Errorf("story %s reset = %v, want %v", story.ID, isReset, wantReset)
+				}
+			}
+		})
+	}
+
+	if _, err := newPRD().ResetStory("story-9", false); err == nil || !strings.Contains(err.Error(), `unknown story "story-9"`) {
+		t.Fatalf("ResetStory(unknown) error = %v, want unknown story", err)
+	}
+}
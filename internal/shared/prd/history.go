@@ -0,0 +1,104 @@
+package prd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// HistoryEntry is one generated PRD kept in RALPH_PRD_HISTORY_DIR.
+type HistoryEntry struct {
+	Path        string
+	GeneratedAt time.Time
+	ProjectName string
+	Stories     int
+	seq         int
+}
+
+var historyName = regexp.MustCompile(`^prd-(\d+)(?:-(\d+))?\.json$`)
+
+// SaveHistory writes a copy of p to dir as prd-<unix>.json, adding a -N
+// suffix when a PRD was already kept for the same second. It returns the
+// path written.
+func SaveHistory(dir string, p *PRD, at time.Time) (string, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal PRD for history: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create PRD history dir %q: %w", dir, err)
+	}
+	for seq := 1; ; seq++ {
+		name := fmt.Sprintf("prd-%d.json", at.Unix())
+		if seq > 1 {
+			name = fmt.Sprintf("prd-%d-%d.json", at.Unix(), seq)
+		}
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to write PRD history %q: %w", path, err)
+		}
+		_, writeErr := f.Write(data)
+		if closeErr := f.Close(); writeErr == nil {
+			writeErr = closeErr
+		}
+		if writeErr != nil {
+			return "", fmt.Errorf("failed to write PRD history %q: %w", path, writeErr)
+		}
+		return path, nil
+	}
+}
+
+// ListHistory returns the PRDs kept in dir, oldest first. A missing dir has
+// no history. Files that do not parse are still listed, without a project
+// name or story count.
+func ListHistory(dir string) ([]HistoryEntry, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PRD history dir %q: %w", dir, err)
+	}
+	var entries []HistoryEntry
+	for _, de := range dirEntries {
+		m := historyName.FindStringSubmatch(de.Name())
+		if de.IsDir() || m == nil {
+			continue
+		}
+		unix, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		entry := HistoryEntry{Path: filepath.Join(dir, de.Name()), GeneratedAt: time.Unix(unix, 0), seq: 1}
+		if m[2] != "" {
+			entry.seq, _ = strconv.Atoi(m[2])
+		}
+		var summary struct {
+			ProjectName string            `json:"project_name"`
+			Stories     []json.RawMessage `json:"stories"`
+		}
+		if data, err := os.ReadFile(entry.Path); err == nil && json.Unmarshal(data, &summary) == nil {
+			entry.ProjectName = summary.ProjectName
+			entry.Stories = len(summary.Stories)
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b HistoryEntry) int {
+		if c := a.GeneratedAt.Compare(b.GeneratedAt); c != 0 {
+			return c
+		}
+		return a.seq - b.seq
+	})
+	return entries, nil
+}
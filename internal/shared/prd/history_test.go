@@ -0,0 +1,71 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveHistoryAvoidsCollisionsWithinASecond(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+	at := time.Unix(1700000000, 0)
+	p := &PRD{ProjectName: "Invites", Stories: []*Story{{ID: "story-1"}, {ID: "story-2"}}}
+
+	first, err := SaveHistory(dir, p, at)
+	if err != nil {
+		t.Fatalf("SaveHistory() error = %v", err)
+	}
+	second, err := SaveHistory(dir, p, at)
+	if err != nil {
+		t.Fatalf("SaveHistory() error = %v", err)
+	}
+	if filepath.Base(first) != "prd-1700000000.json" || filepath.Base(second) != "prd-1700000000-2.json" {
+		t.Fatalf("paths = %q, %q, want prd-1700000000.json and prd-1700000000-2.json", first, second)
+	}
+}
+
+func TestListHistory(t *testing.T) {
+	dir := t.TempDir()
+	older := &PRD{ProjectName: "Older", Stories: []*Story{{ID: "story-1"}}}
+	newer := &PRD{ProjectName: "Newer", Stories: []*Story{{ID: "story-1"}, {ID: "story-2"}}}
+	for _, save := range []struct {
+		p  *PRD
+		at int64
+	}{{newer, 1700000100}, {older, 1700000000}, {newer, 1700000000}} {
+		if _, err := SaveHistory(dir, save.p, time.Unix(save.at, 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ListHistory(dir)
+	if err != nil {
+		t.Fatalf("ListHistory() error = %v", err)
+	}
+	want := []struct {
+		name    string
+		project string
+		stories int
+	}{
+		{"prd-1700000000.json", "Older", 1},
+		{"prd-1700000000-2.json", "Newer", 2},
+		{"prd-1700000100.json", "Newer", 2},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("entries = %+v, want %d", entries, len(want))
+	}
+	for i, w := range want {
+		got := entries[i]
+		if filepath.Base(got.Path) != w.name || got.ProjectName != w.project || got.Stories != w.stories {
+			t.Errorf("entries[%d] = %+v, want %s %s (%d stories)", i, got, w.name, w.project, w.stories)
+		}
+	}
+
+	missing, err := ListHistory(filepath.Join(dir, "missing"))
+	if err != nil || missing != nil {
+		t.Fatalf("ListHistory(missing) = %v, %v, want no entries and no error", missing, err)
+	}
+}
@@ -0,0 +1,74 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// vaguePhrases mark slice behaviors that describe intent rather than an
+// observable outcome a failing test could pin down.
+var vaguePhrases = []string{"works", "properly", "correctly", "as expected", "etc", "tbd", "todo", "handle", "appropriate"}
+
+// minBehaviorWords is the shortest behavior that usually names both an input
+// and an expected result.
+const minBehaviorWords = 3
+
+// VagueFindings flags stories and slices that validate but are unlikely to be
+// actionable for a runner: missing descriptions, very short behaviors, and
+// behaviors phrased with vague wording. An empty result means nothing was
+// flagged.
+func (p *PRD) VagueFindings() []string {
+	var findings []string
+	for _, story := range p.Stories {
+		if story == nil {
+			continue
+		}
+		findings = append(findings, story.vagueFindings()...)
+	}
+	return findings
+}
+
+func (s *Story) vagueFindings() []string {
+	var findings []string
+	if strings.TrimSpace(s.Description) == "" {
+		findings = append(findings, fmt.Sprintf("story %s: description is empty", s.ID))
+	}
+	for _, sl := range s.Slices {
+		if sl == nil {
+			continue
+		}
+		if reason := vagueBehaviorReason(sl.Behavior); reason != "" {
+			findings = append(findings, fmt.Sprintf("story %s slice %s: %s: %q", s.ID, sl.ID, reason, sl.Behavior))
+		}
+	}
+	return findings
+}
+
+func vagueBehaviorReason(behavior string) string {
+	words := strings.Fields(strings.ToLower(behavior))
+	if len(words) < minBehaviorWords {
+		return fmt.Sprintf("behavior has fewer than %d words", minBehaviorWords)
+	}
+	for _, phrase := range vaguePhrases {
+		if containsPhrase(words, strings.Fields(phrase)) {
+			return fmt.Sprintf("behavior uses vague wording %q", phrase)
+		}
+	}
+	return ""
+}
+
+func containsPhrase(words, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		match := true
+		for j, w := range phrase {
+			if strings.Trim(words[i+j], ".,;:!?()") != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVagueFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		story    *Story
+		wantHits []string
+	}{
+		{
+			name:  "specific story",
+			story: &Story{ID: "story-1", Description: "Invite API", Slices: []*Slice{{ID: "slice-1", Behavior: "POST /invites returns 201 with the invite id"}}},
+		},
+		{
+			name:     "empty description",
+			story:    &Story{ID: "story-1", Slices: []*Slice{{ID: "slice-1", Behavior: "POST /invites returns 201"}}},
+			wantHits: []string{"story story-1: description is empty"},
+		},
+		{
+			name:     "short behavior",
+			story:    &Story{ID: "story-1", Description: "d", Slices: []*Slice{{ID: "slice-1", Behavior: "login works"}}},
+			wantHits: []string{"slice slice-1: behavior has fewer than 3 words"},
+		},
+		{
+			name:     "vague wording",
+			story:    &Story{ID: "story-1", Description: "d", Slices: []*Slice{{ID: "slice-1", Behavior: "the form validates input correctly."}}},
+			wantHits: []string{`vague wording "correctly"`},
+		},
+		{
+			name:  "vague word inside another word",
+			story: &Story{ID: "story-1", Description: "d", Slices: []*Slice{{ID: "slice-1", Behavior: "handler returns 404 for missing ids"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&PRD{Stories: []*Story{tt.story}}).VagueFindings()
+			if len(got) != len(tt.wantHits) {
+				t.Fatalf("VagueFindings() = %q, want %d findings", got, len(tt.wantHits))
+			}
+			for i, want := range tt.wantHits {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("finding %d = %q, want containing %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
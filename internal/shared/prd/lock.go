@@ -2,12 +2,12 @@ package prd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/gofrs/flock"
 	"ralph/internal/shared/config"
-	"ralph/internal/shared/constants"
 )
 
 // LockTimeoutError is returned when a file lock cannot be acquired in time.
@@ -36,36 +36,28 @@ func LockPath(prdPath string) string {
 }
 
 func acquireSharedLock(cfg *config.Config) (*flock.Flock, error) {
-	lockPath := LockPath(cfg.PRDPath())
-	fileLock := flock.New(lockPath)
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(constants.FileLockTimeout)*time.Second)
-	defer cancel()
-
-	locked, err := fileLock.TryLockContext(ctx, time.Duration(constants.FileLockRetryDelay)*time.Millisecond)
-	if err != nil {
-		return nil, fmt.Errorf("error acquiring shared lock: %w", err)
-	}
-	if !locked {
-		return nil, &LockTimeoutError{Path: lockPath, Timeout: time.Duration(constants.FileLockTimeout) * time.Second}
-	}
-
-	return fileLock, nil
+	return acquireLock(cfg, "shared")
 }
 
 func acquireExclusiveLock(cfg *config.Config) (*flock.Flock, error) {
+	return acquireLock(cfg, "exclusive")
+}
+
+// acquireLock waits up to RALPH_LOCK_TIMEOUT for the PRD lock, retrying every
+// RALPH_LOCK_RETRY_DELAY.
+func acquireLock(cfg *config.Config, kind string) (*flock.Flock, error) {
 	lockPath := LockPath(cfg.PRDPath())
 	fileLock := flock.New(lockPath)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(constants.FileLockTimeout)*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.PRDLockTimeout())
 	defer cancel()
 
-	locked, err := fileLock.TryLockContext(ctx, time.Duration(constants.FileLockRetryDelay)*time.Millisecond)
-	if err != nil {
-		return nil, fmt.Errorf("error acquiring exclusive lock: %w", err)
+	locked, err := fileLock.TryLockContext(ctx, cfg.PRDLockRetryDelay())
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("error acquiring %s lock: %w", kind, err)
 	}
 	if !locked {
-		return nil, &LockTimeoutError{Path: lockPath, Timeout: time.Duration(constants.FileLockTimeout) * time.Second}
+		return nil, &LockTimeoutError{Path: lockPath, Timeout: cfg.PRDLockTimeout()}
 	}
 
 	return fileLock, nil
@@ -0,0 +1,117 @@
+package prd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/gofrs/flock"
+	"ralph/internal/shared/config"
+)
+
+// LockOwner records which process is driving a run against the PRD.
+type LockOwner struct {
+	PID   int       `json:"pid"`
+	Since time.Time `json:"since"`
+}
+
+// LockStatus describes the PRD lock for diagnosing stuck runs.
+type LockStatus struct {
+	LockPath   string     `json:"lock_path"`
+	Held       bool       `json:"held"`
+	Owner      *LockOwner `json:"owner,omitempty"`
+	OwnerAlive bool       `json:"owner_alive"`
+	Stale      bool       `json:"stale"`
+}
+
+// OwnerPath returns the owner file path for a PRD file. It lives under .ralph
+// so it is never auto-committed.
+func OwnerPath(prdPath string) string {
+	return filepath.Join(filepath.Dir(prdPath), ".ralph", "lock-owner.json")
+}
+
+// ClaimLockOwner writes the owner file for the current process. The returned
+// release func removes it again unless another process has since claimed it.
+func ClaimLockOwner(cfg *config.Config, now time.Time) (func(), error) {
+	path := OwnerPath(cfg.PRDPath())
+	owner := LockOwner{PID: os.Getpid(), Since: now}
+	data, err := json.Marshal(owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal lock owner: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create state dir for %q: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write lock owner %q: %w", path, err)
+	}
+	return func() {
+		if current, err := ReadLockOwner(cfg); err == nil && current != nil && current.PID == owner.PID {
+			_ = os.Remove(path)
+		}
+	}, nil
+}
+
+// ReadLockOwner returns nil without error when no owner file exists.
+func ReadLockOwner(cfg *config.Config) (*LockOwner, error) {
+	path := OwnerPath(cfg.PRDPath())
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock owner %q: %w", path, err)
+	}
+	var owner LockOwner
+	if err := json.Unmarshal(data, &owner); err != nil {
+		return nil, fmt.Errorf("failed to parse lock owner %q: %w", path, err)
+	}
+	return &owner, nil
+}
+
+// InspectLock reports whether the PRD lock is currently held and whether the
+// recorded owner process is still alive. An owner whose process is gone is
+// reported as stale.
+func InspectLock(cfg *config.Config) (*LockStatus, error) {
+	lockPath := LockPath(cfg.PRDPath())
+	status := &LockStatus{LockPath: lockPath}
+
+	if _, err := os.Stat(lockPath); err == nil {
+		fileLock := flock.New(lockPath)
+		locked, err := fileLock.TryLock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to probe lock %q: %w", lockPath, err)
+		}
+		if locked {
+			_ = fileLock.Unlock()
+		}
+		status.Held = !locked
+	}
+
+	owner, err := ReadLockOwner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if owner != nil {
+		status.Owner = owner
+		status.OwnerAlive = processAlive(owner.PID)
+		status.Stale = !status.OwnerAlive
+	}
+	return status, nil
+}
+
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,90 @@
+package prd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInspectLockReportsStaleOwner(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newTestConfig(t, dir, "prd.json")
+	owner := LockOwner{PID: 99999999, Since: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	data, _ := json.Marshal(owner)
+	if err := os.MkdirAll(filepath.Join(dir, ".ralph"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(OwnerPath(cfg.PRDPath()), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := InspectLock(cfg)
+	if err != nil {
+		t.Fatalf("InspectLock() error = %v", err)
+	}
+	if status.Owner == nil || status.Owner.PID != owner.PID || !status.Owner.Since.Equal(owner.Since) {
+		t.Fatalf("Owner = %+v, want %+v", status.Owner, owner)
+	}
+	if status.OwnerAlive {
+		t.Error("OwnerAlive = true for a PID that does not exist")
+	}
+	if !status.Stale {
+		t.Error("Stale = false, want true when the owner process is gone")
+	}
+	if status.Held {
+		t.Error("Held = true with no lock file")
+	}
+}
+
+func TestInspectLockWithoutOwner(t *testing.T) {
+	cfg := newTestConfig(t, t.TempDir(), "prd.json")
+
+	status, err := InspectLock(cfg)
+	if err != nil {
+		t.Fatalf("InspectLock() error = %v", err)
+	}
+	if status.Owner != nil || status.Stale || status.Held {
+		t.Fatalf("status = %+v, want free lock with no owner", status)
+	}
+}
+
+func TestInspectLockReportsHeldLock(t *testing.T) {
+	cfg := newTestConfig(t, t.TempDir(), "prd.json")
+	fileLock, err := acquireExclusiveLock(cfg)
+	if err != nil {
+		t.Fatalf("acquireExclusiveLock() error = %v", err)
+	}
+	defer fileLock.Unlock()
+
+	status, err := InspectLock(cfg)
+	if err != nil {
+		t.Fatalf("InspectLock() error = %v", err)
+	}
+	if !status.Held {
+		t.Error("Held = false while another handle holds the lock")
+	}
+}
+
+func TestClaimLockOwnerRecordsCurrentProcess(t *testing.T) {
+	cfg := newTestConfig(t, t.TempDir(), "prd.json")
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	release, err := ClaimLockOwner(cfg, now)
+	if err != nil {
+		t.Fatalf("ClaimLockOwner() error = %v", err)
+	}
+	status, err := InspectLock(cfg)
+	if err != nil {
+		t.Fatalf("InspectLock() error = %v", err)
+	}
+	if status.Owner == nil || status.Owner.PID != os.Getpid() || !status.OwnerAlive || status.Stale {
+		t.Fatalf("status = %+v, want live owner for this process", status)
+	}
+
+	release()
+	if owner, err := ReadLockOwner(cfg); err != nil || owner != nil {
+		t.Fatalf("ReadLockOwner() after release = %+v, %v; want nil, nil", owner, err)
+	}
+}
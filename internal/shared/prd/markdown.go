@@ -0,0 +1,70 @@
+package prd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ralph/internal/shared/config"
+)
+
+// ToMarkdown renders the PRD as a readable review document. The JSON file
+// stays the source of truth; the markdown is derived from it.
+func (p *PRD) ToMarkdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", p.ProjectName)
+	if p.BranchName != "" {
+		fmt.Fprintf(&b, "\nBranch: `%s`\n", p.BranchName)
+	}
+	if p.Context != "" {
+		fmt.Fprintf(&b, "\n## Context\n\n%s\n", strings.TrimSpace(p.Context))
+	}
+	if p.TestSpec != "" {
+		fmt.Fprintf(&b, "\n## Test spec\n\n%s\n", strings.TrimSpace(p.TestSpec))
+	}
+	b.WriteString("\n## Stories\n")
+	for i, story := range p.Stories {
+		if story == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%d. **%s** (`%s`, priority %d)\n", i+1, story.Title, story.ID, story.Priority)
+		if story.Description != "" {
+			fmt.Fprintf(&b, "\n   %s\n", strings.ReplaceAll(strings.TrimSpace(story.Description), "\n", "\n   "))
+		}
+		if len(story.DependsOn) > 0 {
+			fmt.Fprintf(&b, "\n   Depends on: %s\n", strings.Join(story.DependsOn, ", "))
+		}
+		if len(story.Slices) > 0 {
+			b.WriteString("\n")
+		}
+		for _, sl := range story.Slices {
+			if sl == nil {
+				continue
+			}
+			check := " "
+			if sl.Passes {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "   - [%s] %s\n", check, sl.Behavior)
+			if sl.RedHint != "" {
+				fmt.Fprintf(&b, "     - Test: %s\n", sl.RedHint)
+			}
+		}
+	}
+	return b.String()
+}
+
+// MarkdownPath returns the prd.md sibling of a PRD JSON file.
+func MarkdownPath(prdPath string) string {
+	return strings.TrimSuffix(prdPath, filepath.Ext(prdPath)) + ".md"
+}
+
+// WriteMarkdown writes p.ToMarkdown() next to the PRD file and returns its path.
+func WriteMarkdown(cfg *config.Config, p *PRD) (string, error) {
+	path := MarkdownPath(cfg.PRDPath())
+	if err := os.WriteFile(path, []byte(p.ToMarkdown()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write PRD markdown %q: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,80 @@
+package prd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+)
+
+func TestToMarkdown(t *testing.T) {
+	p := &PRD{
+		ProjectName: "Team Invites",
+		BranchName:  "feature/team-invites",
+		Context:     "Go service; run go test ./...",
+		TestSpec:    "Inviting twice yields one invite.",
+		Stories: []*Story{
+			{ID: "story-1", Title: "Invite API", Description: "Add POST /invites.", Priority: 1, Passes: true, Slices: []*Slice{
+				{ID: "slice-1", Behavior: "returns 201", RedHint: "POST test", Passes: true},
+			}},
+			{ID: "story-2", Title: "Accept invite", Priority: 2, DependsOn: []string{"story-1"}, Slices: []*Slice{
+				{ID: "slice-1", Behavior: "marks accepted", RedHint: "accept test"},
+			}},
+		},
+	}
+
+	got := p.ToMarkdown()
+	for _, want := range []string{
+		"# Team Invites\n",
+		"Branch: `feature/team-invites`",
+		"## Context\n\nGo service; run go test ./...",
+		"## Test spec\n\nInviting twice yields one invite.",
+		"1. **Invite API** (`story-1`, priority 1)",
+		"   Add POST /invites.",
+		"   - [x] returns 201\n     - Test: POST test",
+		"2. **Accept invite** (`story-2`, priority 2)",
+		"   Depends on: story-1",
+		"   - [ ] marks accepted",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("ToMarkdown() missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestToMarkdownSkipsNilSlices(t *testing.T) {
+	p := &PRD{ProjectName: "Nil", Stories: []*Story{
+		{ID: "story-1", Title: "Story", Priority: 1, Slices: []*Slice{nil, {ID: "slice-1", Behavior: "works"}}},
+	}}
+
+	got := p.ToMarkdown()
+	if !strings.Contains(got, "   - [ ] works\n") {
+		t.Fatalf("ToMarkdown() missing the non-nil slice:\n%s", got)
+	}
+	if n := strings.Count(got, "   - ["); n != 1 {
+		t.Errorf("ToMarkdown() rendered %d slices, want 1:\n%s", n, got)
+	}
+}
+
+func TestWriteMarkdownWritesSiblingOfPRD(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{WorkDir: dir, PRDFile: "prd.json"}
+	p := &PRD{ProjectName: "Doc", Stories: []*Story{{ID: "story-1", Title: "One", Priority: 1}}}
+
+	path, err := WriteMarkdown(cfg, p)
+	if err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	if path != filepath.Join(dir, "prd.md") {
+		t.Fatalf("path = %q, want prd.md next to prd.json", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != p.ToMarkdown() {
+		t.Fatalf("file contents differ from ToMarkdown():\n%s", data)
+	}
+}
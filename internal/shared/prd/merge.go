@@ -0,0 +1,65 @@
+package prd
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Merge folds a newly generated PRD into p for --append. Stories from other
+// whose ID is new are appended; stories p already has are kept as they are,
+// so their progress survives. An ID that exists in both with different
+// content is an error and leaves p untouched. Project-level fields come from
+// p unless they are empty there.
+func (p *PRD) Merge(other *PRD) error {
+	if other == nil {
+		return nil
+	}
+	var added []*Story
+	seen := make(map[string]bool, len(other.Stories))
+	for _, story := range other.Stories {
+		if story == nil {
+			continue
+		}
+		if seen[story.ID] {
+			return fmt.Errorf("cannot merge PRD: story %q appears more than once in the new PRD", story.ID)
+		}
+		seen[story.ID] = true
+		existing := p.GetStory(story.ID)
+		if existing == nil {
+			added = append(added, story)
+			continue
+		}
+		if !existing.sameContent(story) {
+			return fmt.Errorf("cannot merge PRD: story %q already exists with different content (%q vs %q); give the new story a different id", story.ID, existing.Title, story.Title)
+		}
+	}
+
+	if p.ProjectName == "" {
+		p.ProjectName = other.ProjectName
+	}
+	if p.BranchName == "" {
+		p.BranchName = other.BranchName
+	}
+	if p.Context == "" {
+		p.Context = other.Context
+	}
+	if p.TestSpec == "" {
+		p.TestSpec = other.TestSpec
+	}
+	if p.TestCommand == "" {
+		p.TestCommand = other.TestCommand
+	}
+	p.Stories = append(p.Stories, added...)
+	return nil
+}
+
+// sameContent reports whether two stories describe the same work, ignoring
+// progress fields such as Passes and the timestamps.
+func (s *Story) sameContent(other *Story) bool {
+	if s.Title != other.Title || s.Description != other.Description || !slices.Equal(s.DependsOn, other.DependsOn) {
+		return false
+	}
+	return slices.EqualFunc(s.Slices, other.Slices, func(a, b *Slice) bool {
+		return a.ID == b.ID && a.Behavior == b.Behavior && a.RedHint == b.RedHint && a.RefactorHint == b.RefactorHint
+	})
+}
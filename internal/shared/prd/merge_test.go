@@ -0,0 +1,90 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeAppendsNewStoriesAndKeepsProgress(t *testing.T) {
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	p := &PRD{
+		ProjectName: "Invites",
+		BranchName:  "feature/invites",
+		Stories: []*Story{
+			{ID: "story-1", Title: "Invite API", Priority: 1, Passes: true, StartedAt: started, Slices: []*Slice{
+				{ID: "slice-1", Behavior: "returns 201", RedHint: "POST test", Passes: true},
+			}},
+		},
+	}
+	other := &PRD{
+		ProjectName: "Accept invites",
+		BranchName:  "feature/accept",
+		Context:     "Go service",
+		Stories: []*Story{
+			{ID: "story-1", Title: "Invite API", Priority: 3, Slices: []*Slice{
+				{ID: "slice-1", Behavior: "returns 201", RedHint: "POST test"},
+			}},
+			{ID: "story-2", Title: "Accept invite", Priority: 2, DependsOn: []string{"story-1"}, Slices: []*Slice{
+				{ID: "slice-1", Behavior: "marks accepted", RedHint: "accept test"},
+			}},
+		},
+	}
+
+	if err := p.Merge(other); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if p.ProjectName != "Invites" || p.BranchName != "feature/invites" {
+		t.Errorf("project/branch = %q/%q, want the current PRD's", p.ProjectName, p.BranchName)
+	}
+	if p.Context != "Go service" {
+		t.Errorf("Context = %q, want it filled from the new PRD", p.Context)
+	}
+	if len(p.Stories) != 2 || p.Stories[1].ID != "story-2" {
+		t.Fatalf("stories = %d, want story-2 appended after story-1", len(p.Stories))
+	}
+	kept := p.Stories[0]
+	if !kept.Passes || !kept.Slices[0].Passes || !kept.StartedAt.Equal(started) || kept.Priority != 1 {
+		t.Errorf("story-1 = %+v, want its progress and priority preserved", kept)
+	}
+}
+
+func TestMergeRejectsConflictingStoryIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		other  *PRD
+		errMsg string
+	}{
+		{
+			name:   "different title",
+			other:  &PRD{Stories: []*Story{{ID: "story-1", Title: "Something else", Slices: []*Slice{{ID: "slice-1", Behavior: "returns 201"}}}}},
+			errMsg: `story "story-1" already exists with different content`,
+		},
+		{
+			name:   "different slices",
+			other:  &PRD{Stories: []*Story{{ID: "story-1", Title: "Invite API", Slices: []*Slice{{ID: "slice-1", Behavior: "returns 200"}}}}},
+			errMsg: `story "story-1" already exists with different content`,
+		},
+		{
+			name: "duplicate in new PRD",
+			other: &PRD{Stories: []*Story{
+				{ID: "story-2", Title: "A", Slices: []*Slice{{ID: "slice-1", Behavior: "a"}}},
+				{ID: "story-2", Title: "B", Slices: []*Slice{{ID: "slice-1", Behavior: "b"}}},
+			}},
+			errMsg: `story "story-2" appears more than once`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PRD{Stories: []*Story{{ID: "story-1", Title: "Invite API", Slices: []*Slice{{ID: "slice-1", Behavior: "returns 201"}}}}}
+			err := p.Merge(tt.other)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Fatalf("Merge() error = %v, want containing %q", err, tt.errMsg)
+			}
+			if len(p.Stories) != 1 {
+				t.Fatalf("stories = %d after failed merge, want the PRD untouched", len(p.Stories))
+			}
+		})
+	}
+}
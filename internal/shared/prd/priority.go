@@ -0,0 +1,82 @@
+package prd
+
+import (
+	"slices"
+	"sort"
+)
+
+// SortStories orders p.Stories the way NextReadyStory picks them: by priority,
+// then by ID. The sort is stable, so the result does not depend on how the
+// stories happened to be ordered before.
+func (p *PRD) SortStories() {
+	sort.SliceStable(p.Stories, func(i, j int) bool {
+		return storyRunsBefore(p.Stories[i], p.Stories[j])
+	})
+}
+
+// storyRunsBefore is the priority-then-ID order NextReadyStory uses.
+func storyRunsBefore(a, b *Story) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	return a.ID < b.ID
+}
+
+// NormalizePriorities renumbers story priorities to a dense 1..N sequence in
+// the order NextReadyStory would pick them (priority, then ID). It reports
+// whether any priority changed; story order in the slice is left untouched.
+func (p *PRD) NormalizePriorities() bool {
+	ordered := make([]*Story, 0, len(p.Stories))
+	for _, story := range p.Stories {
+		if story != nil {
+			ordered = append(ordered, story)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return storyRunsBefore(ordered[i], ordered[j])
+	})
+
+	changed := false
+	for i, story := range ordered {
+		if story.Priority != i+1 {
+			story.Priority = i + 1
+			changed = true
+		}
+	}
+	return changed
+}
+
+// KeepTopStories trims p to its n highest-priority stories (priority, then
+// ID, as NextReadyStory orders them), keeping their order in the file, and
+// drops depends_on entries that pointed at a removed story. It returns the
+// removed story IDs, or nil when p already has n stories or fewer.
+func (p *PRD) KeepTopStories(n int) []string {
+	if n <= 0 || len(p.Stories) <= n {
+		return nil
+	}
+	ordered := slices.Clone(p.Stories)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return storyRunsBefore(ordered[i], ordered[j])
+	})
+	keep := make(map[*Story]bool, n)
+	for _, story := range ordered[:n] {
+		keep[story] = true
+	}
+
+	var kept []*Story
+	var removed []string
+	for _, story := range p.Stories {
+		if keep[story] {
+			kept = append(kept, story)
+		} else {
+			removed = append(removed, story.ID)
+		}
+	}
+	for _, story := range kept {
+		story.DependsOn = slices.DeleteFunc(story.DependsOn, func(id string) bool {
+			return slices.Contains(removed, id)
+		})
+	}
+	p.Stories = kept
+	return removed
+}
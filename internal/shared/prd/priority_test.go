@@ -0,0 +1,110 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizePriorities(t *testing.T) {
+	tests := []struct {
+		name        string
+		stories     []*Story
+		want        map[string]int
+		wantChanged bool
+	}{
+		{
+			name:        "sparse priorities become dense",
+			stories:     []*Story{{ID: "a", Priority: 1}, {ID: "b", Priority: 5}, {ID: "c", Priority: 10}},
+			want:        map[string]int{"a": 1, "b": 2, "c": 3},
+			wantChanged: true,
+		},
+		{
+			name:        "duplicate priorities break ties by ID",
+			stories:     []*Story{{ID: "b", Priority: 1}, {ID: "a", Priority: 1}, {ID: "c", Priority: 3}},
+			want:        map[string]int{"a": 1, "b": 2, "c": 3},
+			wantChanged: true,
+		},
+		{
+			name:        "unsorted slice keeps relative priority order",
+			stories:     []*Story{{ID: "z", Priority: 7}, {ID: "y", Priority: 0}, {ID: "x", Priority: 3}},
+			want:        map[string]int{"y": 1, "x": 2, "z": 3},
+			wantChanged: true,
+		},
+		{
+			name:        "dense priorities are unchanged",
+			stories:     []*Story{{ID: "a", Priority: 1}, {ID: "b", Priority: 2}},
+			want:        map[string]int{"a": 1, "b": 2},
+			wantChanged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PRD{Stories: tt.stories}
+			order := make([]string, len(p.Stories))
+			for i, s := range p.Stories {
+				order[i] = s.ID
+			}
+
+			if got := p.NormalizePriorities(); got != tt.wantChanged {
+				t.Errorf("NormalizePriorities() = %v, want %v", got, tt.wantChanged)
+			}
+			for i, s := range p.Stories {
+				if s.ID != order[i] {
+					t.Fatalf("story %d = %q, want slice order preserved (%q)", i, s.ID, order[i])
+				}
+				if s.Priority != tt.want[s.ID] {
+					t.Errorf("story %q priority = %d, want %d", s.ID, s.Priority, tt.want[s.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestKeepTopStories(t *testing.T) {
+	p := &PRD{Stories: []*Story{
+		{ID: "story-1", Priority: 3},
+		{ID: "story-2", Priority: 1},
+		{ID: "story-3", Priority: 2, DependsOn: []string{"story-1", "story-2"}},
+		{ID: "story-4", Priority: 2},
+	}}
+
+	removed := p.KeepTopStories(3)
+	if len(removed) != 1 || removed[0] != "story-1" {
+		t.Fatalf("removed = %v, want [story-1]", removed)
+	}
+	var ids []string
+	for _, story := range p.Stories {
+		ids = append(ids, story.ID)
+	}
+	if strings.Join(ids, ",") != "story-2,story-3,story-4" {
+		t.Fatalf("stories = %v, want the three highest-priority in file order", ids)
+	}
+	if deps := p.GetStory("story-3").DependsOn; len(deps) != 1 || deps[0] != "story-2" {
+		t.Fatalf("story-3 depends_on = %v, want the dependency on the dropped story removed", deps)
+	}
+	if removed := p.KeepTopStories(5); removed != nil {
+		t.Fatalf("KeepTopStories over the story count removed %v", removed)
+	}
+}
+
+func TestSortStories(t *testing.T) {
+	p := &PRD{Stories: []*Story{
+		{ID: "story-c", Priority: 2},
+		{ID: "story-b", Priority: 1},
+		{ID: "story-d", Priority: 2},
+		{ID: "story-a", Priority: 1},
+	}}
+
+	want := "story-a,story-b,story-c,story-d"
+	for round := 1; round <= 3; round++ {
+		p.SortStories()
+		var ids []string
+		for _, story := range p.Stories {
+			ids = append(ids, story.ID)
+		}
+		if got := strings.Join(ids, ","); got != want {
+			t.Fatalf("round %d: stories = %s, want %s (priority, then ID)", round, got, want)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package prd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseMarkdownSpec builds a PRD from a markdown spec instead of asking the
+// runner to generate one. The structure is:
+//
+//	# Project name
+//	Optional context paragraphs.
+//
+//	## Story: Title
+//	Description paragraphs.
+//	- one slice behavior per bullet
+//
+//	```test_spec
+//	Holistic test scenarios (may appear anywhere, blocks are concatenated).
+//	```
+//
+// Stories are numbered story-1..N in document order with matching priorities.
+// The result is validated before it is returned.
+func ParseMarkdownSpec(data []byte, branchPrefix string) (*PRD, error) {
+	p := &PRD{}
+	var context, testSpec []string
+	var description []string
+	var story *Story
+	var fence string
+	var fenceLines []string
+
+	flushStory := func() {
+		if story == nil {
+			return
+		}
+		story.Description = strings.TrimSpace(strings.Join(description, "\n"))
+		p.Stories = append(p.Stories, story)
+		story, description = nil, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxContextSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, "```") {
+				if fence == "test_spec" {
+					testSpec = append(testSpec, strings.TrimSpace(strings.Join(fenceLines, "\n")))
+				} else if story != nil {
+					description = append(description, fenceLines...)
+					description = append(description, line)
+				} else {
+					context = append(context, fenceLines...)
+					context = append(context, line)
+				}
+				fence, fenceLines = "", nil
+				continue
+			}
+			fenceLines = append(fenceLines, line)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			fence = strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			if fence == "" {
+				fence = "code"
+			}
+			if fence != "test_spec" {
+				fenceLines = []string{line}
+			}
+		case strings.HasPrefix(trimmed, "## "):
+			flushStory()
+			title := strings.TrimSpace(strings.TrimPrefix(trimmed, "## "))
+			if rest, ok := strings.CutPrefix(title, "Story:"); ok {
+				title = strings.TrimSpace(rest)
+			}
+			n := len(p.Stories) + 1
+			story = &Story{ID: fmt.Sprintf("story-%d", n), Title: title, Priority: n}
+		case strings.HasPrefix(trimmed, "# ") && story == nil && p.ProjectName == "":
+			p.ProjectName = strings.TrimSpace(strings.TrimPrefix(trimmed, "# "))
+		case story != nil && isBullet(trimmed):
+			behavior := strings.TrimSpace(trimmed[2:])
+			story.Slices = append(story.Slices, &Slice{
+				ID:       fmt.Sprintf("slice-%d", len(story.Slices)+1),
+				Behavior: behavior,
+				RedHint:  "add a failing test showing that " + behavior,
+			})
+		case story != nil:
+			description = append(description, line)
+		default:
+			context = append(context, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	if fence != "" {
+		return nil, fmt.Errorf("unterminated %q code fence", fence)
+	}
+	flushStory()
+
+	if p.ProjectName == "" {
+		return nil, errors.New("spec must start with a \"# Project name\" heading")
+	}
+	if len(p.Stories) == 0 {
+		return nil, errors.New("spec has no \"## Story\" headings")
+	}
+	p.Context = strings.TrimSpace(strings.Join(context, "\n"))
+	p.TestSpec = strings.Join(testSpec, "\n\n")
+	if slug := specSlug(p.ProjectName); slug != "" {
+		p.BranchName = branchPrefix + "/" + slug
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("spec does not describe a valid PRD: %w", err)
+	}
+	return p, nil
+}
+
+func isBullet(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ")
+}
+
+func specSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
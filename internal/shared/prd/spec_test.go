@@ -0,0 +1,92 @@
+package prd
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleSpec = "# Team Invites\n" +
+	"\n" +
+	"Go service with a chi router; run go test ./...\n" +
+	"\n" +
+	"## Story: Invite API\n" +
+	"Add POST /invites that stores a pending invite.\n" +
+	"\n" +
+	"- POST /invites with a valid email returns 201\n" +
+	"- duplicate invites return 409\n" +
+	"\n" +
+	"```test_spec\n" +
+	"Inviting the same email twice yields one pending invite.\n" +
+	"```\n" +
+	"\n" +
+	"## Accept invite\n" +
+	"* GET /invites/{token}/accept marks the invite accepted\n"
+
+func TestParseMarkdownSpec(t *testing.T) {
+	p, err := ParseMarkdownSpec([]byte(sampleSpec), "feature")
+	if err != nil {
+		t.Fatalf("ParseMarkdownSpec() error = %v", err)
+	}
+
+	if p.ProjectName != "Team Invites" {
+		t.Errorf("ProjectName = %q, want %q", p.ProjectName, "Team Invites")
+	}
+	if p.BranchName != "feature/team-invites" {
+		t.Errorf("BranchName = %q, want %q", p.BranchName, "feature/team-invites")
+	}
+	if p.Context != "Go service with a chi router; run go test ./..." {
+		t.Errorf("Context = %q", p.Context)
+	}
+	if p.TestSpec != "Inviting the same email twice yields one pending invite." {
+		t.Errorf("TestSpec = %q", p.TestSpec)
+	}
+
+	want := []struct {
+		id, title, description string
+		priority               int
+		behaviors              []string
+	}{
+		{"story-1", "Invite API", "Add POST /invites that stores a pending invite.", 1,
+			[]string{"POST /invites with a valid email returns 201", "duplicate invites return 409"}},
+		{"story-2", "Accept invite", "", 2,
+			[]string{"GET /invites/{token}/accept marks the invite accepted"}},
+	}
+	if len(p.Stories) != len(want) {
+		t.Fatalf("got %d stories, want %d", len(p.Stories), len(want))
+	}
+	for i, w := range want {
+		s := p.Stories[i]
+		if s.ID != w.id || s.Title != w.title || s.Description != w.description || s.Priority != w.priority {
+			t.Errorf("story %d = {%q %q %q %d}, want {%q %q %q %d}", i, s.ID, s.Title, s.Description, s.Priority, w.id, w.title, w.description, w.priority)
+		}
+		if len(s.Slices) != len(w.behaviors) {
+			t.Fatalf("story %s has %d slices, want %d", s.ID, len(s.Slices), len(w.behaviors))
+		}
+		for j, behavior := range w.behaviors {
+			if s.Slices[j].Behavior != behavior || s.Slices[j].RedHint == "" {
+				t.Errorf("story %s slice %d = %+v, want behavior %q with a red hint", s.ID, j, s.Slices[j], behavior)
+			}
+		}
+	}
+}
+
+func TestParseMarkdownSpecRejectsInvalidSpecs(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   string
+		errMsg string
+	}{
+		{name: "missing project heading", spec: "## Story: A\n- works\n", errMsg: "# Project name"},
+		{name: "no stories", spec: "# Project\nJust context.\n", errMsg: "no \"## Story\" headings"},
+		{name: "story without criteria", spec: "# Project\n## Story: A\nNo bullets here.\n", errMsg: "at least one slice"},
+		{name: "unterminated fence", spec: "# Project\n## Story: A\n- works\n```test_spec\nnever closed\n", errMsg: "unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMarkdownSpec([]byte(tt.spec), "feature")
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Fatalf("ParseMarkdownSpec() error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package prd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PriorityCount is the number of stories sharing one priority value.
+type PriorityCount struct {
+	Priority int
+	Stories  int
+}
+
+// Stats is a quick sanity check of a freshly generated PRD, shown after a
+// dry run. It reuses the terms of validation and VagueFindings.
+type Stats struct {
+	Stories    int
+	ByPriority []PriorityCount // Ascending priority, so the first bucket runs first
+	// WeakTests counts stories with a slice that has no red_hint, leaving the
+	// runner to invent the failing test itself.
+	WeakTests int
+	// Vague counts stories VagueFindings would flag.
+	Vague int
+}
+
+// Stats counts the PRD's stories by priority and how many have weak test
+// guidance or vague wording. The PRD's Summary field is the completion
+// changelog, hence the different name.
+func (p *PRD) Stats() Stats {
+	var st Stats
+	byPriority := make(map[int]int)
+	for _, story := range p.Stories {
+		if story == nil {
+			continue
+		}
+		st.Stories++
+		byPriority[story.Priority]++
+		if story.hasWeakTests() {
+			st.WeakTests++
+		}
+		if len(story.vagueFindings()) > 0 {
+			st.Vague++
+		}
+	}
+	for priority, n := range byPriority {
+		st.ByPriority = append(st.ByPriority, PriorityCount{Priority: priority, Stories: n})
+	}
+	sort.Slice(st.ByPriority, func(i, j int) bool { return st.ByPriority[i].Priority < st.ByPriority[j].Priority })
+	return st
+}
+
+// Lines renders the stats as short human-readable lines.
+func (st Stats) Lines() []string {
+	buckets := make([]string, 0, len(st.ByPriority))
+	for _, b := range st.ByPriority {
+		buckets = append(buckets, fmt.Sprintf("P%d: %d", b.Priority, b.Stories))
+	}
+	lines := []string{fmt.Sprintf("%d stories", st.Stories)}
+	if len(buckets) > 0 {
+		lines[0] += " (" + strings.Join(buckets, ", ") + ")"
+	}
+	return append(lines,
+		fmt.Sprintf("%d with a slice missing a test hint", st.WeakTests),
+		fmt.Sprintf("%d flagged as vague (see ralph validate)", st.Vague),
+	)
+}
+
+func (s *Story) hasWeakTests() bool {
+	if len(s.Slices) == 0 {
+		return true
+	}
+	for _, sl := range s.Slices {
+		if sl == nil || strings.TrimSpace(sl.RedHint) == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package prd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	p := &PRD{Stories: []*Story{
+		{ID: "story-1", Description: "Adds invites", Priority: 2, Slices: []*Slice{
+			{ID: "slice-1", Behavior: "POST /invites returns 201", RedHint: "POST test"},
+		}},
+		{ID: "story-2", Description: "Accepts invites", Priority: 1, Slices: []*Slice{
+			{ID: "slice-1", Behavior: "accepting marks the invite accepted"},
+		}},
+		{ID: "story-3", Priority: 2, Slices: []*Slice{
+			{ID: "slice-1", Behavior: "it works", RedHint: "smoke test"},
+		}},
+		nil,
+	}}
+
+	got := p.Stats()
+	want := Stats{
+		Stories:    3,
+		ByPriority: []PriorityCount{{Priority: 1, Stories: 1}, {Priority: 2, Stories: 2}},
+		WeakTests:  1,
+		Vague:      1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Stats() = %+v, want %+v", got, want)
+	}
+
+	wantLines := []string{
+		"3 stories (P1: 1, P2: 2)",
+		"1 with a slice missing a test hint",
+		"1 flagged as vague (see ralph validate)",
+	}
+	if lines := got.Lines(); !reflect.DeepEqual(lines, wantLines) {
+		t.Fatalf("Lines() = %q, want %q", lines, wantLines)
+	}
+}
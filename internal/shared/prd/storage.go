@@ -12,6 +12,19 @@ import (
 	"ralph/internal/shared/constants"
 )
 
+// ValidationError is returned by Load when the PRD file parses but breaks a
+// PRD rule, as opposed to being unreadable or not JSON.
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("PRD validation failed for %q: %v", e.Path, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
 // Load reads and parses the PRD under a shared lock.
 func Load(cfg *config.Config) (*PRD, error) {
 	prdPath := cfg.PRDPath()
@@ -28,7 +41,7 @@ func Load(cfg *config.Config) (*PRD, error) {
 	}
 
 	if err := rejectLegacyAcceptanceCriteriaInJSON(data); err != nil {
-		return nil, fmt.Errorf("PRD validation failed for %q: %w", prdPath, err)
+		return nil, &ValidationError{Path: prdPath, Err: err}
 	}
 
 	var p PRD
@@ -37,8 +50,9 @@ func Load(cfg *config.Config) (*PRD, error) {
 	}
 
 	if err := p.Validate(); err != nil {
-		return nil, fmt.Errorf("PRD validation failed for %q: %w", prdPath, err)
+		return nil, &ValidationError{Path: prdPath, Err: err}
 	}
+	p.SortStories()
 
 	return &p, nil
 }
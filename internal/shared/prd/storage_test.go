@@ -1,6 +1,7 @@
 package prd
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -396,6 +397,30 @@ func TestConcurrentWrites(t *testing.T) {
 	}
 }
 
+func TestLoadSortsStories(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := newTestConfig(t, tmpDir, "test.json")
+	data := `{"project_name":"P","stories":[` +
+		`{"id":"story-2","title":"T","description":"D","priority":1,"slices":[{"id":"s","behavior":"returns 201","red_hint":"r"}]},` +
+		`{"id":"story-3","title":"T","description":"D","priority":0,"slices":[{"id":"s","behavior":"returns 201","red_hint":"r"}]},` +
+		`{"id":"story-1","title":"T","description":"D","priority":1,"slices":[{"id":"s","behavior":"returns 201","red_hint":"r"}]}]}`
+	if err := os.WriteFile(cfg.PRDPath(), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	var ids []string
+	for _, story := range p.Stories {
+		ids = append(ids, story.ID)
+	}
+	if got := strings.Join(ids, ","); got != "story-3,story-1,story-2" {
+		t.Fatalf("loaded stories = %s, want story-3,story-1,story-2", got)
+	}
+}
+
 func TestLockTimeoutError(t *testing.T) {
 	err := &LockTimeoutError{
 		Path:    "/tmp/test.lock",
@@ -408,6 +433,29 @@ func TestLockTimeoutError(t *testing.T) {
 	}
 }
 
+func TestLockTimeoutUsesConfiguredWait(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := newTestConfig(t, tmpDir, "test.json")
+	cfg.LockTimeout = 50 * time.Millisecond
+	cfg.LockRetryDelay = 10 * time.Millisecond
+
+	held, err := acquireExclusiveLock(cfg)
+	if err != nil {
+		t.Fatalf("acquireExclusiveLock() error = %v", err)
+	}
+	defer held.Unlock()
+
+	started := time.Now()
+	_, err = Load(cfg)
+	var timeoutErr *LockTimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Timeout != cfg.LockTimeout {
+		t.Fatalf("Load() error = %v, want LockTimeoutError after %s", err, cfg.LockTimeout)
+	}
+	if waited := time.Since(started); waited > 5*time.Second {
+		t.Errorf("Load() waited %s for a busy lock, want about %s", waited, cfg.LockTimeout)
+	}
+}
+
 func TestVersionConflictError(t *testing.T) {
 	err := &VersionConflictError{
 		Expected: 5,
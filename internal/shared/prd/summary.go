@@ -0,0 +1,30 @@
+package prd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildSummary compiles a changelog-style summary of the PRD's stories and the
+// test command that gated them, so a finished PRD doubles as a change record.
+func (p *PRD) BuildSummary(testCommand string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Completed %d/%d stories.", p.CompletedCount(), len(p.Stories))
+	for _, story := range p.Stories {
+		if story == nil {
+			continue
+		}
+		progress := story.RunProgress()
+		status := "done"
+		if story.Skip && !story.Passes {
+			status = SkippedGlyph + " skipped"
+		} else if !story.Passes {
+			status = "incomplete"
+		}
+		fmt.Fprintf(&b, "\n- %s %s (%s, %d/%d slices)", story.ID, story.Title, status, progress.CompletedSlices, progress.TotalSlices)
+	}
+	if testCommand != "" {
+		fmt.Fprintf(&b, "\nTests: %s", testCommand)
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package prd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildSummary(t *testing.T) {
+	p := &PRD{Stories: []*Story{
+		{ID: "s1", Title: "Login", Slices: []*Slice{{ID: "a", Passes: true}, {ID: "b", Passes: true}}, Passes: true},
+		{ID: "s2", Title: "Logout", Slices: []*Slice{{ID: "a"}}},
+	}}
+
+	tests := []struct {
+		name        string
+		testCommand string
+		want        []string
+		notWant     []string
+	}{
+		{
+			name:        "with test command",
+			testCommand: "go test ./...",
+			want: []string{
+				"Completed 1/2 stories.",
+				"- s1 Login (done, 2/2 slices)",
+				"- s2 Logout (incomplete, 0/1 slices)",
+				"Tests: go test ./...",
+			},
+		},
+		{
+			name:    "without test command",
+			want:    []string{"Completed 1/2 stories."},
+			notWant: []string{"Tests:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.BuildSummary(tt.testCommand)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("summary missing %q:\n%s", w, got)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(got, w) {
+					t.Errorf("summary should not contain %q:\n%s", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSummaryRoundTrip(t *testing.T) {
+	cfg := newTestConfig(t, t.TempDir(), "prd.json")
+	p := &PRD{ProjectName: "P", Stories: []*Story{{ID: "s1", Title: "T", Description: "D", Slices: testSlice("b"), Priority: 1, Passes: true}}}
+	p.Summary = p.BuildSummary("make test")
+
+	if err := Save(cfg, p); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(cfg)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Summary != p.Summary {
+		t.Fatalf("Summary = %q, want %q", loaded.Summary, p.Summary)
+	}
+}
+
+func TestSummaryOmittedWhenEmpty(t *testing.T) {
+	data, err := json.Marshal(&PRD{ProjectName: "P"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "summary") {
+		t.Fatalf("empty summary should be omitted, got %s", data)
+	}
+}
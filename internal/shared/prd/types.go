@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 )
 
 const (
@@ -22,13 +24,19 @@ type Slice struct {
 }
 
 type Story struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Slices      []*Slice `json:"slices,omitempty"`
-	Priority    int      `json:"priority"`
-	DependsOn   []string `json:"depends_on,omitempty"` // Story IDs this story depends on
-	Passes      bool     `json:"passes"`
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Slices      []*Slice  `json:"slices,omitempty"`
+	Priority    int       `json:"priority"`
+	DependsOn   []string  `json:"depends_on,omitempty"` // Story IDs this story depends on
+	Passes      bool      `json:"passes"`
+	Skip        bool      `json:"skip,omitempty"`        // Set by --skip; counts as done without being implemented
+	StartedAt   time.Time `json:"started_at,omitzero"`   // First time the story was picked up
+	CompletedAt time.Time `json:"completed_at,omitzero"` // When the story's last slice passed
+	// Interruptions counts runs of the story cut short by cancelling the run
+	// (Ctrl+C, SIGTERM) before it finished.
+	Interruptions int `json:"interruptions,omitempty"`
 }
 
 type PRD struct {
@@ -39,26 +47,40 @@ type PRD struct {
 	TestSpec    string   `json:"test_spec,omitempty"`    // Holistic test spec covering all stories
 	TestCommand string   `json:"test_command,omitempty"` // Project-specific test command (overrides config)
 	Stories     []*Story `json:"stories"`
+	Summary     string   `json:"summary,omitempty"`    // Written on successful completion
+	Iterations  int      `json:"iterations,omitempty"` // Stories started across all runs, including retries
 }
 
 func (p *PRD) NextReadyStory() *Story {
-	ready := p.ReadyStories()
+	return p.NextReadyStoryExcept(nil)
+}
+
+// NextReadyStoryExcept is NextReadyStory ignoring the story IDs in skip.
+func (p *PRD) NextReadyStoryExcept(skip map[string]bool) *Story {
+	ready := p.readyStoriesExcept(skip)
 	if len(ready) == 0 {
 		return nil
 	}
-	sort.Slice(ready, func(i, j int) bool {
-		if ready[i].Priority != ready[j].Priority {
-			return ready[i].Priority < ready[j].Priority
-		}
-		return ready[i].ID < ready[j].ID
+	sort.SliceStable(ready, func(i, j int) bool {
+		return storyRunsBefore(ready[i], ready[j])
 	})
 	return ready[0]
 }
 
+func (p *PRD) readyStoriesExcept(skip map[string]bool) []*Story {
+	var ready []*Story
+	for _, story := range p.ReadyStories() {
+		if !skip[story.ID] {
+			ready = append(ready, story)
+		}
+	}
+	return ready
+}
+
 func (p *PRD) ReadyStories() []*Story {
 	var ready []*Story
 	for _, story := range p.Stories {
-		if story.Passes || !p.dependenciesSatisfied(story) {
+		if story.Done() || !p.dependenciesSatisfied(story) {
 			continue
 		}
 		ready = append(ready, story)
@@ -69,7 +91,7 @@ func (p *PRD) ReadyStories() []*Story {
 func (p *PRD) BlockedStories() []*Story {
 	var blocked []*Story
 	for _, story := range p.Stories {
-		if story.Passes || p.dependenciesSatisfied(story) {
+		if story.Done() || p.dependenciesSatisfied(story) {
 			continue
 		}
 		blocked = append(blocked, story)
@@ -77,6 +99,18 @@ func (p *PRD) BlockedStories() []*Story {
 	return blocked
 }
 
+// FailedStories are stories that were started but are not done: they failed,
+// were set aside by --best-effort, or were interrupted mid-run.
+func (p *PRD) FailedStories() []*Story {
+	var failed []*Story
+	for _, story := range p.Stories {
+		if !story.Done() && !story.StartedAt.IsZero() {
+			failed = append(failed, story)
+		}
+	}
+	return failed
+}
+
 func (p *PRD) ValidateDependencies() error {
 	visited := make(map[string]bool)
 	var dfs func(id string, path []string) error
@@ -106,6 +140,13 @@ func (p *PRD) ValidateDependencies() error {
 		return nil
 	}
 
+	for _, story := range p.Stories {
+		for _, depID := range story.DependsOn {
+			if depID != "" && p.GetStory(depID) == nil {
+				return fmt.Errorf("story %q depends on unknown story %q", story.ID, depID)
+			}
+		}
+	}
 	for _, story := range p.Stories {
 		if err := dfs(story.ID, nil); err != nil {
 			return err
@@ -117,13 +158,34 @@ func (p *PRD) ValidateDependencies() error {
 func (p *PRD) CompletedCount() int {
 	count := 0
 	for _, story := range p.Stories {
-		if story.Passes {
+		if story.Done() {
 			count++
 		}
 	}
 	return count
 }
 
+// Elapsed is the wall time from the first story start to the last story
+// completion, or zero when no story has both timestamps yet.
+func (p *PRD) Elapsed() time.Duration {
+	var first, last time.Time
+	for _, story := range p.Stories {
+		if story == nil {
+			continue
+		}
+		if !story.StartedAt.IsZero() && (first.IsZero() || story.StartedAt.Before(first)) {
+			first = story.StartedAt
+		}
+		if story.CompletedAt.After(last) {
+			last = story.CompletedAt
+		}
+	}
+	if first.IsZero() || last.Before(first) {
+		return 0
+	}
+	return last.Sub(first)
+}
+
 func (s *Story) CompletedSliceCount() int {
 	completed, _, _ := s.sliceProgress()
 	return completed
@@ -141,13 +203,35 @@ func (s *Story) NextPendingSlice() *Slice {
 
 func (p *PRD) AllCompleted() bool {
 	for _, story := range p.Stories {
-		if !story.Passes {
+		if !story.Done() {
 			return false
 		}
 	}
 	return true
 }
 
+// SkippedGlyph marks a skipped story wherever stories are listed: the CLI
+// story list, ralph status, the run summary and the TUI.
+const SkippedGlyph = "⊘"
+
+// Done reports whether the story needs no more work: it passed or was skipped.
+func (s *Story) Done() bool {
+	return s.Passes || s.Skip
+}
+
+// SkipStories marks the given story IDs as skipped. Unknown IDs are an error
+// so a typo does not silently leave the story in the run.
+func (p *PRD) SkipStories(ids []string) error {
+	for _, id := range ids {
+		story := p.GetStory(id)
+		if story == nil {
+			return fmt.Errorf("cannot skip unknown story %q", id)
+		}
+		story.Skip = true
+	}
+	return nil
+}
+
 func (p *PRD) GetStory(id string) *Story {
 	for _, story := range p.Stories {
 		if story.ID == id {
@@ -164,6 +248,10 @@ func (p *PRD) Validate() error {
 		return fmt.Errorf("story count %d exceeds maximum %d", len(p.Stories), MaxStories)
 	}
 
+	if dups := p.duplicateStoryIDs(); len(dups) > 0 {
+		return fmt.Errorf("duplicate story IDs: %s", strings.Join(dups, ", "))
+	}
+
 	seenIDs := make(map[string]bool)
 	for i, story := range p.Stories {
 		if err := story.Validate(seenIDs); err != nil {
@@ -177,6 +265,24 @@ func (p *PRD) Validate() error {
 	return nil
 }
 
+// duplicateStoryIDs lists each non-empty story ID that appears more than once,
+// in order of first appearance. GetStory returns the first match, so progress
+// written for a duplicate would land on the wrong story.
+func (p *PRD) duplicateStoryIDs() []string {
+	counts := make(map[string]int, len(p.Stories))
+	var dups []string
+	for _, story := range p.Stories {
+		if story == nil || story.ID == "" {
+			continue
+		}
+		counts[story.ID]++
+		if counts[story.ID] == 2 {
+			dups = append(dups, story.ID)
+		}
+	}
+	return dups
+}
+
 func errLegacyAcceptanceCriteria(storyID string) error {
 	if storyID == "" {
 		storyID = "<unknown>"
@@ -292,7 +398,7 @@ func (p *PRD) dependenciesSatisfied(story *Story) bool {
 func (p *PRD) storyPassMap() map[string]bool {
 	depMap := make(map[string]bool, len(p.Stories))
 	for _, s := range p.Stories {
-		depMap[s.ID] = s.Passes
+		depMap[s.ID] = s.Done()
 	}
 	return depMap
 }
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestStorySchemaOmitsAcceptanceCriteria(t *testing.T) {
@@ -29,6 +30,89 @@ func TestStorySchemaOmitsAcceptanceCriteria(t *testing.T) {
 	}
 }
 
+func TestStoryTimestampsAreOptionalInJSON(t *testing.T) {
+	data, err := json.Marshal(&PRD{ProjectName: "P", Stories: []*Story{{ID: "story-1", Title: "T"}}})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, key := range []string{"started_at", "completed_at", "iterations"} {
+		if strings.Contains(string(data), key) {
+			t.Errorf("marshaled PRD without timing contains %q: %s", key, data)
+		}
+	}
+
+	var legacy PRD
+	if err := json.Unmarshal([]byte(`{"project_name":"P","stories":[{"id":"story-1","title":"T","passes":true}]}`), &legacy); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if legacy.Iterations != 0 || !legacy.Stories[0].StartedAt.IsZero() || !legacy.Stories[0].CompletedAt.IsZero() {
+		t.Errorf("legacy PRD timing = %d %v %v, want zero values", legacy.Iterations, legacy.Stories[0].StartedAt, legacy.Stories[0].CompletedAt)
+	}
+}
+
+func TestSkippedStoriesCountAsDone(t *testing.T) {
+	p := &PRD{Stories: []*Story{
+		{ID: "story-1", Priority: 1, Skip: true},
+		{ID: "story-2", Priority: 2, DependsOn: []string{"story-1"}},
+	}}
+
+	if next := p.NextReadyStory(); next == nil || next.ID != "story-2" {
+		t.Fatalf("NextReadyStory() = %v, want story-2 with its skipped dependency satisfied", next)
+	}
+	if got := p.CompletedCount(); got != 1 {
+		t.Errorf("CompletedCount() = %d, want 1", got)
+	}
+	if p.AllCompleted() {
+		t.Error("AllCompleted() = true with story-2 pending")
+	}
+	p.Stories[1].Passes = true
+	if !p.AllCompleted() {
+		t.Error("AllCompleted() = false, want skipped and passed stories to finish the run")
+	}
+}
+
+func TestSkipStories(t *testing.T) {
+	p := &PRD{Stories: []*Story{{ID: "story-1"}, {ID: "story-2"}}}
+	if err := p.SkipStories([]string{"story-2"}); err != nil {
+		t.Fatalf("SkipStories() error = %v", err)
+	}
+	if p.Stories[0].Skip || !p.Stories[1].Skip {
+		t.Errorf("Skip = %v/%v, want only story-2 skipped", p.Stories[0].Skip, p.Stories[1].Skip)
+	}
+	if err := p.SkipStories([]string{"story-9"}); err == nil || !strings.Contains(err.Error(), `"story-9"`) {
+		t.Errorf("SkipStories(unknown) error = %v, want unknown story error", err)
+	}
+}
+
+func TestElapsed(t *testing.T) {
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		stories []*Story
+		want    time.Duration
+	}{
+		{name: "no timestamps", stories: []*Story{{ID: "1"}}, want: 0},
+		{name: "started only", stories: []*Story{{ID: "1", StartedAt: base}}, want: 0},
+		{
+			name: "first start to last completion",
+			stories: []*Story{
+				{ID: "1", StartedAt: base.Add(time.Minute), CompletedAt: base.Add(5 * time.Minute)},
+				{ID: "2", StartedAt: base, CompletedAt: base.Add(2 * time.Minute)},
+				{ID: "3", StartedAt: base.Add(6 * time.Minute)},
+			},
+			want: 5 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PRD{Stories: tt.stories}
+			if got := p.Elapsed(); got != tt.want {
+				t.Errorf("Elapsed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPRDOmitsNextPendingStory(t *testing.T) {
 	typ := reflect.TypeOf((*PRD)(nil))
 	if _, ok := typ.MethodByName("NextPendingStory"); ok {
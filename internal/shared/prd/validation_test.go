@@ -52,7 +52,45 @@ func TestPRD_Validate(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "duplicate story ID",
+			errMsg:  "duplicate story IDs: story-1",
+		},
+		{
+			name: "several duplicate story IDs",
+			prd: &PRD{
+				ProjectName: "Test Project",
+				Stories: []*Story{
+					{ID: "story-2", Title: "Story 1", Description: "Description", Priority: 1, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+					{ID: "story-1", Title: "Story 2", Description: "Description", Priority: 2, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+					{ID: "story-2", Title: "Story 3", Description: "Description", Priority: 3, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+					{ID: "story-1", Title: "Story 4", Description: "Description", Priority: 4, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+					{ID: "story-2", Title: "Story 5", Description: "Description", Priority: 5, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "duplicate story IDs: story-2, story-1",
+		},
+		{
+			name: "unknown dependency",
+			prd: &PRD{
+				ProjectName: "Test Project",
+				Stories: []*Story{
+					{ID: "story-1", Title: "Story 1", Priority: 1, DependsOn: []string{"story-9"}, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  `story "story-1" depends on unknown story "story-9"`,
+		},
+		{
+			name: "dependency cycle",
+			prd: &PRD{
+				ProjectName: "Test Project",
+				Stories: []*Story{
+					{ID: "story-1", Title: "Story 1", Priority: 1, DependsOn: []string{"story-2"}, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+					{ID: "story-2", Title: "Story 2", Priority: 2, DependsOn: []string{"story-1"}, Slices: []*Slice{{ID: "slice-1", Behavior: "works", RedHint: "add test"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "circular dependency detected",
 		},
 	}
 
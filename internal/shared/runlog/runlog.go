@@ -0,0 +1,120 @@
+// Package runlog tees a run's output to a plain-text log file in --output-dir
+// so every run leaves an audit trail beyond terminal scrollback.
+package runlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/workflow/events"
+)
+
+// Log appends run events to ralph-run-<timestamp>.log. A nil *Log ignores
+// every call, so callers can hold one unconditionally.
+type Log struct {
+	mu    sync.Mutex
+	f     *os.File
+	path  string
+	clock clock.Clock
+}
+
+// FileName is the log file name for a run started at t.
+func FileName(t time.Time) string {
+	return "ralph-run-" + t.Format("20060102-150405") + ".log"
+}
+
+// Open creates dir if needed and a new log file for a run starting now on clk,
+// which also timestamps every recorded line.
+func Open(dir string, clk clock.Clock) (*Log, error) {
+	now := clk.Now()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating output dir %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, FileName(now))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("creating run log %s: %w", path, err)
+	}
+	return &Log{f: f, path: path, clock: clk}, nil
+}
+
+// Path is the log file path.
+func (l *Log) Path() string {
+	if l == nil {
+		return ""
+	}
+	return l.path
+}
+
+// Record writes every output line, including verbose ones, plus markers for
+// story starts and completions and the final run status.
+func (l *Log) Record(ev events.Event) {
+	if l == nil {
+		return
+	}
+	var line string
+	switch e := ev.(type) {
+	case events.EventOutput:
+		line = e.Text
+		if e.IsErr {
+			line = "[stderr] " + line
+		}
+	case events.EventStoryStarted:
+		if e.Story != nil {
+			line = fmt.Sprintf("=== story %s started: %s", e.Story.ID, e.Story.Title)
+		}
+	case events.EventStoryCompleted:
+		if e.Story != nil {
+			line = fmt.Sprintf("=== story %s %s", e.Story.ID, storyResult(e))
+		}
+	case events.EventError:
+		line = fmt.Sprintf("=== run failed: %v", e.Err)
+	case events.EventCompleted:
+		line = "=== run completed"
+		if e.Partial() {
+			line += " with unfinished stories: " + strings.Join(e.Unfinished, ", ")
+		}
+	default:
+		return
+	}
+	if line == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	fmt.Fprintf(l.f, "%s %s\n", l.clock.Now().Format(time.RFC3339), strings.TrimRight(line, "\n"))
+}
+
+// Close closes the file; later Record calls are dropped.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+func storyResult(e events.EventStoryCompleted) string {
+	if e.Result != "" {
+		return string(e.Result)
+	}
+	if e.Success {
+		return string(events.StoryPassed)
+	}
+	return "failed"
+}
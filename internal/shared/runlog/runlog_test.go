@@ -0,0 +1,89 @@
+package runlog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/clock/clocktest"
+	"ralph/internal/shared/prd"
+	"ralph/internal/workflow/events"
+)
+
+func TestFileName(t *testing.T) {
+	got := FileName(time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC))
+	if got != "ralph-run-20260304-050607.log" {
+		t.Fatalf("FileName() = %q", got)
+	}
+}
+
+func TestRecordWritesOutputMarkersAndStatus(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	clk := clocktest.NewFake(time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC))
+	l, err := Open(dir, clk)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got, want := l.Path(), filepath.Join(dir, "ralph-run-20260304-050607.log"); got != want {
+		t.Fatalf("Path() = %q, want %q", got, want)
+	}
+	story := &prd.Story{ID: "story-1", Title: "Login"}
+	l.Record(events.EventStoryStarted{Story: story})
+	l.Record(events.EventOutput{Output: events.Output{Text: "writing handler", Verbose: true}})
+	l.Record(events.EventOutput{Output: events.Output{Text: "boom", IsErr: true}})
+	l.Record(events.EventStoryCompleted{Story: story, Success: true, Result: events.StoryPassed})
+	l.Record(events.EventPRDGenerating{})
+	l.Record(events.EventCompleted{Unfinished: []string{"story-2"}})
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	l.Record(events.EventOutput{Output: events.Output{Text: "after close"}})
+
+	data, err := os.ReadFile(l.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		"2026-03-04T05:06:07Z === story story-1 started: Login",
+		" writing handler\n",
+		"[stderr] boom",
+		"=== story story-1 passed",
+		"=== run completed with unfinished stories: story-2",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("log missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "after close") {
+		t.Fatalf("log recorded an event after Close:\n%s", got)
+	}
+	if n := strings.Count(got, "\n"); n != 5 {
+		t.Fatalf("log has %d lines, want 5:\n%s", n, got)
+	}
+}
+
+func TestRecordRunFailure(t *testing.T) {
+	l, err := Open(t.TempDir(), clock.Real{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Record(events.EventError{Err: errors.New("interrupted")})
+	_ = l.Close()
+	data, _ := os.ReadFile(l.Path())
+	if !strings.Contains(string(data), "=== run failed: interrupted") {
+		t.Fatalf("log = %q, want run failed status", data)
+	}
+}
+
+func TestNilLogIsNoop(t *testing.T) {
+	var l *Log
+	l.Record(events.EventOutput{Output: events.Output{Text: "x"}})
+	if err := l.Close(); err != nil || l.Path() != "" {
+		t.Fatalf("nil Log should ignore calls, got err=%v path=%q", err, l.Path())
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"strings"
 	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/logger"
 )
 
 type ClaudeRunner struct {
 	cfg     *config.Config
+	clock   clock.Clock
 	CmdFunc func(ctx context.Context, name string, args ...string) CmdInterface
+	version versionCache
 }
 
 var _ RunnerInterface = (*ClaudeRunner)(nil)
@@ -30,10 +33,15 @@ func (r *ClaudeRunner) IsInternalLog(line string) bool {
 	return stderrLineIsInternal(line, stderrFilterDefaultPipedCLI)
 }
 
-func NewClaude(cfg *config.Config) *ClaudeRunner {
+func (r *ClaudeRunner) Version(ctx context.Context) (string, error) {
+	return r.version.get(ctx, r.cfg.WorkDir, r.CommandName())
+}
+
+func NewClaude(cfg *config.Config, clk clock.Clock) *ClaudeRunner {
 	return &ClaudeRunner{
 		cfg:     cfg,
-		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir),
+		clock:   clk,
+		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir, cfg.RunnerEnv),
 	}
 }
 
@@ -53,13 +61,15 @@ func (r *ClaudeRunner) Run(ctx context.Context, prompt string, outputCh chan<- O
 		"work_dir", r.cfg.WorkDir)
 
 	if outputCh != nil {
-		outputCh <- newStartingOutputLine(r.RunnerName())
+		outputCh <- newStartingOutputLine(r.RunnerName(), r.clock.Now())
 	}
 
-	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh,
-		parseClaudeStreamJSON,
+	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh, r.cfg, r.clock,
 		func(line string) []OutputLine {
-			return []OutputLine{{Text: line, IsErr: true, Time: time.Now(), Verbose: r.IsInternalLog(line)}}
+			return parseClaudeStreamJSON(line, r.clock.Now())
+		},
+		func(line string) []OutputLine {
+			return []OutputLine{{Text: line, IsErr: true, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
 		},
 	)
 
@@ -90,15 +100,13 @@ type claudeStreamEvent struct {
 	Result string `json:"result,omitempty"`
 }
 
-func parseClaudeStreamJSON(line string) []OutputLine {
+func parseClaudeStreamJSON(line string, now time.Time) []OutputLine {
 	var event claudeStreamEvent
 	if err := json.Unmarshal([]byte(line), &event); err != nil {
-		return []OutputLine{{Text: line, Time: time.Now(), Verbose: true}}
+		return []OutputLine{{Text: line, Time: now, Verbose: true}}
 	}
 
 	var outputs []OutputLine
-	now := time.Now()
-
 	switch event.Type {
 	case "system":
 		if event.Subtype == "init" {
@@ -1,18 +1,21 @@
 package runner
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"strings"
 	"testing"
 	"time"
 
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/clock/clocktest"
 	"ralph/internal/shared/config"
 )
 
 func TestNewClaude(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	if r == nil {
 		t.Fatal("NewClaude() returned nil")
@@ -27,7 +30,7 @@ func TestNewClaude(t *testing.T) {
 
 func TestClaudeRunArgs(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	var capturedArgs []string
 	mock := &mockCmd{stdout: "output line", stderr: ""}
@@ -49,7 +52,7 @@ func TestClaudeRunArgs(t *testing.T) {
 
 func TestClaudeRunSupportsLargePrompts(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	prompt := strings.Repeat("implement feature ", 40000)
 	mock := &mockCmd{stdout: "output line", stderr: ""}
@@ -71,7 +74,7 @@ func TestClaudeRunSupportsLargePrompts(t *testing.T) {
 
 func TestClaudeRunWithOutputChannel(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	mock := &mockCmd{stdout: "line1\nline2", stderr: "err1"}
 	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
@@ -97,7 +100,7 @@ func TestClaudeRunWithOutputChannel(t *testing.T) {
 
 func TestClaudeRunStdoutError(t *testing.T) {
 	cfg := &config.Config{}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	mock := &mockCmd{stdoutErr: errors.New("stdout error")}
 	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
@@ -115,7 +118,7 @@ func TestClaudeRunStdoutError(t *testing.T) {
 
 func TestClaudeRunStderrError(t *testing.T) {
 	cfg := &config.Config{}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	mock := &mockCmd{stderrErr: errors.New("stderr error")}
 	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
@@ -133,7 +136,7 @@ func TestClaudeRunStderrError(t *testing.T) {
 
 func TestClaudeRunStartError(t *testing.T) {
 	cfg := &config.Config{}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	mock := &mockCmd{startErr: errors.New("start error")}
 	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
@@ -151,7 +154,7 @@ func TestClaudeRunStartError(t *testing.T) {
 
 func TestClaudeRunWaitError(t *testing.T) {
 	cfg := &config.Config{}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	mock := &mockCmd{waitErr: errors.New("wait error")}
 	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
@@ -181,7 +184,7 @@ func TestClaudeOutputLineVerboseField(t *testing.T) {
 
 func TestClaudeRunOutputTimestamps(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	mock := &mockCmd{stdout: "test output line", stderr: ""}
 	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
@@ -210,6 +213,104 @@ func TestClaudeRunOutputTimestamps(t *testing.T) {
 	}
 }
 
+func TestClaudeRunOutputUsesRunnerClock(t *testing.T) {
+	fixed := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := NewClaude(&config.Config{Runner: "claude"}, clocktest.NewFake(fixed))
+	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
+		return &mockCmd{stdout: "plain line", stderr: "err line"}
+	}
+
+	outputCh := make(chan OutputLine, 10)
+	if err := r.Run(context.Background(), "prompt", outputCh); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	close(outputCh)
+
+	for line := range outputCh {
+		if !line.Time.Equal(fixed) {
+			t.Errorf("line %q Time = %v, want %v", line.Text, line.Time, fixed)
+		}
+	}
+}
+
+func TestClaudeRunRawOutputPassesJSONThroughUnparsed(t *testing.T) {
+	const assistantLine = `{"type":"assistant","message":{"content":[{"type":"text","text":"hello"}]}}`
+
+	tests := []struct {
+		name       string
+		raw        bool
+		wantStdout string
+		wantTexts  []string
+	}{
+		{name: "default parses stream", raw: false, wantStdout: "", wantTexts: []string{"Starting Claude Code...", "hello"}},
+		{name: "raw output copies stream and still returns parsed lines", raw: true, wantStdout: assistantLine + "\n", wantTexts: []string{"Starting Claude Code...", "hello"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			prev := rawOutputWriter
+			rawOutputWriter = &stdout
+			t.Cleanup(func() { rawOutputWriter = prev })
+
+			r := NewClaude(&config.Config{Runner: "claude", RawOutput: tt.raw}, clock.Real{})
+			r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
+				return &mockCmd{stdout: assistantLine}
+			}
+
+			outputCh := make(chan OutputLine, 10)
+			if err := r.Run(context.Background(), "prompt", outputCh); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			close(outputCh)
+
+			var texts []string
+			for line := range outputCh {
+				texts = append(texts, line.Text)
+				if line.Text == "hello" && line.Raw != tt.raw {
+					t.Errorf("parsed line Raw = %v, want %v", line.Raw, tt.raw)
+				}
+			}
+			if strings.Join(texts, "|") != strings.Join(tt.wantTexts, "|") {
+				t.Errorf("output lines = %q, want %q", texts, tt.wantTexts)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("raw stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestClaudeRunDebugForwardsRawLinesWithStream(t *testing.T) {
+	const assistantLine = `{"type":"assistant","message":{"content":[{"type":"text","text":"hello"}]}}`
+	const internalLine = "Warning: telemetry disabled"
+
+	r := NewClaude(&config.Config{Runner: "claude", LogLevel: config.LogLevelDebug}, clock.Real{})
+	r.CmdFunc = func(ctx context.Context, name string, args ...string) CmdInterface {
+		return &mockCmd{stdout: assistantLine, stderr: internalLine}
+	}
+
+	outputCh := make(chan OutputLine, 10)
+	if err := r.Run(context.Background(), "prompt", outputCh); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	close(outputCh)
+
+	got := make(map[string]OutputLine)
+	for line := range outputCh {
+		got[line.Text] = line
+	}
+	if line, ok := got[assistantLine]; !ok || line.IsErr || line.Verbose {
+		t.Errorf("stdout line = %+v (found %v), want unparsed, IsErr=false, not verbose", line, ok)
+	}
+	if line, ok := got[internalLine]; !ok || !line.IsErr || line.Verbose {
+		t.Errorf("stderr line = %+v (found %v), want IsErr=true and not filtered as verbose", line, ok)
+	}
+	if _, ok := got["hello"]; ok {
+		t.Error("debug output should not include the parsed assistant text")
+	}
+}
+
 func TestParseClaudeStreamJSON(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -294,10 +395,10 @@ func TestParseClaudeStreamJSON(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			outputs := parseClaudeStreamJSON(tt.input)
+			outputs := parseClaudeStreamJSON(tt.input, time.Now())
 
 			if len(outputs) != tt.wantLen {
-				t.Errorf("parseClaudeStreamJSON() returned %d outputs, want %d", len(outputs), tt.wantLen)
+				t.Errorf("parseClaudeStreamJSON(, time.Now()) returned %d outputs, want %d", len(outputs), tt.wantLen)
 				return
 			}
 
@@ -318,7 +419,7 @@ func TestParseClaudeStreamJSON(t *testing.T) {
 
 func TestParseClaudeStreamJSONTimestamps(t *testing.T) {
 	before := time.Now()
-	outputs := parseClaudeStreamJSON(`{"type":"assistant","message":{"content":[{"type":"text","text":"test"}]}}`)
+	outputs := parseClaudeStreamJSON(`{"type":"assistant","message":{"content":[{"type":"text","text":"test"}]}}`, time.Now())
 	after := time.Now()
 
 	if len(outputs) != 1 {
@@ -332,7 +433,7 @@ func TestParseClaudeStreamJSONTimestamps(t *testing.T) {
 
 func TestClaudeRunnerIsInternalLog(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	r := NewClaude(cfg)
+	r := NewClaude(cfg, clock.Real{})
 
 	tests := []struct {
 		name string
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/constants"
 	"ralph/internal/shared/logger"
@@ -14,15 +15,18 @@ import (
 
 type CopilotRunner struct {
 	cfg     *config.Config
+	clock   clock.Clock
 	CmdFunc func(ctx context.Context, name string, args ...string) CmdInterface
+	version versionCache
 }
 
 var _ RunnerInterface = (*CopilotRunner)(nil)
 
-func NewCopilot(cfg *config.Config) *CopilotRunner {
+func NewCopilot(cfg *config.Config, clk clock.Clock) *CopilotRunner {
 	return &CopilotRunner{
 		cfg:     cfg,
-		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir),
+		clock:   clk,
+		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir, cfg.RunnerEnv),
 	}
 }
 
@@ -38,6 +42,10 @@ func (r *CopilotRunner) IsInternalLog(line string) bool {
 	return stderrLineIsInternal(line, stderrFilterDefaultPipedCLI)
 }
 
+func (r *CopilotRunner) Version(ctx context.Context) (string, error) {
+	return r.version.get(ctx, r.cfg.WorkDir, r.CommandName())
+}
+
 func (r *CopilotRunner) Run(ctx context.Context, prompt string, outputCh chan<- OutputLine) error {
 	args := []string{
 		"--allow-all-tools",
@@ -56,13 +64,15 @@ func (r *CopilotRunner) Run(ctx context.Context, prompt string, outputCh chan<-
 		"work_dir", r.cfg.WorkDir)
 
 	if outputCh != nil {
-		outputCh <- newStartingOutputLine(r.RunnerName())
+		outputCh <- newStartingOutputLine(r.RunnerName(), r.clock.Now())
 	}
 
-	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh,
-		parseCopilotJSONL,
+	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh, r.cfg, r.clock,
 		func(line string) []OutputLine {
-			return []OutputLine{{Text: line, IsErr: true, Time: time.Now(), Verbose: r.IsInternalLog(line)}}
+			return parseCopilotJSONL(line, r.clock.Now())
+		},
+		func(line string) []OutputLine {
+			return []OutputLine{{Text: line, IsErr: true, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
 		},
 	)
 
@@ -81,7 +91,7 @@ func (r *CopilotRunner) Run(ctx context.Context, prompt string, outputCh chan<-
 	return nil
 }
 
-func parseCopilotJSONL(line string) []OutputLine {
+func parseCopilotJSONL(line string, now time.Time) []OutputLine {
 	var event struct {
 		Type     string `json:"type"`
 		ExitCode int    `json:"exitCode"`
@@ -95,11 +105,9 @@ func parseCopilotJSONL(line string) []OutputLine {
 		} `json:"data"`
 	}
 	if err := json.Unmarshal([]byte(line), &event); err != nil {
-		return []OutputLine{{Text: line, Time: time.Now(), Verbose: true}}
+		return []OutputLine{{Text: line, Time: now, Verbose: true}}
 	}
 
-	now := time.Now()
-
 	switch event.Type {
 	case "assistant.message_delta":
 		if event.Data.DeltaContent != "" {
@@ -4,13 +4,15 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 )
 
 func TestNewCopilot(t *testing.T) {
 	cfg := &config.Config{Runner: "copilot"}
-	r := NewCopilot(cfg)
+	r := NewCopilot(cfg, clock.Real{})
 
 	if r == nil {
 		t.Fatal("NewCopilot() returned nil")
@@ -22,7 +24,7 @@ func TestNewCopilot(t *testing.T) {
 
 func TestCopilotRunnerNames(t *testing.T) {
 	cfg := &config.Config{Runner: "copilot"}
-	r := NewCopilot(cfg)
+	r := NewCopilot(cfg, clock.Real{})
 
 	if r.RunnerName() != "copilot" {
 		t.Errorf("RunnerName() = %q, want %q", r.RunnerName(), "copilot")
@@ -34,7 +36,7 @@ func TestCopilotRunnerNames(t *testing.T) {
 
 func TestCopilotRunnerIsInternalLog(t *testing.T) {
 	cfg := &config.Config{Runner: "copilot"}
-	r := NewCopilot(cfg)
+	r := NewCopilot(cfg, clock.Real{})
 
 	tests := []struct {
 		line string
@@ -56,7 +58,7 @@ func TestCopilotRunnerIsInternalLog(t *testing.T) {
 
 func TestCopilotRunnerRunArgs(t *testing.T) {
 	cfg := &config.Config{Runner: "copilot"}
-	r := NewCopilot(cfg)
+	r := NewCopilot(cfg, clock.Real{})
 
 	var capturedName string
 	var capturedArgs []string
@@ -90,7 +92,7 @@ func TestCopilotRunnerRunArgs(t *testing.T) {
 
 func TestCopilotRunnerSupportsLargePrompts(t *testing.T) {
 	cfg := &config.Config{Runner: "copilot"}
-	r := NewCopilot(cfg)
+	r := NewCopilot(cfg, clock.Real{})
 
 	prompt := strings.Repeat("implement feature ", 40000)
 	mock := &mockCmd{stdout: "", stderr: ""}
@@ -112,7 +114,7 @@ func TestCopilotRunnerSupportsLargePrompts(t *testing.T) {
 
 func TestParseCopilotJSONL_MessageDelta(t *testing.T) {
 	line := `{"type":"assistant.message_delta","data":{"deltaContent":"hello"}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -132,7 +134,7 @@ func TestParseCopilotJSONL_MessageDelta(t *testing.T) {
 
 func TestParseCopilotJSONL_ToolExecutionStart(t *testing.T) {
 	line := `{"type":"tool.execution_start","data":{"toolName":"bash"}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -149,7 +151,7 @@ func TestParseCopilotJSONL_ToolExecutionStart(t *testing.T) {
 
 func TestParseCopilotJSONL_SessionError(t *testing.T) {
 	line := `{"type":"session.error","data":{"message":"auth failed"}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -166,7 +168,7 @@ func TestParseCopilotJSONL_SessionError(t *testing.T) {
 
 func TestParseCopilotJSONL_ModelCallFailure(t *testing.T) {
 	line := `{"type":"model.call_failure","data":{"errorMessage":"rate limited"}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -182,7 +184,7 @@ func TestParseCopilotJSONL_ModelCallFailure(t *testing.T) {
 }
 
 func TestParseCopilotJSONL_MalformedJSON(t *testing.T) {
-	lines := parseCopilotJSONL("not json at all")
+	lines := parseCopilotJSONL("not json at all", time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -193,7 +195,7 @@ func TestParseCopilotJSONL_MalformedJSON(t *testing.T) {
 
 func TestParseCopilotJSONL_SessionMCPServersLoaded(t *testing.T) {
 	line := `{"type":"session.mcp_servers_loaded","data":{}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -207,7 +209,7 @@ func TestParseCopilotJSONL_SessionMCPServersLoaded(t *testing.T) {
 
 func TestParseCopilotJSONL_AssistantTurnStart(t *testing.T) {
 	line := `{"type":"assistant.turn_start","data":{}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -218,7 +220,7 @@ func TestParseCopilotJSONL_AssistantTurnStart(t *testing.T) {
 
 func TestParseCopilotJSONL_ToolExecutionComplete(t *testing.T) {
 	line := `{"type":"tool.execution_complete","data":{"toolName":"bash"}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -229,7 +231,7 @@ func TestParseCopilotJSONL_ToolExecutionComplete(t *testing.T) {
 
 func TestParseCopilotJSONL_Result(t *testing.T) {
 	line := `{"type":"result","exitCode":0,"sessionId":"abc"}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -243,7 +245,7 @@ func TestParseCopilotJSONL_Result(t *testing.T) {
 
 func TestParseCopilotJSONL_ResultFailure(t *testing.T) {
 	line := `{"type":"result","exitCode":1,"sessionId":"abc"}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -254,7 +256,7 @@ func TestParseCopilotJSONL_ResultFailure(t *testing.T) {
 
 func TestParseCopilotJSONL_ResultNestedExitCodeFallback(t *testing.T) {
 	line := `{"type":"result","data":{"exitCode":2}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -265,7 +267,7 @@ func TestParseCopilotJSONL_ResultNestedExitCodeFallback(t *testing.T) {
 
 func TestParseCopilotJSONL_AssistantMessageVerbose(t *testing.T) {
 	line := `{"type":"assistant.message","data":{"content":"hello from copilot"}}`
-	lines := parseCopilotJSONL(line)
+	lines := parseCopilotJSONL(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -293,7 +295,7 @@ func TestCopilotResultExitCode(t *testing.T) {
 }
 
 func TestParseCopilotJSONL_UnknownEvent(t *testing.T) {
-	lines := parseCopilotJSONL(`{"type":"unknown_event","data":{}}`)
+	lines := parseCopilotJSONL(`{"type":"unknown_event","data":{}}`, time.Now())
 	if lines != nil {
 		t.Errorf("unknown event type should return nil, got %v", lines)
 	}
@@ -7,21 +7,25 @@ import (
 	"strings"
 	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/logger"
 )
 
 type CursorAgentRunner struct {
 	cfg     *config.Config
+	clock   clock.Clock
 	CmdFunc func(ctx context.Context, name string, args ...string) CmdInterface
+	version versionCache
 }
 
 var _ RunnerInterface = (*CursorAgentRunner)(nil)
 
-func NewCursorAgent(cfg *config.Config) *CursorAgentRunner {
+func NewCursorAgent(cfg *config.Config, clk clock.Clock) *CursorAgentRunner {
 	return &CursorAgentRunner{
 		cfg:     cfg,
-		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir),
+		clock:   clk,
+		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir, cfg.RunnerEnv),
 	}
 }
 
@@ -37,6 +41,10 @@ func (r *CursorAgentRunner) IsInternalLog(line string) bool {
 	return stderrLineIsInternal(line, stderrFilterDefaultPipedCLI)
 }
 
+func (r *CursorAgentRunner) Version(ctx context.Context) (string, error) {
+	return r.version.get(ctx, r.cfg.WorkDir, r.CommandName())
+}
+
 func (r *CursorAgentRunner) Run(ctx context.Context, prompt string, outputCh chan<- OutputLine) error {
 	args := []string{"--print", "--output-format", "stream-json", "--trust", "--yolo"}
 
@@ -48,13 +56,15 @@ func (r *CursorAgentRunner) Run(ctx context.Context, prompt string, outputCh cha
 		"work_dir", r.cfg.WorkDir)
 
 	if outputCh != nil {
-		outputCh <- newStartingOutputLine(r.RunnerName())
+		outputCh <- newStartingOutputLine(r.RunnerName(), r.clock.Now())
 	}
 
-	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh,
-		parseCursorStreamJSON,
+	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh, r.cfg, r.clock,
 		func(line string) []OutputLine {
-			return []OutputLine{{Text: line, IsErr: true, Time: time.Now(), Verbose: r.IsInternalLog(line)}}
+			return parseCursorStreamJSON(line, r.clock.Now())
+		},
+		func(line string) []OutputLine {
+			return []OutputLine{{Text: line, IsErr: true, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
 		},
 	)
 
@@ -74,15 +84,13 @@ func (r *CursorAgentRunner) Run(ctx context.Context, prompt string, outputCh cha
 	return nil
 }
 
-func parseCursorStreamJSON(line string) []OutputLine {
+func parseCursorStreamJSON(line string, now time.Time) []OutputLine {
 	var event claudeStreamEvent
 	if err := json.Unmarshal([]byte(line), &event); err != nil {
-		return []OutputLine{{Text: line, Time: time.Now(), Verbose: true}}
+		return []OutputLine{{Text: line, Time: now, Verbose: true}}
 	}
 
 	var outputs []OutputLine
-	now := time.Now()
-
 	switch event.Type {
 	case "assistant":
 		for _, content := range event.Message.Content {
@@ -4,13 +4,15 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 )
 
 func TestNewCursorAgent(t *testing.T) {
 	cfg := &config.Config{Runner: "cursor"}
-	r := NewCursorAgent(cfg)
+	r := NewCursorAgent(cfg, clock.Real{})
 
 	if r == nil {
 		t.Fatal("NewCursorAgent() returned nil")
@@ -22,7 +24,7 @@ func TestNewCursorAgent(t *testing.T) {
 
 func TestCursorAgentIsInternalLog(t *testing.T) {
 	cfg := &config.Config{Runner: "cursor"}
-	r := NewCursorAgent(cfg)
+	r := NewCursorAgent(cfg, clock.Real{})
 
 	tests := []struct {
 		line string
@@ -44,7 +46,7 @@ func TestCursorAgentIsInternalLog(t *testing.T) {
 
 func TestParseCursorStreamJSON_AssistantText(t *testing.T) {
 	line := `{"type":"assistant","message":{"content":[{"type":"text","text":"hello world"}]}}`
-	lines := parseCursorStreamJSON(line)
+	lines := parseCursorStreamJSON(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -58,7 +60,7 @@ func TestParseCursorStreamJSON_AssistantText(t *testing.T) {
 
 func TestParseCursorStreamJSON_ToolUse(t *testing.T) {
 	line := `{"type":"assistant","message":{"content":[{"type":"tool_use","name":"bash"}]}}`
-	lines := parseCursorStreamJSON(line)
+	lines := parseCursorStreamJSON(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -69,7 +71,7 @@ func TestParseCursorStreamJSON_ToolUse(t *testing.T) {
 
 func TestParseCursorStreamJSON_ResultSuccess(t *testing.T) {
 	line := `{"type":"result","subtype":"success"}`
-	lines := parseCursorStreamJSON(line)
+	lines := parseCursorStreamJSON(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -83,7 +85,7 @@ func TestParseCursorStreamJSON_ResultSuccess(t *testing.T) {
 
 func TestParseCursorStreamJSON_ResultError(t *testing.T) {
 	line := `{"type":"result","subtype":"error"}`
-	lines := parseCursorStreamJSON(line)
+	lines := parseCursorStreamJSON(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -93,14 +95,14 @@ func TestParseCursorStreamJSON_ResultError(t *testing.T) {
 }
 
 func TestParseCursorStreamJSON_UnknownType(t *testing.T) {
-	lines := parseCursorStreamJSON(`{"type":"unknown_event"}`)
+	lines := parseCursorStreamJSON(`{"type":"unknown_event"}`, time.Now())
 	if lines != nil {
 		t.Errorf("unknown event type should return nil, got %v", lines)
 	}
 }
 
 func TestParseCursorStreamJSON_MalformedJSON(t *testing.T) {
-	lines := parseCursorStreamJSON("not json at all")
+	lines := parseCursorStreamJSON("not json at all", time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
@@ -111,7 +113,7 @@ func TestParseCursorStreamJSON_MalformedJSON(t *testing.T) {
 
 func TestCursorAgentRunArgs(t *testing.T) {
 	cfg := &config.Config{Runner: "cursor"}
-	r := NewCursorAgent(cfg)
+	r := NewCursorAgent(cfg, clock.Real{})
 
 	var capturedArgs []string
 	mock := &mockCmd{stdout: "", stderr: ""}
@@ -132,7 +134,7 @@ func TestCursorAgentRunArgs(t *testing.T) {
 
 func TestCursorAgentSupportsLargePrompts(t *testing.T) {
 	cfg := &config.Config{Runner: "cursor"}
-	r := NewCursorAgent(cfg)
+	r := NewCursorAgent(cfg, clock.Real{})
 
 	prompt := strings.Repeat("implement feature ", 40000)
 	mock := &mockCmd{stdout: "", stderr: ""}
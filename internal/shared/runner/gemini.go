@@ -0,0 +1,150 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/logger"
+)
+
+// GeminiRunner runs Google's Gemini CLI headless with
+// `gemini --model <model> --yolo --output-format stream-json`, reading the
+// prompt from stdin.
+type GeminiRunner struct {
+	cfg     *config.Config
+	clock   clock.Clock
+	model   string
+	CmdFunc func(ctx context.Context, name string, args ...string) CmdInterface
+	version versionCache
+}
+
+var _ RunnerInterface = (*GeminiRunner)(nil)
+
+func NewGemini(cfg *config.Config, clk clock.Clock) *GeminiRunner {
+	return &GeminiRunner{
+		cfg:     cfg,
+		clock:   clk,
+		model:   config.GeminiModel(cfg.Runner),
+		CmdFunc: defaultCmdFunc(cfg.WorkDir, cfg.RunnerEnv),
+	}
+}
+
+func (r *GeminiRunner) RunnerName() string {
+	return "gemini"
+}
+
+func (r *GeminiRunner) CommandName() string {
+	return "gemini"
+}
+
+// geminiStartupNoise is what the Gemini CLI prints before it starts working,
+// including lines that would otherwise look like user-facing errors.
+var geminiStartupNoise = []string{
+	"Loaded cached credentials",
+	"Data collection is disabled",
+	"YOLO mode is enabled",
+	"Flushing log events",
+	"Error flushing log events",
+	"[STARTUP]",
+}
+
+func (r *GeminiRunner) IsInternalLog(line string) bool {
+	for _, noise := range geminiStartupNoise {
+		if strings.Contains(line, noise) {
+			return true
+		}
+	}
+	return stderrLineIsInternal(line, stderrFilterDefaultPipedCLI)
+}
+
+func (r *GeminiRunner) Version(ctx context.Context) (string, error) {
+	return r.version.get(ctx, r.cfg.WorkDir, r.CommandName())
+}
+
+func (r *GeminiRunner) Run(ctx context.Context, prompt string, outputCh chan<- OutputLine) error {
+	args := []string{"--model", r.model, "--yolo", "--output-format", "stream-json"}
+
+	logger.Debug("invoking AI runner",
+		"runner", r.RunnerName(),
+		"command", r.CommandName(),
+		"model", r.model,
+		"prompt_length", len(prompt),
+		"work_dir", r.cfg.WorkDir)
+
+	if outputCh != nil {
+		outputCh <- newStartingOutputLine(r.RunnerName(), r.clock.Now())
+	}
+
+	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh, r.cfg, r.clock,
+		func(line string) []OutputLine {
+			return parseGeminiStreamJSON(line, r.clock.Now(), r.IsInternalLog)
+		},
+		func(line string) []OutputLine {
+			return []OutputLine{{Text: line, IsErr: true, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
+		},
+	)
+
+	if err != nil {
+		logger.Debug("AI runner exited with code",
+			"runner", r.RunnerName(),
+			"command", r.CommandName(),
+			"exit_code", exitCode(err),
+			"error", err)
+		return wrapRunnerError(r.RunnerName(), err)
+	}
+
+	logger.Debug("AI runner completed successfully",
+		"runner", r.RunnerName(),
+		"command", r.CommandName(),
+		"model", r.model)
+	return nil
+}
+
+type geminiStreamEvent struct {
+	Type     string `json:"type"`
+	Role     string `json:"role"`
+	Content  string `json:"content"`
+	Delta    bool   `json:"delta"`
+	ToolName string `json:"tool_name"`
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	Error    *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// parseGeminiStreamJSON turns one stream-json event into output lines.
+// Anything that is not JSON is startup chatter; isInternal decides whether it
+// is hidden unless verbose.
+func parseGeminiStreamJSON(line string, now time.Time, isInternal func(string) bool) []OutputLine {
+	var event geminiStreamEvent
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		return []OutputLine{{Text: line, Time: now, Verbose: isInternal(line)}}
+	}
+
+	switch event.Type {
+	case "message":
+		if event.Role == "assistant" && event.Content != "" {
+			return []OutputLine{{Text: event.Content, Time: now, Append: event.Delta}}
+		}
+	case "tool_use":
+		return []OutputLine{{Text: fmt.Sprintf("Using tool: %s", event.ToolName), Time: now}}
+	case "error":
+		return []OutputLine{{Text: event.Message, Time: now, IsErr: true}}
+	case "result":
+		if event.Status == "success" {
+			return []OutputLine{{Text: "Task completed successfully", Time: now, Verbose: true}}
+		}
+		text := "Task failed"
+		if event.Error != nil && event.Error.Message != "" {
+			text += ": " + event.Error.Message
+		}
+		return []OutputLine{{Text: text, Time: now, IsErr: true}}
+	}
+	return nil
+}
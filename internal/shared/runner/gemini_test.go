@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+)
+
+func TestNewReturnsGeminiRunner(t *testing.T) {
+	r := assertRunnerIs[*GeminiRunner](t, New(&config.Config{Runner: "gemini/gemini-2.5-pro"}, clock.Real{}))
+
+	if r.RunnerName() != "gemini" || r.CommandName() != "gemini" {
+		t.Errorf("RunnerName/CommandName = %q/%q, want gemini/gemini", r.RunnerName(), r.CommandName())
+	}
+	if r.model != "gemini-2.5-pro" {
+		t.Errorf("model = %q, want gemini-2.5-pro", r.model)
+	}
+}
+
+func TestGeminiRunArgsAndStream(t *testing.T) {
+	r := NewGemini(&config.Config{Runner: "gemini/gemini-2.5-flash"}, clock.Real{})
+
+	var name string
+	var args []string
+	mock := &mockCmd{
+		stdout: "Loaded cached credentials.\n" +
+			`{"type":"init","session_id":"s1","model":"gemini-2.5-flash"}` + "\n" +
+			`{"type":"message","role":"user","content":"test prompt"}` + "\n" +
+			`{"type":"message","role":"assistant","content":"Adding ","delta":true}` + "\n" +
+			`{"type":"tool_use","tool_name":"write_file","tool_id":"t1"}` + "\n" +
+			`{"type":"result","status":"success"}`,
+	}
+	r.CmdFunc = stubCmdFunc(mock, &name, &args)
+
+	outputCh := make(chan OutputLine, 10)
+	if err := r.Run(context.Background(), "test prompt", outputCh); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	close(outputCh)
+
+	if name != "gemini" {
+		t.Fatalf("command = %q, want gemini", name)
+	}
+	assertArgsEqual(t, args, []string{"--model", "gemini-2.5-flash", "--yolo", "--output-format", "stream-json"})
+	assertPromptDeliveredViaStdin(t, mock, "test prompt")
+
+	var visible []OutputLine
+	for line := range outputCh {
+		if !line.Verbose {
+			visible = append(visible, line)
+		}
+	}
+	want := []string{"Starting gemini...", "Adding ", "Using tool: write_file"}
+	if len(visible) != len(want) {
+		t.Fatalf("visible lines = %+v, want %q", visible, want)
+	}
+	for i, w := range want {
+		if visible[i].Text != w {
+			t.Errorf("visible[%d] = %q, want %q", i, visible[i].Text, w)
+		}
+	}
+	if !visible[1].Append {
+		t.Error("assistant delta should stream with Append")
+	}
+}
+
+func TestParseGeminiStreamJSONFailures(t *testing.T) {
+	r := NewGemini(&config.Config{Runner: "gemini/gemini-2.5-pro"}, clock.Real{})
+	tests := []struct {
+		line string
+		want string
+	}{
+		{`{"type":"error","severity":"error","message":"quota exceeded"}`, "quota exceeded"},
+		{`{"type":"result","status":"error","error":{"type":"FatalError","message":"auth required"}}`, "Task failed: auth required"},
+	}
+	for _, tt := range tests {
+		lines := parseGeminiStreamJSON(tt.line, time.Now(), r.IsInternalLog)
+		if len(lines) != 1 || lines[0].Text != tt.want || !lines[0].IsErr {
+			t.Errorf("parseGeminiStreamJSON(%s) = %+v, want one error line %q", tt.line, lines, tt.want)
+		}
+	}
+}
+
+func TestGeminiIsInternalLog(t *testing.T) {
+	r := NewGemini(&config.Config{Runner: "gemini/gemini-2.5-pro"}, clock.Real{})
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"Loaded cached credentials.", true},
+		{"Error flushing log events: timeout", true},
+		{"[STARTUP] Recording metric", true},
+		{"error: GEMINI_API_KEY not set", false},
+	}
+	for _, tt := range tests {
+		if got := r.IsInternalLog(tt.line); got != tt.want {
+			t.Errorf("IsInternalLog(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// CheckInstalled reports a missing runner binary up front, so a run fails
+// with an actionable message instead of a start error deep in a phase.
+// Test doubles that do not shell out are never checked.
+func CheckInstalled(r RunnerInterface) error {
+	switch r.(type) {
+	case *Runner, *ClaudeRunner, *CopilotRunner, *CursorAgentRunner, *GeminiRunner, *OllamaRunner, *PiRunner:
+	default:
+		return nil
+	}
+	command := r.CommandName()
+	if _, err := exec.LookPath(command); err != nil {
+		return fmt.Errorf("%s not found in PATH: install it or set RALPH_RUNNER to an available runner (see ralph runners)", command)
+	}
+	return nil
+}
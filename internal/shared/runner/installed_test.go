@@ -0,0 +1,32 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+)
+
+func TestCheckInstalled(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	if err := CheckInstalled(NewClaude(config.DefaultConfig(), clock.Real{})); err != nil {
+		t.Fatalf("CheckInstalled(claude) error = %v, want nil with claude on PATH", err)
+	}
+
+	err := CheckInstalled(New(&config.Config{Runner: "opencode"}, clock.Real{}))
+	if err == nil || !strings.Contains(err.Error(), "opencode not found in PATH") || !strings.Contains(err.Error(), "RALPH_RUNNER") {
+		t.Fatalf("CheckInstalled(opencode) error = %v, want actionable not-found error", err)
+	}
+
+	if err := CheckInstalled(NewMock(config.DefaultConfig(), clock.Real{})); err != nil {
+		t.Fatalf("CheckInstalled(mock) error = %v, want nil for a runner without a binary", err)
+	}
+}
@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/logger"
+)
+
+// requestLimiter spaces runner starts evenly so a backend sees at most
+// RALPH_REQUESTS_PER_MINUTE sessions per minute. It is a token bucket holding
+// one token: a start waits until interval has passed since the previous one.
+type requestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = map[string]*requestLimiter{}
+)
+
+// limiterFor returns the limiter shared by every runner for backend, so
+// concurrent stories and retries draw from the same budget.
+func limiterFor(backend string, perMinute int) *requestLimiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	l, ok := limiters[backend]
+	if !ok {
+		l = &requestLimiter{}
+		limiters[backend] = l
+	}
+	l.mu.Lock()
+	l.interval = time.Minute / time.Duration(perMinute)
+	l.mu.Unlock()
+	return l
+}
+
+// waitForRequestSlot blocks until backend may start another session under
+// perMinute, or ctx is done, reading the time from clk. A perMinute of 0 never
+// waits.
+func waitForRequestSlot(ctx context.Context, clk clock.Clock, backend string, perMinute int) error {
+	if perMinute <= 0 {
+		return nil
+	}
+	return limiterFor(backend, perMinute).wait(ctx, clk, backend)
+}
+
+func (l *requestLimiter) wait(ctx context.Context, clk clock.Clock, backend string) error {
+	l.mu.Lock()
+	now := clk.Now()
+	start := now
+	if l.next.After(now) {
+		start = l.next
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	logger.Debug("waiting for runner request slot", "backend", backend, "delay", delay)
+	select {
+	case <-clk.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
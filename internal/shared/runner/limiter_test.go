@@ -0,0 +1,91 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/clock/clocktest"
+	"ralph/internal/shared/config"
+)
+
+func TestRequestsPerMinuteSpacesCommandStartsAcrossRunners(t *testing.T) {
+	cfg := &config.Config{Runner: "claude", RequestsPerMinute: 600}
+	interval := time.Minute / 600
+
+	var mu sync.Mutex
+	var starts []time.Time
+	newRunner := func() *ClaudeRunner {
+		r := NewClaude(cfg, clock.Real{})
+		r.CmdFunc = func(context.Context, string, ...string) CmdInterface {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+			return &mockCmd{stdout: "ok"}
+		}
+		return r
+	}
+
+	var wg sync.WaitGroup
+	for _, r := range []*ClaudeRunner{newRunner(), newRunner(), newRunner()} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.Run(context.Background(), "prompt", nil); err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(starts) != 3 {
+		t.Fatalf("command starts = %d, want 3", len(starts))
+	}
+	slices.SortFunc(starts, time.Time.Compare)
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i].Sub(starts[i-1]); gap < interval-10*time.Millisecond {
+			t.Errorf("start %d came %v after the previous one, want at least %v", i, gap, interval)
+		}
+	}
+}
+
+func TestRequestSlotWaitRespectsContext(t *testing.T) {
+	if err := waitForRequestSlot(context.Background(), clock.Real{}, "limiter-ctx-test", 1); err != nil {
+		t.Fatalf("first slot error = %v, want immediate slot", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	err := waitForRequestSlot(ctx, clock.Real{}, "limiter-ctx-test", 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second slot error = %v, want context deadline", err)
+	}
+	if waited := time.Since(started); waited > time.Second {
+		t.Errorf("waited %v for a canceled context, want prompt return", waited)
+	}
+}
+
+func TestRequestSlotUnlimitedNeverWaits(t *testing.T) {
+	for range 100 {
+		if err := waitForRequestSlot(context.Background(), clock.Real{}, "limiter-off-test", 0); err != nil {
+			t.Fatalf("waitForRequestSlot() error = %v", err)
+		}
+	}
+}
+
+func TestRequestSlotWaitsOnClock(t *testing.T) {
+	clk := clocktest.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	for range 3 {
+		if err := waitForRequestSlot(context.Background(), clk, "limiter-clock-test", 1); err != nil {
+			t.Fatalf("waitForRequestSlot() error = %v", err)
+		}
+	}
+	if got := clk.Slept(); got != 2*time.Minute {
+		t.Errorf("waited %v on the clock, want 2m for three starts at one per minute", got)
+	}
+}
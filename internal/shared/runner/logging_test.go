@@ -3,6 +3,7 @@ package runner
 import (
 	"testing"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 )
 
@@ -52,7 +53,7 @@ func TestRunnerNames(t *testing.T) {
 				WorkDir: "/tmp",
 			}
 
-			runner := New(cfg)
+			runner := New(cfg, clock.Real{})
 
 			if runner.RunnerName() != tt.wantRunner {
 				t.Errorf("RunnerName() = %q, want %q", runner.RunnerName(), tt.wantRunner)
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 )
 
@@ -48,7 +49,7 @@ func TestManualAllRunnersAvoidArgvOverflow(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			cfg := &config.Config{Runner: tc.runner, WorkDir: t.TempDir()}
-			r := New(cfg)
+			r := New(cfg, clock.Real{})
 
 			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel()
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	promptpkg "ralph/internal/prompt"
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/prd"
 )
@@ -16,11 +17,12 @@ const mockQuestionsFile = ".ralph/questions.json"
 
 // Mock is a deterministic runner for integration tests (RALPH_RUNNER=mock).
 type Mock struct {
-	cfg *config.Config
+	cfg   *config.Config
+	clock clock.Clock
 }
 
-func NewMock(cfg *config.Config) RunnerInterface {
-	return &Mock{cfg: cfg}
+func NewMock(cfg *config.Config, clk clock.Clock) RunnerInterface {
+	return &Mock{cfg: cfg, clock: clk}
 }
 
 func (m *Mock) RunnerName() string  { return "mock" }
@@ -36,7 +38,7 @@ func (m *Mock) Run(ctx context.Context, prompt string, outputCh chan<- OutputLin
 	default:
 	}
 
-	line := OutputLine{Text: "mock runner output", Time: time.Now()}
+	line := OutputLine{Text: "mock runner output", Time: m.clock.Now()}
 	select {
 	case outputCh <- line:
 	case <-ctx.Done():
@@ -64,7 +66,15 @@ func (m *Mock) Run(ctx context.Context, prompt string, outputCh chan<- OutputLin
 	case promptpkg.KindDiffReview:
 		findings := "===ralph-findings===\n[]\n===/ralph-findings==="
 		select {
-		case outputCh <- OutputLine{Text: findings, Time: time.Now()}:
+		case outputCh <- OutputLine{Text: findings, Time: m.clock.Now()}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+
+	case promptpkg.KindStoryVerify:
+		select {
+		case outputCh <- OutputLine{Text: promptpkg.StoryVerifiedMarker + " mock verification passed", Time: m.clock.Now()}:
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -74,7 +84,7 @@ func (m *Mock) Run(ctx context.Context, prompt string, outputCh chan<- OutputLin
 		if d := os.Getenv("RALPH_MOCK_IMPL_DELAY_MS"); d != "" {
 			if ms, err := strconv.Atoi(d); err == nil && ms > 0 {
 				select {
-				case <-time.After(time.Duration(ms) * time.Millisecond):
+				case <-m.clock.After(time.Duration(ms) * time.Millisecond):
 				case <-ctx.Done():
 					return ctx.Err()
 				}
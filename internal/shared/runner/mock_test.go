@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"ralph/internal/prompt"
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/prd"
 )
@@ -20,7 +21,7 @@ func TestMockRunnerWritesPRD(t *testing.T) {
 	cfg.Runner = "mock"
 	cfg.PRDFile = "prd.json"
 
-	r := NewMock(cfg)
+	r := NewMock(cfg, clock.Real{})
 	ch := make(chan OutputLine, 4)
 	if err := r.Run(context.Background(), prompt.PRDGeneration("build x", cfg.PRDFile, cfg.BranchPrefix, false), ch); err != nil {
 		t.Fatalf("Run() error = %v", err)
@@ -50,7 +51,7 @@ func TestMockRunnerImplDelayDoesNotSlowSelfReview(t *testing.T) {
 	cfg.WorkDir = t.TempDir()
 	cfg.Runner = "mock"
 
-	r := NewMock(cfg)
+	r := NewMock(cfg, clock.Real{})
 	ch := make(chan OutputLine, 4)
 	start := time.Now()
 	if err := r.Run(context.Background(), prompt.PRDSelfReview("build x", cfg.PRDFile, 1, 3), ch); err != nil {
@@ -66,7 +67,7 @@ func TestMockRunnerOutputsCleanReviewFindings(t *testing.T) {
 	cfg.WorkDir = t.TempDir()
 	cfg.Runner = "mock"
 
-	r := NewMock(cfg)
+	r := NewMock(cfg, clock.Real{})
 	ch := make(chan OutputLine, 4)
 	if err := r.Run(context.Background(), prompt.CriticalDiffReview("", cfg.PRDFile, nil), ch); err != nil {
 		t.Fatalf("Run() error = %v", err)
@@ -152,11 +153,11 @@ func TestMockRunnerAdvancesOneSliceAtATime(t *testing.T) {
 		t.Fatalf("Save() error = %v", err)
 	}
 
-	r := NewMock(cfg)
+	r := NewMock(cfg, clock.Real{})
 	ch := make(chan OutputLine, 2)
 	implPrompt := prompt.StoryImplementation("story-1", "Story", "Desc", []prompt.SliceData{
 		{ID: "slice-1", Behavior: "first behavior", RedHint: "write first failing test"},
-	}, "", "", cfg.PRDFile, 0, 1, nil)
+	}, "", "", cfg.PRDFile, 0, 1, nil, nil)
 
 	if err := r.Run(context.Background(), implPrompt, ch); err != nil {
 		t.Fatalf("first Run() error = %v", err)
@@ -175,7 +176,7 @@ func TestMockRunnerAdvancesOneSliceAtATime(t *testing.T) {
 
 	implPrompt = prompt.StoryImplementation("story-1", "Story", "Desc", []prompt.SliceData{
 		{ID: "slice-2", Behavior: "second behavior", RedHint: "write second failing test"},
-	}, "", "", cfg.PRDFile, 0, 1, nil)
+	}, "", "", cfg.PRDFile, 0, 1, nil, nil)
 	if err := r.Run(context.Background(), implPrompt, ch); err != nil {
 		t.Fatalf("second Run() error = %v", err)
 	}
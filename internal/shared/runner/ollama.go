@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"context"
+	"strings"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/logger"
+)
+
+// OllamaRunner runs a local model with `ollama run <model>`. The model only
+// produces text; it has no tools to edit files on its own.
+type OllamaRunner struct {
+	cfg     *config.Config
+	clock   clock.Clock
+	model   string
+	CmdFunc func(ctx context.Context, name string, args ...string) CmdInterface
+	version versionCache
+}
+
+var _ RunnerInterface = (*OllamaRunner)(nil)
+
+func NewOllama(cfg *config.Config, clk clock.Clock) *OllamaRunner {
+	return &OllamaRunner{
+		cfg:     cfg,
+		clock:   clk,
+		model:   config.OllamaModel(cfg.Runner),
+		CmdFunc: defaultCmdFunc(cfg.WorkDir, cfg.RunnerEnv),
+	}
+}
+
+func (r *OllamaRunner) RunnerName() string {
+	return "ollama"
+}
+
+func (r *OllamaRunner) CommandName() string {
+	return "ollama"
+}
+
+func (r *OllamaRunner) IsInternalLog(line string) bool {
+	return stderrLineIsInternal(line, stderrFilterDefaultPipedCLI)
+}
+
+func (r *OllamaRunner) Version(ctx context.Context) (string, error) {
+	return r.version.get(ctx, r.cfg.WorkDir, r.CommandName())
+}
+
+func (r *OllamaRunner) Run(ctx context.Context, prompt string, outputCh chan<- OutputLine) error {
+	args := []string{"run", r.model}
+
+	logger.Debug("invoking AI runner",
+		"runner", r.RunnerName(),
+		"command", r.CommandName(),
+		"model", r.model,
+		"prompt_length", len(prompt),
+		"work_dir", r.cfg.WorkDir)
+
+	if outputCh != nil {
+		outputCh <- newStartingOutputLine(r.RunnerName(), r.clock.Now())
+	}
+
+	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh, r.cfg, r.clock,
+		func(line string) []OutputLine {
+			return []OutputLine{{Text: line, Time: r.clock.Now()}}
+		},
+		func(line string) []OutputLine {
+			return []OutputLine{{Text: line, IsErr: true, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
+		},
+	)
+
+	if err != nil {
+		logger.Debug("AI runner exited with code",
+			"runner", r.RunnerName(),
+			"command", r.CommandName(),
+			"exit_code", exitCode(err),
+			"error", err)
+		return wrapRunnerError(r.RunnerName(), err)
+	}
+
+	logger.Debug("AI runner completed successfully",
+		"runner", r.RunnerName(),
+		"command", r.CommandName(),
+		"model", r.model)
+	return nil
+}
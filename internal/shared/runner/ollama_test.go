@@ -0,0 +1,51 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+)
+
+func TestNewReturnsOllamaRunner(t *testing.T) {
+	r := assertRunnerIs[*OllamaRunner](t, New(&config.Config{Runner: "ollama/llama3"}, clock.Real{}))
+
+	if r.RunnerName() != "ollama" || r.CommandName() != "ollama" {
+		t.Errorf("RunnerName/CommandName = %q/%q, want ollama/ollama", r.RunnerName(), r.CommandName())
+	}
+	if r.model != "llama3" {
+		t.Errorf("model = %q, want llama3", r.model)
+	}
+}
+
+func TestOllamaRunArgs(t *testing.T) {
+	r := NewOllama(&config.Config{Runner: "ollama/qwen2.5-coder:7b"}, clock.Real{})
+
+	var name string
+	var args []string
+	mock := &mockCmd{stdout: "first line\nsecond line", stderr: ""}
+	r.CmdFunc = stubCmdFunc(mock, &name, &args)
+
+	outputCh := make(chan OutputLine, 10)
+	if err := r.Run(context.Background(), "test prompt", outputCh); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	close(outputCh)
+
+	if name != "ollama" {
+		t.Fatalf("command = %q, want ollama", name)
+	}
+	assertArgsEqual(t, args, []string{"run", "qwen2.5-coder:7b"})
+	assertPromptDeliveredViaStdin(t, mock, "test prompt")
+
+	var lines []string
+	for line := range outputCh {
+		if !line.IsErr {
+			lines = append(lines, line.Text)
+		}
+	}
+	if len(lines) < 2 || lines[len(lines)-2] != "first line" || lines[len(lines)-1] != "second line" {
+		t.Fatalf("stdout lines = %q, want each stdout line forwarded", lines)
+	}
+}
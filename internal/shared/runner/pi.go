@@ -7,21 +7,25 @@ import (
 	"strings"
 	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/logger"
 )
 
 type PiRunner struct {
 	cfg     *config.Config
+	clock   clock.Clock
 	CmdFunc func(ctx context.Context, name string, args ...string) CmdInterface
+	version versionCache
 }
 
 var _ RunnerInterface = (*PiRunner)(nil)
 
-func NewPi(cfg *config.Config) *PiRunner {
+func NewPi(cfg *config.Config, clk clock.Clock) *PiRunner {
 	return &PiRunner{
 		cfg:     cfg,
-		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir),
+		clock:   clk,
+		CmdFunc: defaultCmdFuncNoStdin(cfg.WorkDir, cfg.RunnerEnv),
 	}
 }
 
@@ -37,6 +41,10 @@ func (r *PiRunner) IsInternalLog(line string) bool {
 	return stderrLineIsInternal(line, stderrFilterDefaultPipedCLI)
 }
 
+func (r *PiRunner) Version(ctx context.Context) (string, error) {
+	return r.version.get(ctx, r.cfg.WorkDir, r.CommandName())
+}
+
 func (r *PiRunner) Run(ctx context.Context, prompt string, outputCh chan<- OutputLine) error {
 	args := []string{"--print", "--mode", "json", "--no-session"}
 
@@ -48,13 +56,15 @@ func (r *PiRunner) Run(ctx context.Context, prompt string, outputCh chan<- Outpu
 		"work_dir", r.cfg.WorkDir)
 
 	if outputCh != nil {
-		outputCh <- newStartingOutputLine(r.RunnerName())
+		outputCh <- newStartingOutputLine(r.RunnerName(), r.clock.Now())
 	}
 
-	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh,
-		parsePiJSONLine,
+	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh, r.cfg, r.clock,
+		func(line string) []OutputLine {
+			return parsePiJSONLine(line, r.clock.Now())
+		},
 		func(line string) []OutputLine {
-			return []OutputLine{{Text: line, IsErr: true, Time: time.Now(), Verbose: r.IsInternalLog(line)}}
+			return []OutputLine{{Text: line, IsErr: true, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
 		},
 	)
 
@@ -78,8 +88,7 @@ type piAssistantMsgEvt struct {
 	Delta string `json:"delta"`
 }
 
-func parsePiJSONLine(line string) []OutputLine {
-	now := time.Now()
+func parsePiJSONLine(line string, now time.Time) []OutputLine {
 	var head struct {
 		Type string `json:"type"`
 	}
@@ -1,10 +1,13 @@
 package runner
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestParsePiJSONLine_TextDeltaAppends(t *testing.T) {
 	line := `{"type":"message_update","assistantMessageEvent":{"type":"text_delta","delta":"hello"}}`
-	lines := parsePiJSONLine(line)
+	lines := parsePiJSONLine(line, time.Now())
 	if len(lines) != 1 {
 		t.Fatalf("expected 1 output, got %d", len(lines))
 	}
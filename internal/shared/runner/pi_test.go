@@ -5,12 +5,13 @@ import (
 	"strings"
 	"testing"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 )
 
 func TestPiRunnerRunArgs(t *testing.T) {
 	cfg := &config.Config{Runner: "pi"}
-	r := NewPi(cfg)
+	r := NewPi(cfg, clock.Real{})
 
 	var capturedArgs []string
 	mock := &mockCmd{stdout: "", stderr: ""}
@@ -31,7 +32,7 @@ func TestPiRunnerRunArgs(t *testing.T) {
 
 func TestPiRunnerSupportsLargePrompts(t *testing.T) {
 	cfg := &config.Config{Runner: "pi"}
-	r := NewPi(cfg)
+	r := NewPi(cfg, clock.Real{})
 
 	prompt := strings.Repeat("implement feature ", 40000)
 	mock := &mockCmd{stdout: "", stderr: ""}
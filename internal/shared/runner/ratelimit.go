@@ -0,0 +1,29 @@
+package runner
+
+import (
+	"regexp"
+	"strings"
+)
+
+var rateLimitStatusPattern = regexp.MustCompile(`\b429\b`)
+
+var rateLimitPhrases = []string{
+	"rate limit",
+	"rate_limit",
+	"ratelimit",
+	"too many requests",
+	"overloaded",
+	"quota exceeded",
+}
+
+// IsRateLimitMessage reports whether a runner output or error line looks like
+// a provider rate-limit or overload response.
+func IsRateLimitMessage(text string) bool {
+	lower := strings.ToLower(text)
+	for _, phrase := range rateLimitPhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return rateLimitStatusPattern.MatchString(text)
+}
@@ -0,0 +1,25 @@
+package runner
+
+import "testing"
+
+func TestIsRateLimitMessage(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Error: Rate limit reached for requests", true},
+		{`{"type":"error","error":{"type":"rate_limit_error"}}`, true},
+		{"API Error: 529 Overloaded", true},
+		{"HTTP 429: Too Many Requests", true},
+		{"status 429", true},
+		{"You have exceeded your quota exceeded for today", true},
+		{"Edited file line 4290", false},
+		{"All tests passed", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsRateLimitMessage(tt.text); got != tt.want {
+			t.Errorf("IsRateLimitMessage(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
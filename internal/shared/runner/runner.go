@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/constants"
 	"ralph/internal/shared/logger"
@@ -24,48 +25,61 @@ type OutputLine struct {
 	Time    time.Time
 	Verbose bool
 	Append  bool
+	// Raw marks a line already copied verbatim to the raw output stream;
+	// observers still see it, but it is not shown again in the UI.
+	Raw bool
 }
 
 type Runner struct {
 	cfg     *config.Config
+	clock   clock.Clock
 	CmdFunc func(ctx context.Context, name string, args ...string) CmdInterface
+	version versionCache
 }
 
 var _ RunnerInterface = (*Runner)(nil)
 
-func New(cfg *config.Config) RunnerInterface {
+func New(cfg *config.Config, clk clock.Clock) RunnerInterface {
 	provider := config.DetectRunner(cfg.Runner)
 	if provider == config.RunnerClaude {
 		logger.Debug("using Claude Code runner", "runner", cfg.Runner)
-		return NewClaude(cfg)
+		return NewClaude(cfg, clk)
 	}
 	if provider == config.RunnerPi {
 		logger.Debug("using pi runner", "runner", cfg.Runner)
-		return NewPi(cfg)
+		return NewPi(cfg, clk)
 	}
 	if provider == config.RunnerCursor {
 		logger.Debug("using cursor-agent runner", "runner", cfg.Runner)
-		return NewCursorAgent(cfg)
+		return NewCursorAgent(cfg, clk)
 	}
 	if provider == config.RunnerCopilot {
 		logger.Debug("using copilot runner", "runner", cfg.Runner)
-		return NewCopilot(cfg)
+		return NewCopilot(cfg, clk)
+	}
+	if provider == config.RunnerOllama {
+		logger.Debug("using ollama runner", "runner", cfg.Runner)
+		return NewOllama(cfg, clk)
+	}
+	if provider == config.RunnerGemini {
+		logger.Debug("using gemini runner", "runner", cfg.Runner)
+		return NewGemini(cfg, clk)
 	}
 	if provider == config.RunnerMock {
 		logger.Debug("using mock runner", "runner", cfg.Runner)
-		return NewMock(cfg)
+		return NewMock(cfg, clk)
 	}
 
 	logger.Debug("using OpenCode runner", "runner", cfg.Runner)
-	return &Runner{cfg: cfg, CmdFunc: defaultCmdFunc(cfg.WorkDir)}
+	return &Runner{cfg: cfg, clock: clk, CmdFunc: defaultCmdFunc(cfg.WorkDir, cfg.RunnerEnv)}
 }
 
-func NewWithError(cfg *config.Config) (RunnerInterface, error) {
+func NewWithError(cfg *config.Config, clk clock.Clock) (RunnerInterface, error) {
 	if err := cfg.ValidateRunner(); err != nil {
 		return nil, fmt.Errorf("invalid runner configuration %q: %w", cfg.Runner, err)
 	}
 
-	return New(cfg), nil
+	return New(cfg, clk), nil
 }
 
 func (r *Runner) RunnerName() string {
@@ -80,6 +94,10 @@ func (r *Runner) IsInternalLog(line string) bool {
 	return stderrLineIsInternal(line, stderrFilterOpenCode)
 }
 
+func (r *Runner) Version(ctx context.Context) (string, error) {
+	return r.version.get(ctx, r.cfg.WorkDir, r.CommandName())
+}
+
 func (r *Runner) Run(ctx context.Context, prompt string, outputCh chan<- OutputLine) error {
 	args := []string{"run", "--print-logs"}
 
@@ -91,15 +109,15 @@ func (r *Runner) Run(ctx context.Context, prompt string, outputCh chan<- OutputL
 		"work_dir", r.cfg.WorkDir)
 
 	if outputCh != nil {
-		outputCh <- OutputLine{Text: fmt.Sprintf("Starting %s...", r.RunnerName()), Time: time.Now()}
+		outputCh <- OutputLine{Text: fmt.Sprintf("Starting %s...", r.RunnerName()), Time: r.clock.Now()}
 	}
 
-	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh,
+	err := runWithPipedCommandAndStdin(ctx, r.CommandName(), r.CmdFunc, strings.NewReader(prompt), args, outputCh, r.cfg, r.clock,
 		func(line string) []OutputLine {
-			return []OutputLine{{Text: line, IsErr: false, Time: time.Now(), Verbose: r.IsInternalLog(line)}}
+			return []OutputLine{{Text: line, IsErr: false, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
 		},
 		func(line string) []OutputLine {
-			return []OutputLine{{Text: line, IsErr: true, Time: time.Now(), Verbose: r.IsInternalLog(line)}}
+			return []OutputLine{{Text: line, IsErr: true, Time: r.clock.Now(), Verbose: r.IsInternalLog(line)}}
 		},
 	)
 
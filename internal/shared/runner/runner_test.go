@@ -7,12 +7,13 @@ import (
 	"strings"
 	"testing"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 )
 
 func TestNew(t *testing.T) {
 	cfg := &config.Config{Runner: "opencode"}
-	r := New(cfg)
+	r := New(cfg, clock.Real{})
 
 	if r == nil {
 		t.Fatal("New() returned nil")
@@ -211,7 +212,7 @@ func TestOpenCodeSupportsLargePrompts(t *testing.T) {
 }
 
 func TestDefaultCmdFunc(t *testing.T) {
-	cmdFunc := defaultCmdFunc("")
+	cmdFunc := defaultCmdFunc("", nil)
 	cmd := cmdFunc(context.Background(), "echo", "test")
 	if cmd == nil {
 		t.Error("defaultCmdFunc() returned nil")
@@ -220,7 +221,7 @@ func TestDefaultCmdFunc(t *testing.T) {
 
 func TestDefaultCmdFuncWithWorkDir(t *testing.T) {
 	tmpDir := t.TempDir()
-	cmdFunc := defaultCmdFunc(tmpDir)
+	cmdFunc := defaultCmdFunc(tmpDir, nil)
 	cmd := cmdFunc(context.Background(), "pwd")
 	if cmd == nil {
 		t.Error("defaultCmdFunc() returned nil")
@@ -231,8 +232,23 @@ func TestDefaultCmdFuncWithWorkDir(t *testing.T) {
 	}
 }
 
+func TestDefaultCmdFuncPassesRunnerEnv(t *testing.T) {
+	t.Setenv("RALPH_TEST_INHERITED", "parent")
+	t.Setenv("RALPH_TEST_ENDPOINT", "https://default.example")
+	cmdFunc := defaultCmdFunc("", []string{"RALPH_TEST_ENDPOINT=https://staging.example", "RALPH_TEST_PROJECT=demo"})
+	cmd := cmdFunc(context.Background(), "sh", "-c", "echo $RALPH_TEST_INHERITED $RALPH_TEST_ENDPOINT $RALPH_TEST_PROJECT")
+
+	out, err := cmd.(*realCmd).Output()
+	if err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	if got, want := strings.TrimSpace(string(out)), "parent https://staging.example demo"; got != want {
+		t.Errorf("command saw %q, want %q (inherited env plus RALPH_RUNNER_ENV overrides)", got, want)
+	}
+}
+
 func TestRealCmdPipes(t *testing.T) {
-	cmdFunc := defaultCmdFunc("")
+	cmdFunc := defaultCmdFunc("", nil)
 	cmd := cmdFunc(context.Background(), "echo", "test")
 	rc := cmd.(*realCmd)
 
@@ -254,7 +270,7 @@ func TestRealCmdPipes(t *testing.T) {
 }
 
 func TestRealCmdStartWait(t *testing.T) {
-	cmdFunc := defaultCmdFunc("")
+	cmdFunc := defaultCmdFunc("", nil)
 	cmd := cmdFunc(context.Background(), "echo", "test")
 	rc := cmd.(*realCmd)
 
@@ -395,7 +411,7 @@ func TestOutputLineVerboseField(t *testing.T) {
 
 func TestNewReturnsCopilotRunner(t *testing.T) {
 	cfg := &config.Config{Runner: "copilot"}
-	r := New(cfg)
+	r := New(cfg, clock.Real{})
 
 	if r == nil {
 		t.Fatal("New() returned nil")
@@ -417,7 +433,7 @@ func TestNewReturnsCopilotRunner(t *testing.T) {
 
 func TestNewReturnsCursorAgentRunner(t *testing.T) {
 	cfg := &config.Config{Runner: "cursor"}
-	r := New(cfg)
+	r := New(cfg, clock.Real{})
 
 	if r == nil {
 		t.Fatal("New() returned nil")
@@ -439,7 +455,7 @@ func TestNewReturnsCursorAgentRunner(t *testing.T) {
 
 func TestNewReturnsClaudeRunner(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	runner := New(cfg)
+	runner := New(cfg, clock.Real{})
 
 	if runner == nil {
 		t.Fatal("New() returned nil")
@@ -457,7 +473,7 @@ func TestNewReturnsClaudeRunner(t *testing.T) {
 
 func TestNewReturnsOpenCodeRunner(t *testing.T) {
 	cfg := &config.Config{Runner: "opencode"}
-	runner := New(cfg)
+	runner := New(cfg, clock.Real{})
 
 	if runner == nil {
 		t.Fatal("New() returned nil")
@@ -475,7 +491,7 @@ func TestNewReturnsOpenCodeRunner(t *testing.T) {
 
 func TestNewWithDefaultRunner(t *testing.T) {
 	cfg := &config.Config{Runner: config.DefaultRunner}
-	runner := New(cfg)
+	runner := New(cfg, clock.Real{})
 
 	if runner == nil {
 		t.Fatal("New() returned nil")
@@ -503,7 +519,7 @@ func TestNewWithError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			runner, err := NewWithError(&config.Config{Runner: tt.runner})
+			runner, err := NewWithError(&config.Config{Runner: tt.runner}, clock.Real{})
 			if tt.wantErr {
 				if err == nil {
 					t.Fatal("NewWithError() should return error")
@@ -550,7 +566,7 @@ func TestRunnerSwitchingBetweenRuns(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := New(&config.Config{Runner: tt.runner})
+			r := New(&config.Config{Runner: tt.runner}, clock.Real{})
 			if r == nil {
 				t.Fatal("New() returned nil")
 			}
@@ -568,7 +584,7 @@ func TestRunnerSwitchingBetweenRuns(t *testing.T) {
 
 func TestIntegrationClaudeRunnerExecution(t *testing.T) {
 	cfg := &config.Config{Runner: "claude"}
-	runner := New(cfg)
+	runner := New(cfg, clock.Real{})
 
 	mock := &mockCmd{stdout: "claude output", stderr: ""}
 	claudeRunner, ok := runner.(*ClaudeRunner)
@@ -594,7 +610,7 @@ func TestIntegrationClaudeRunnerExecution(t *testing.T) {
 
 func TestIntegrationOpenCodeRunnerExecution(t *testing.T) {
 	cfg := &config.Config{Runner: "opencode"}
-	runner := New(cfg)
+	runner := New(cfg, clock.Real{})
 
 	mock := &mockCmd{stdout: "opencode output", stderr: ""}
 	openCodeRunner, ok := runner.(*Runner)
@@ -620,7 +636,7 @@ func TestIntegrationOpenCodeRunnerExecution(t *testing.T) {
 
 func TestIntegrationPiRunnerExecution(t *testing.T) {
 	cfg := &config.Config{Runner: "pi"}
-	runner := New(cfg)
+	runner := New(cfg, clock.Real{})
 
 	mock := &mockCmd{stdout: `{"type":"session","version":3}`, stderr: ""}
 	piR, ok := runner.(*PiRunner)
@@ -651,12 +667,12 @@ func TestRunnerInterfaceIsInternalLog(t *testing.T) {
 		line string
 		want bool
 	}{
-		{name: "open code internal", r: New(&config.Config{Runner: "opencode"}), line: "service=bus starting", want: true},
-		{name: "open code normal", r: New(&config.Config{Runner: "opencode"}), line: "Regular output", want: false},
-		{name: "claude internal", r: New(&config.Config{Runner: "claude"}), line: "debug info", want: true},
-		{name: "claude user error", r: New(&config.Config{Runner: "claude"}), line: "Error: file not found", want: false},
-		{name: "pi internal", r: New(&config.Config{Runner: "pi"}), line: "debug info", want: true},
-		{name: "pi user error", r: New(&config.Config{Runner: "pi"}), line: "Error: file not found", want: false},
+		{name: "open code internal", r: New(&config.Config{Runner: "opencode"}, clock.Real{}), line: "service=bus starting", want: true},
+		{name: "open code normal", r: New(&config.Config{Runner: "opencode"}, clock.Real{}), line: "Regular output", want: false},
+		{name: "claude internal", r: New(&config.Config{Runner: "claude"}, clock.Real{}), line: "debug info", want: true},
+		{name: "claude user error", r: New(&config.Config{Runner: "claude"}, clock.Real{}), line: "Error: file not found", want: false},
+		{name: "pi internal", r: New(&config.Config{Runner: "pi"}, clock.Real{}), line: "debug info", want: true},
+		{name: "pi user error", r: New(&config.Config{Runner: "pi"}, clock.Real{}), line: "Error: file not found", want: false},
 	}
 
 	for _, tt := range tests {
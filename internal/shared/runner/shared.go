@@ -5,13 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
 )
 
-func newStartingOutputLine(runnerName string) OutputLine {
-	return OutputLine{Text: fmt.Sprintf("Starting %s...", runnerName), Time: time.Now()}
+// rawOutputWriter receives unparsed runner lines when raw output is enabled.
+var rawOutputWriter io.Writer = os.Stdout
+
+func newStartingOutputLine(runnerName string, now time.Time) OutputLine {
+	return OutputLine{Text: fmt.Sprintf("Starting %s...", runnerName), Time: now}
 }
 
 func runWithPipedCommandAndStdin(
@@ -21,13 +29,51 @@ func runWithPipedCommandAndStdin(
 	stdin io.Reader,
 	args []string,
 	outputCh chan<- OutputLine,
+	cfg *config.Config,
+	clk clock.Clock,
 	stdoutTransform, stderrTransform LineTransformer,
 ) error {
+	switch {
+	case cfg.RawOutput:
+		var mu sync.Mutex
+		stdoutTransform = rawPassthrough(rawOutputWriter, &mu, stdoutTransform)
+		stderrTransform = rawPassthrough(rawOutputWriter, &mu, stderrTransform)
+	case cfg.LogLevel >= config.LogLevelDebug:
+		stdoutTransform, stderrTransform = debugPassthrough(clk, false), debugPassthrough(clk, true)
+	}
+	if err := waitForRequestSlot(ctx, clk, cmdName, cfg.RequestsPerMinute); err != nil {
+		return err
+	}
 	cmd := cmdFactory(ctx, cmdName, args...)
 	setCmdStdin(cmd, stdin)
 	return runPipedCommand(cmdName, cmd, outputCh, stdoutTransform, stderrTransform)
 }
 
+// rawPassthrough copies each line verbatim to w, then still returns the lines
+// transform parses from it marked Raw, so markers, rate limits and exit errors
+// are detected while the forwarder keeps them out of the UI. mu serializes
+// writes from the stdout and stderr readers.
+func rawPassthrough(w io.Writer, mu *sync.Mutex, transform LineTransformer) LineTransformer {
+	return func(line string) []OutputLine {
+		mu.Lock()
+		fmt.Fprintln(w, line)
+		mu.Unlock()
+		outs := transform(line)
+		for i := range outs {
+			outs[i].Raw = true
+		}
+		return outs
+	}
+}
+
+// debugPassthrough forwards each line unparsed and never marks it verbose,
+// so --debug shows exactly what the runner printed on which stream.
+func debugPassthrough(clk clock.Clock, isErr bool) LineTransformer {
+	return func(line string) []OutputLine {
+		return []OutputLine{{Text: line, IsErr: isErr, Time: clk.Now()}}
+	}
+}
+
 func wrapRunnerError(runnerName string, err error) error {
 	var detailErr *ExitDetailError
 	if errors.As(err, &detailErr) {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
 	"sync"
@@ -43,24 +44,27 @@ func (c *realCmd) StderrPipe() (io.ReadCloser, error) { return c.Cmd.StderrPipe(
 func (c *realCmd) Start() error                       { return c.Cmd.Start() }
 func (c *realCmd) Wait() error                        { return c.Cmd.Wait() }
 
-func defaultCmdFunc(workDir string) func(ctx context.Context, name string, args ...string) CmdInterface {
+// defaultCmdFunc runs commands in workDir with the parent environment plus
+// env (RALPH_RUNNER_ENV), whose KEY=VALUE entries win over inherited ones.
+func defaultCmdFunc(workDir string, env []string) func(ctx context.Context, name string, args ...string) CmdInterface {
 	return func(ctx context.Context, name string, args ...string) CmdInterface {
 		cmd := exec.CommandContext(ctx, name, args...)
 		if workDir != "" {
 			cmd.Dir = workDir
 		}
+		if len(env) > 0 {
+			cmd.Env = append(os.Environ(), env...)
+		}
 		return &realCmd{cmd}
 	}
 }
 
-func defaultCmdFuncNoStdin(workDir string) func(ctx context.Context, name string, args ...string) CmdInterface {
+func defaultCmdFuncNoStdin(workDir string, env []string) func(ctx context.Context, name string, args ...string) CmdInterface {
+	newCmd := defaultCmdFunc(workDir, env)
 	return func(ctx context.Context, name string, args ...string) CmdInterface {
-		cmd := exec.CommandContext(ctx, name, args...)
-		if workDir != "" {
-			cmd.Dir = workDir
-		}
-		cmd.Stdin = nil
-		return &realCmd{cmd}
+		cmd := newCmd(ctx, name, args...)
+		cmd.(*realCmd).Stdin = nil
+		return cmd
 	}
 }
 
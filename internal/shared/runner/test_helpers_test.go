@@ -5,12 +5,13 @@ import (
 	"io"
 	"testing"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 )
 
 func newTestRunner(t *testing.T, cfg *config.Config) *Runner {
 	t.Helper()
-	return &Runner{cfg: cfg, CmdFunc: defaultCmdFunc(cfg.WorkDir)}
+	return &Runner{cfg: cfg, clock: clock.Real{}, CmdFunc: defaultCmdFunc(cfg.WorkDir, cfg.RunnerEnv)}
 }
 
 func stubCmdFunc(mock CmdInterface, capturedName *string, capturedArgs *[]string) func(context.Context, string, ...string) CmdInterface {
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// TransientClassifier is implemented by runners that tell a transient
+// failure, such as a network blip or a provider rate limit, from one caused by
+// the work itself differently than IsTransientError does.
+type TransientClassifier interface {
+	IsTransient(err error) bool
+}
+
+var transientPhrases = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"tls handshake timeout",
+	"no such host",
+	"temporary failure in name resolution",
+	"network is unreachable",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+}
+
+// IsTransientError reports whether a runner error looks like a network blip or
+// provider rate limit that is worth retrying as is. Cancellation and timeouts
+// are never transient, nor is a runner that ran but produced nothing useful.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	text := err.Error()
+	if IsRateLimitMessage(text) {
+		return true
+	}
+	lower := strings.ToLower(text)
+	for _, phrase := range transientPhrases {
+		if strings.Contains(lower, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransient asks r to classify err, falling back to IsTransientError for
+// runners without a TransientClassifier.
+func IsTransient(r RunnerInterface, err error) bool {
+	if c, ok := r.(TransientClassifier); ok {
+		return c.IsTransient(err)
+	}
+	return IsTransientError(err)
+}
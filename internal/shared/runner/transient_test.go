@@ -0,0 +1,48 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("claude exited: API Error: 429 Too Many Requests"), true},
+		{errors.New("read tcp 10.0.0.1:443: connection reset by peer"), true},
+		{errors.New("dial tcp: lookup api.example.com: no such host"), true},
+		{errors.New("502 Bad Gateway"), true},
+		{fmt.Errorf("runner invocation timed out after 1m: %w", context.DeadlineExceeded), false},
+		{context.Canceled, false},
+		{errors.New("runner did not produce output"), false},
+		{errors.New("exit status 1"), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsTransientError(tt.err); got != tt.want {
+			t.Errorf("IsTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// neverTransientRunner classifies every failure as permanent.
+type neverTransientRunner struct{ RunnerInterface }
+
+func (neverTransientRunner) IsTransient(error) bool { return false }
+
+func TestIsTransientUsesRunnerClassifier(t *testing.T) {
+	err := errors.New("connection reset by peer")
+	if !IsTransient(NewClaude(config.DefaultConfig(), clock.Real{}), err) {
+		t.Error("IsTransient(claude) = false, want IsTransientError's true for a connection reset")
+	}
+	if IsTransient(neverTransientRunner{NewMock(config.DefaultConfig(), clock.Real{})}, err) {
+		t.Error("IsTransient(classifier) = true, want the runner's own false")
+	}
+}
@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// versionTimeout bounds a backend's --version call so a wedged CLI cannot
+// hold up startup.
+const versionTimeout = 5 * time.Second
+
+// Versioner is implemented by runners that can report the version of the
+// backend CLI they shell out to.
+type Versioner interface {
+	Version(ctx context.Context) (string, error)
+}
+
+var (
+	_ Versioner = (*Runner)(nil)
+	_ Versioner = (*ClaudeRunner)(nil)
+	_ Versioner = (*CopilotRunner)(nil)
+	_ Versioner = (*CursorAgentRunner)(nil)
+	_ Versioner = (*OllamaRunner)(nil)
+	_ Versioner = (*GeminiRunner)(nil)
+	_ Versioner = (*PiRunner)(nil)
+)
+
+// commandOutput runs a backend command and returns its stdout; tests replace
+// it to avoid depending on installed CLIs.
+var commandOutput = func(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	return cmd.Output()
+}
+
+// versionCache queries a backend's --version once and remembers the answer,
+// including a failure, for the rest of the run.
+type versionCache struct {
+	once    sync.Once
+	version string
+	err     error
+}
+
+func (c *versionCache) get(ctx context.Context, dir, command string) (string, error) {
+	c.once.Do(func() {
+		ctx, cancel := context.WithTimeout(ctx, versionTimeout)
+		defer cancel()
+		out, err := commandOutput(ctx, dir, command, "--version")
+		if err != nil {
+			c.err = fmt.Errorf("%s --version: %w", command, err)
+			return
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				c.version = line
+				return
+			}
+		}
+		c.err = fmt.Errorf("%s --version printed nothing", command)
+	})
+	return c.version, c.err
+}
+
+// VersionOf returns r's backend version, or "unknown" when r cannot report
+// one or the backend is missing.
+func VersionOf(ctx context.Context, r RunnerInterface) string {
+	v, ok := r.(Versioner)
+	if !ok {
+		return "unknown"
+	}
+	version, err := v.Version(ctx)
+	if err != nil || version == "" {
+		return "unknown"
+	}
+	return version
+}
+
+// HeaderLine describes the runner and its backend version for the start of a
+// run, so bug reports say which CLI version did the work.
+func HeaderLine(ctx context.Context, r RunnerInterface) string {
+	return fmt.Sprintf("Runner: %s (%s %s)", r.RunnerName(), r.CommandName(), VersionOf(ctx, r))
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/config"
+)
+
+func stubCommandOutput(t *testing.T, out string, err error) *int {
+	t.Helper()
+	calls := 0
+	orig := commandOutput
+	commandOutput = func(_ context.Context, _, name string, args ...string) ([]byte, error) {
+		calls++
+		if name != "claude" || len(args) != 1 || args[0] != "--version" {
+			t.Errorf("ran %s %v, want claude --version", name, args)
+		}
+		return []byte(out), err
+	}
+	t.Cleanup(func() { commandOutput = orig })
+	return &calls
+}
+
+func TestHeaderLineReportsBackendVersionOnce(t *testing.T) {
+	calls := stubCommandOutput(t, "\n  2.0.14 (Claude Code)\nextra\n", nil)
+	r := NewClaude(config.DefaultConfig(), clock.Real{})
+
+	for range 2 {
+		if got, want := HeaderLine(context.Background(), r), "Runner: Claude Code (claude 2.0.14 (Claude Code))"; got != want {
+			t.Errorf("HeaderLine() = %q, want %q", got, want)
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("--version ran %d times, want 1", *calls)
+	}
+}
+
+func TestVersionOfUnknown(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		err  error
+		r    func() RunnerInterface
+	}{
+		{name: "binary missing", err: errors.New(`exec: "claude": executable file not found in $PATH`), r: func() RunnerInterface { return NewClaude(config.DefaultConfig(), clock.Real{}) }},
+		{name: "empty output", out: "\n", r: func() RunnerInterface { return NewClaude(config.DefaultConfig(), clock.Real{}) }},
+		{name: "runner without a backend", r: func() RunnerInterface { return NewMock(config.DefaultConfig(), clock.Real{}) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubCommandOutput(t, tt.out, tt.err)
+			if got := VersionOf(context.Background(), tt.r()); got != "unknown" {
+				t.Errorf("VersionOf() = %q, want unknown", got)
+			}
+		})
+	}
+}
@@ -27,10 +27,25 @@ func NewWithRunner(cfg *config.Config, r runner.RunnerInterface) *Session {
 }
 
 func (s *Session) ApproveReview(ctx context.Context, cfg *config.Config) error {
+	return s.ApproveReviewSkipping(ctx, cfg, nil)
+}
+
+// ApproveReviewSkipping approves the reviewed PRD after marking the stories in
+// skip as skipped and saving it, so the run leaves them out.
+func (s *Session) ApproveReviewSkipping(ctx context.Context, cfg *config.Config, skip []string) error {
 	p, err := s.PRDForImplementation(cfg)
 	if err != nil {
 		return err
 	}
+	if len(skip) > 0 {
+		p = p.Clone()
+		if err := p.SkipStories(skip); err != nil {
+			return err
+		}
+		if err := prd.Save(cfg, p); err != nil {
+			return fmt.Errorf("save PRD with skipped stories: %w", err)
+		}
+	}
 	s.StartImplementation(ctx, p)
 	return nil
 }
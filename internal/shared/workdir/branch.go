@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"unicode"
 )
 
 var fallbackDefaultBranches = []string{"main", "master", "develop", "trunk"}
@@ -70,6 +71,25 @@ func CheckoutBranch(workDir, branchName string) error {
 	return err
 }
 
+// SwitchBranch checks out an existing branch without moving it, unlike
+// CheckoutBranch. Git refuses when uncommitted changes would be overwritten.
+func SwitchBranch(workDir, branchName string) error {
+	_, err := runGitCommand(workDir, "checkout", branchName)
+	return err
+}
+
+// HeadSHA returns the full commit hash HEAD points at.
+func HeadSHA(workDir string) (string, error) {
+	return runGitCommand(workDir, "rev-parse", "HEAD")
+}
+
+// ResetHard moves the current branch to sha and discards uncommitted changes
+// to tracked files. Untracked files are left alone.
+func ResetHard(workDir, sha string) error {
+	_, err := runGitCommand(workDir, "reset", "--hard", sha)
+	return err
+}
+
 func runGitCommand(workDir string, args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = workDir
@@ -79,3 +99,43 @@ func runGitCommand(workDir string, args ...string) (string, error) {
 	}
 	return strings.TrimSpace(string(out)), nil
 }
+
+// SanitizeBranchName turns name into a lowercase branch ref git accepts (see
+// git check-ref-format): anything but letters, digits, "/", ".", "_", and "-"
+// becomes a hyphen, runs of hyphens collapse to one, ".." is broken up, and
+// each path component is trimmed of edge hyphens, leading and trailing dots,
+// and a ".lock" suffix. The result depends only on name, so the PRD can store
+// it as the branch that actually exists.
+func SanitizeBranchName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '/', r == '.', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	sanitized := b.String()
+	for strings.Contains(sanitized, "..") {
+		sanitized = strings.ReplaceAll(sanitized, "..", ".")
+	}
+	for strings.Contains(sanitized, "--") {
+		sanitized = strings.ReplaceAll(sanitized, "--", "-")
+	}
+
+	var parts []string
+	for _, part := range strings.Split(sanitized, "/") {
+		for {
+			trimmed := strings.TrimSuffix(strings.Trim(part, "-."), ".lock")
+			if trimmed == part {
+				break
+			}
+			part = trimmed
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
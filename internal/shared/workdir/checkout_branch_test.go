@@ -1,6 +1,8 @@
 package workdir_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"ralph/internal/shared/testgit"
@@ -24,3 +26,29 @@ func TestCheckoutBranch(t *testing.T) {
 		t.Fatalf("CurrentBranchName() = %q, want %q", got, branchName)
 	}
 }
+
+func TestResetHardReturnsToHeadSHA(t *testing.T) {
+	dir := t.TempDir()
+	testgit.InitRepo(t, dir)
+	base, err := workdir.HeadSHA(dir)
+	if err != nil || len(base) != 40 {
+		t.Fatalf("HeadSHA() = %q, %v, want a full commit hash", base, err)
+	}
+
+	testgit.WriteFile(t, dir, "story.txt", "half done\n")
+	testgit.CommitFile(t, dir, "story.txt", "story slice")
+	testgit.WriteFile(t, dir, "README.md", "edited\n")
+
+	if err := workdir.ResetHard(dir, base); err != nil {
+		t.Fatalf("ResetHard() error = %v", err)
+	}
+	if got, _ := workdir.HeadSHA(dir); got != base {
+		t.Fatalf("HEAD = %q after reset, want %q", got, base)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(data) != "ok\n" {
+		t.Fatalf("README.md = %q, want the committed contents restored", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "story.txt")); !os.IsNotExist(err) {
+		t.Fatalf("story.txt still exists after reset: %v", err)
+	}
+}
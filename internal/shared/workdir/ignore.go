@@ -0,0 +1,110 @@
+package workdir
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName lists gitignore-style globs, one per line, for paths that
+// codebase detection should not count. Blank lines and # comments are
+// skipped, a leading ! re-includes, a trailing / matches only directories,
+// and ** matches any number of directories. Patterns without a slash match a
+// name at any depth; the rest are relative to the work dir.
+const IgnoreFileName = ".ralphignore"
+
+type ignorePattern struct {
+	glob     string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+type ignoreRules []ignorePattern
+
+// loadIgnoreRules reads .ralphignore from workDir. A missing or unreadable
+// file means nothing is ignored.
+func loadIgnoreRules(workDir string) ignoreRules {
+	f, err := os.Open(filepath.Join(workDir, IgnoreFileName))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var rules ignoreRules
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var p ignorePattern
+		if rest, ok := strings.CutPrefix(line, "!"); ok {
+			p.negate = true
+			line = rest
+		}
+		if rest, ok := strings.CutSuffix(line, "/"); ok {
+			p.dirOnly = true
+			line = rest
+		}
+		if rest, ok := strings.CutPrefix(line, "/"); ok {
+			p.anchored = true
+			line = rest
+		}
+		if strings.Contains(line, "/") {
+			p.anchored = true
+		}
+		if line == "" {
+			continue
+		}
+		p.glob = line
+		rules = append(rules, p)
+	}
+	return rules
+}
+
+// ignored reports whether rel, a slash-separated path relative to the work
+// dir, is excluded. Later patterns win, as in .gitignore.
+func (r ignoreRules) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, p := range r {
+		if p.matches(rel, isDir) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+func (p ignorePattern) matches(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if !p.anchored {
+		ok, _ := path.Match(p.glob, path.Base(rel))
+		return ok
+	}
+	return matchSegments(strings.Split(p.glob, "/"), strings.Split(rel, "/"))
+}
+
+func matchSegments(glob, parts []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(glob[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(glob[0], parts[0]); !ok {
+			return false
+		}
+		glob, parts = glob[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
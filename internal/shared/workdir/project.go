@@ -37,16 +37,20 @@ var skipDirNames = map[string]bool{
 	"__pycache__":  true,
 }
 
+// ContainsSource reports whether workDir looks like an existing codebase: a
+// project manifest or a source file outside skipped directories and paths
+// listed in .ralphignore.
 func ContainsSource(workDir string) bool {
 	if workDir == "" {
 		return false
 	}
+	ignore := loadIgnoreRules(workDir)
 	for _, name := range projectManifests {
-		if fileExists(filepath.Join(workDir, name)) {
+		if fileExists(filepath.Join(workDir, name)) && !ignore.ignored(name, false) {
 			return true
 		}
 	}
-	return containsSourceFile(workDir)
+	return containsSourceFile(workDir, ignore)
 }
 
 func DetectTestCommand(workDir string) string {
@@ -70,19 +74,27 @@ func DetectTestCommand(workDir string) string {
 	return ""
 }
 
-func containsSourceFile(workDir string) bool {
+func containsSourceFile(workDir string, ignore ignoreRules) bool {
 	found := false
 	_ = filepath.WalkDir(workDir, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
+		rel, relErr := filepath.Rel(workDir, path)
+		if relErr != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
 		if d.IsDir() {
 			name := d.Name()
-			if strings.HasPrefix(name, ".") || skipDirNames[name] {
+			if strings.HasPrefix(name, ".") || skipDirNames[name] || ignore.ignored(rel, true) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		if ignore.ignored(rel, false) {
+			return nil
+		}
 		ext := strings.ToLower(filepath.Ext(path))
 		if sourceExtensions[ext] {
 			found = true
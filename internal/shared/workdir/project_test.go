@@ -78,6 +78,43 @@ func TestContainsSourceStillDetectsSourceFiles(t *testing.T) {
 	}
 }
 
+func TestContainsSourceHonorsRalphignore(t *testing.T) {
+	cases := []struct {
+		name   string
+		ignore string
+		files  []string
+		want   bool
+	}{
+		{name: "only ignored dir", ignore: "scripts/\n", files: []string{"scripts/deploy.sh", "scripts/lib/util.py"}, want: false},
+		{name: "ignored glob at any depth", ignore: "# generated\n*.gen.go\n", files: []string{"api/types.gen.go", "db.gen.go"}, want: false},
+		{name: "anchored double star", ignore: "tools/**/*.js\n", files: []string{"tools/a/b/build.js"}, want: false},
+		{name: "ignored manifest", ignore: "Makefile\n", files: []string{"Makefile"}, want: false},
+		{name: "negation re-includes", ignore: "scripts/*\n!scripts/main.go\n", files: []string{"scripts/deploy.sh", "scripts/main.go"}, want: true},
+		{name: "unignored source still counts", ignore: "scripts/\n", files: []string{"scripts/deploy.sh", "cmd/app.go"}, want: true},
+		{name: "dir-only pattern skips files of that name", ignore: "build.sh/\n", files: []string{"build.sh"}, want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, workdir.IgnoreFileName), []byte(tc.ignore), 0644); err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range tc.files {
+				path := filepath.Join(dir, filepath.FromSlash(f))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := workdir.ContainsSource(dir); got != tc.want {
+				t.Fatalf("ContainsSource() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestDetectTestCommandFromManifests(t *testing.T) {
 	cases := []struct {
 		name  string
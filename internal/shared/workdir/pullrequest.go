@@ -0,0 +1,50 @@
+package workdir
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GHUnavailableError means the GitHub CLI cannot open a pull request here:
+// gh is not on PATH or is not logged in. Hint says how to fix it.
+type GHUnavailableError struct {
+	Hint string
+	Err  error
+}
+
+func (e *GHUnavailableError) Error() string {
+	return fmt.Sprintf("cannot open a pull request with gh: %s", e.Hint)
+}
+
+func (e *GHUnavailableError) Unwrap() error { return e.Err }
+
+// OpenPR pushes branch to origin and opens a pull request for it with the
+// GitHub CLI, returning the PR URL gh prints.
+func OpenPR(workDir, branch, title, body string) (string, error) {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return "", &GHUnavailableError{Hint: "install the GitHub CLI (https://cli.github.com) to open pull requests automatically", Err: err}
+	}
+	if _, err := runGHCommand(workDir, "auth", "status"); err != nil {
+		return "", &GHUnavailableError{Hint: "run gh auth login, then open the pull request with gh pr create", Err: err}
+	}
+	if _, err := runGitCommand(workDir, "push", "--set-upstream", "origin", branch); err != nil {
+		return "", fmt.Errorf("push %s before opening a pull request: %w", branch, err)
+	}
+	out, err := runGHCommand(workDir, "pr", "create", "--head", branch, "--title", title, "--body", body)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(out, "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+func runGHCommand(workDir string, args ...string) (string, error) {
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = workDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("gh %s in %s: %w: %s", strings.Join(args[:min(2, len(args))], " "), workDir, err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
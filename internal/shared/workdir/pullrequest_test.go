@@ -0,0 +1,90 @@
+package workdir_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/testgit"
+	"ralph/internal/shared/workdir"
+)
+
+// fakeGH puts a gh script on PATH that logs its arguments to gh.log and
+// fails auth status when loggedIn is false.
+func fakeGH(t *testing.T, loggedIn bool) string {
+	t.Helper()
+	bin := t.TempDir()
+	log := filepath.Join(bin, "gh.log")
+	authExit := "0"
+	if !loggedIn {
+		authExit = "1"
+	}
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\n" +
+		"if [ \"$1\" = auth ]; then exit " + authExit + "; fi\n" +
+		"echo Creating pull request\necho https://github.com/acme/app/pull/7\n"
+	if err := os.WriteFile(filepath.Join(bin, "gh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func repoWithOrigin(t *testing.T, branch string) string {
+	t.Helper()
+	origin := t.TempDir()
+	if out, err := exec.Command("git", "init", "--bare", origin).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v\n%s", err, out)
+	}
+	dir := t.TempDir()
+	testgit.InitRepo(t, dir)
+	for _, args := range [][]string{{"remote", "add", "origin", origin}, {"checkout", "-b", branch}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func TestOpenPRPushesAndReturnsURL(t *testing.T) {
+	log := fakeGH(t, true)
+	dir := repoWithOrigin(t, "feature/invites")
+
+	url, err := workdir.OpenPR(dir, "feature/invites", "Invites", "Completed 1/1 stories.")
+	if err != nil {
+		t.Fatalf("OpenPR() error = %v", err)
+	}
+	if url != "https://github.com/acme/app/pull/7" {
+		t.Errorf("OpenPR() = %q, want the URL gh printed", url)
+	}
+	calls, _ := os.ReadFile(log)
+	if !strings.Contains(string(calls), "pr create --head feature/invites --title Invites --body Completed 1/1 stories.") {
+		t.Errorf("gh calls = %q, want pr create for the branch", calls)
+	}
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "feature/invites@{upstream}")
+	cmd.Dir = dir
+	if out, err := cmd.Output(); err != nil || strings.TrimSpace(string(out)) != "origin/feature/invites" {
+		t.Errorf("upstream = %q, %v, want the branch pushed to origin", out, err)
+	}
+}
+
+func TestOpenPRReportsUnavailableGH(t *testing.T) {
+	fakeGH(t, false)
+	dir := repoWithOrigin(t, "feature/invites")
+
+	_, err := workdir.OpenPR(dir, "feature/invites", "Invites", "")
+	var unavailable *workdir.GHUnavailableError
+	if !errors.As(err, &unavailable) || !strings.Contains(unavailable.Hint, "gh auth login") {
+		t.Fatalf("OpenPR() error = %v, want a GHUnavailableError pointing at gh auth login", err)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	_, err = workdir.OpenPR(dir, "feature/invites", "Invites", "")
+	if !errors.As(err, &unavailable) || !strings.Contains(unavailable.Hint, "install the GitHub CLI") {
+		t.Fatalf("OpenPR() without gh error = %v, want a GHUnavailableError suggesting installing gh", err)
+	}
+}
@@ -0,0 +1,46 @@
+package workdir_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"ralph/internal/shared/workdir"
+)
+
+func TestSanitizeBranchName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "valid name unchanged", in: "feature/add-login", want: "feature/add-login"},
+		{name: "spaces", in: "feature/add login page", want: "feature/add-login-page"},
+		{name: "uppercase", in: "Feature/Add-Login", want: "feature/add-login"},
+		{name: "slashes kept as components", in: "feature/auth/oauth flow", want: "feature/auth/oauth-flow"},
+		{name: "repeated separators collapse", in: "feature/add  --  login!!", want: "feature/add-login"},
+		{name: "unicode letters kept", in: "feature/Café Crème", want: "feature/café-crème"},
+		{name: "unicode symbols replaced", in: "feature/🚀 launch ✓", want: "feature/launch"},
+		{name: "tilde and colon", in: "feature/v1~2:fix", want: "feature/v1-2-fix"},
+		{name: "glob and caret", in: "feature/what?*^[x]", want: "feature/what-x"},
+		{name: "double dots", in: "feature/a..b", want: "feature/a.b"},
+		{name: "reflog syntax", in: "feature/x@{1}", want: "feature/x-1"},
+		{name: "leading dot component", in: "feature/.hidden", want: "feature/hidden"},
+		{name: "lock suffix", in: "feature/thing.lock", want: "feature/thing"},
+		{name: "hyphen before lock suffix", in: "feature/thing-.lock", want: "feature/thing"},
+		{name: "leading hyphen", in: "-feature/-x", want: "feature/x"},
+		{name: "empty components and trailing slash", in: "/feature//x/", want: "feature/x"},
+		{name: "trailing dot", in: "feature/x.", want: "feature/x"},
+		{name: "surrounding whitespace", in: "  feature/x  ", want: "feature/x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := workdir.SanitizeBranchName(tt.in)
+			if got != tt.want {
+				t.Fatalf("SanitizeBranchName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if err := exec.Command("git", "check-ref-format", "--branch", got).Run(); err != nil {
+				t.Fatalf("git rejects sanitized branch %q: %v", got, err)
+			}
+		})
+	}
+}
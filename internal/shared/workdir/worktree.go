@@ -0,0 +1,43 @@
+package workdir
+
+import (
+	"path/filepath"
+)
+
+// WorktreePath is where RALPH_USE_WORKTREE keeps the isolated checkout for
+// workDir. It lives under .ralph so it stays out of the way of the main
+// checkout and is reused by --resume until the run completes.
+func WorktreePath(workDir string) string {
+	return filepath.Join(workDir, ".ralph", "worktree")
+}
+
+// CreateWorktree adds a git worktree at path for the repository in workDir.
+// An existing branch is checked out there, a missing one is created from
+// HEAD, and an empty branch leaves the worktree on a detached HEAD so the
+// branch can be chosen once the PRD names it.
+func CreateWorktree(workDir, branch, path string) error {
+	args := []string{"worktree", "add"}
+	switch {
+	case branch == "":
+		args = append(args, "--detach", path)
+	case branchExists(workDir, branch):
+		args = append(args, path, branch)
+	default:
+		args = append(args, "-b", branch, path)
+	}
+	_, err := runGitCommand(workDir, args...)
+	return err
+}
+
+// RemoveWorktree deletes the worktree at path, including untracked run
+// state, and prunes its metadata from the repository in workDir. Commits made
+// in the worktree stay on its branch.
+func RemoveWorktree(workDir, path string) error {
+	_, err := runGitCommand(workDir, "worktree", "remove", "--force", path)
+	return err
+}
+
+func branchExists(workDir, branch string) bool {
+	_, err := runGitCommand(workDir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
@@ -0,0 +1,78 @@
+package workdir_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/testgit"
+	"ralph/internal/shared/workdir"
+)
+
+func TestCreateWorktree(t *testing.T) {
+	tests := []struct {
+		name       string
+		branch     string
+		existing   bool
+		wantBranch string
+	}{
+		{name: "new branch", branch: "feature/new", wantBranch: "feature/new"},
+		{name: "existing branch", branch: "feature/old", existing: true, wantBranch: "feature/old"},
+		{name: "detached", branch: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			testgit.InitRepo(t, dir)
+			if tt.existing {
+				if out, err := exec.Command("git", "-C", dir, "branch", tt.branch).CombinedOutput(); err != nil {
+					t.Fatalf("git branch: %v\n%s", err, out)
+				}
+			}
+			path := workdir.WorktreePath(dir)
+
+			if err := workdir.CreateWorktree(dir, tt.branch, path); err != nil {
+				t.Fatalf("CreateWorktree() error = %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(path, "README.md")); err != nil {
+				t.Fatalf("worktree is missing checked-out files: %v", err)
+			}
+			got, err := workdir.CurrentBranchName(path)
+			if tt.wantBranch == "" {
+				if err == nil {
+					t.Fatalf("CurrentBranchName() = %q, want detached HEAD", got)
+				}
+			} else if err != nil || got != tt.wantBranch {
+				t.Fatalf("CurrentBranchName() = %q, %v, want %q", got, err, tt.wantBranch)
+			}
+			if main, _ := workdir.CurrentBranchName(dir); main != "main" {
+				t.Fatalf("main checkout branch = %q, want it left on main", main)
+			}
+		})
+	}
+}
+
+func TestRemoveWorktreeKeepsCommits(t *testing.T) {
+	dir := t.TempDir()
+	testgit.InitRepo(t, dir)
+	path := workdir.WorktreePath(dir)
+	if err := workdir.CreateWorktree(dir, "feature/isolated", path); err != nil {
+		t.Fatal(err)
+	}
+	testgit.WriteFile(t, path, "story.txt", "done\n")
+	testgit.CommitFile(t, path, "story.txt", "story")
+	testgit.WriteFile(t, path, "scratch.txt", "untracked\n")
+
+	if err := workdir.RemoveWorktree(dir, path); err != nil {
+		t.Fatalf("RemoveWorktree() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("worktree dir still exists: %v", err)
+	}
+	out, err := exec.Command("git", "-C", dir, "log", "--format=%s", "feature/isolated").CombinedOutput()
+	if err != nil || !strings.Contains(string(out), "story") {
+		t.Fatalf("feature/isolated log = %q, %v, want the worktree commit", out, err)
+	}
+}
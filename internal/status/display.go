@@ -2,11 +2,44 @@ package status
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/prd"
 )
 
+// maxTitleWidth caps the title column so long story titles do not push the
+// rest of the table off narrow terminals.
+const maxTitleWidth = 48
+
+const statusColumn = 4
+
+// tableStyles colors the status table with the TUI palette.
+type tableStyles struct {
+	header  lipgloss.Style
+	done    lipgloss.Style
+	pending lipgloss.Style
+	skipped lipgloss.Style
+}
+
+func newTableStyles(w io.Writer, noColor bool) tableStyles {
+	r := lipgloss.NewRenderer(w)
+	if noColor {
+		r.SetColorProfile(termenv.Ascii)
+	}
+	return tableStyles{
+		header:  r.NewStyle().Bold(true).Foreground(lipgloss.Color("#C084FC")),
+		done:    r.NewStyle().Foreground(lipgloss.Color("#10B981")),
+		pending: r.NewStyle().Foreground(lipgloss.Color("#F59E0B")),
+		skipped: r.NewStyle().Foreground(lipgloss.Color("#9CA3AF")),
+	}
+}
+
 func Display(cfg *config.Config) error {
 
 	exists, err := prd.Exists(cfg)
@@ -36,30 +69,82 @@ func Display(cfg *config.Config) error {
 	fmt.Printf("Stories: %d total, %d completed, %d pending\n",
 		total, completed, pending)
 
-	for _, story := range p.Stories {
-		status := "⏳"
-		if story.Passes {
-			status = "✓"
-		}
+	if total == 0 {
+		return nil
+	}
+	fmt.Println()
+	styles := newTableStyles(os.Stdout, cfg.NoColor || os.Getenv("NO_COLOR") != "")
+	fmt.Print(renderStoryTable(p.Stories, styles))
 
-		fmt.Printf("%s [%s] %s (priority: %d)\n",
-			status, story.ID, story.Title, story.Priority)
+	for _, story := range p.Stories {
 		if len(story.Slices) == 0 {
 			continue
 		}
-		fmt.Printf("  %d/%d slices complete\n", story.CompletedSliceCount(), len(story.Slices))
+		fmt.Printf("\n[%s] %d/%d slices complete\n", story.ID, story.CompletedSliceCount(), len(story.Slices))
 		for _, slice := range story.Slices {
 			sliceStatus := "⏳"
 			if slice.Passes {
 				sliceStatus = "✓"
 			}
-			fmt.Printf("    %s [%s] %s\n", sliceStatus, slice.ID, slice.Behavior)
-			fmt.Printf("      Red hint: %s\n", slice.RedHint)
+			fmt.Printf("  %s [%s] %s\n", sliceStatus, slice.ID, slice.Behavior)
+			fmt.Printf("    Red hint: %s\n", slice.RedHint)
 			if slice.RefactorHint != "" {
-				fmt.Printf("      Refactor hint: %s\n", slice.RefactorHint)
+				fmt.Printf("    Refactor hint: %s\n", slice.RefactorHint)
 			}
 		}
 	}
 
 	return nil
 }
+
+// renderStoryTable lays the stories out in aligned columns. Widths are
+// measured before styling so color codes do not throw off the alignment.
+func renderStoryTable(stories []*prd.Story, styles tableStyles) string {
+	rows := [][]string{{"ID", "TITLE", "PRIORITY", "COMPLEXITY", "STATUS", "SLICES"}}
+	var rowStyles []lipgloss.Style
+	for _, story := range stories {
+		label, style := "⏳ pending", styles.pending
+		if story.Passes {
+			label, style = "✓ done", styles.done
+		} else if story.Skip {
+			label, style = prd.SkippedGlyph+" skipped", styles.skipped
+		}
+		slices := "-"
+		if len(story.Slices) > 0 {
+			slices = fmt.Sprintf("%d/%d", story.CompletedSliceCount(), len(story.Slices))
+		}
+		rows = append(rows, []string{story.ID, truncateTitle(story.Title), fmt.Sprint(story.Priority), fmt.Sprint(story.Complexity()), label, slices})
+		rowStyles = append(rowStyles, style)
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var b strings.Builder
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = cell + strings.Repeat(" ", widths[i]-lipgloss.Width(cell))
+		}
+		if r == 0 {
+			b.WriteString(styles.header.Render(strings.TrimRight(strings.Join(cells, "  "), " ")) + "\n")
+			continue
+		}
+		// Only the status cell is colored so IDs and titles stay readable.
+		cells[statusColumn] = rowStyles[r-1].Render(cells[statusColumn])
+		b.WriteString(strings.TrimRight(strings.Join(cells, "  "), " ") + "\n")
+	}
+	return b.String()
+}
+
+func truncateTitle(title string) string {
+	runes := []rune(title)
+	if len(runes) <= maxTitleWidth {
+		return title
+	}
+	return string(runes[:maxTitleWidth-1]) + "…"
+}
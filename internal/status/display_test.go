@@ -50,13 +50,13 @@ func TestDisplay(t *testing.T) {
 		for _, want := range []string{
 			"Project: Test Project (Branch: main)",
 			"Stories: 2 total, 1 completed, 1 pending",
-			"✓ [story-1] Completed story (priority: 1)",
-			"⏳ [story-2] Pending story (priority: 2)",
 		} {
 			if !strings.Contains(output, want) {
 				t.Errorf("output missing %q\ngot: %s", want, output)
 			}
 		}
+		assertTableRow(t, output, "story-1", "story-1  Completed story  1         6           ✓ done      1/1")
+		assertTableRow(t, output, "story-2", "story-2  Pending story    2         6           ⏳ pending  0/1")
 	})
 
 	t.Run("PRD without branch name", func(t *testing.T) {
@@ -128,6 +128,33 @@ func TestDisplay_AllCompletedStories(t *testing.T) {
 	}
 }
 
+func TestDisplay_SkippedStories(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{PRDFile: "prd.json", WorkDir: tmpDir}
+
+	testPRD := &prd.PRD{
+		ProjectName: "Skipped",
+		Stories: []*prd.Story{
+			{ID: "story-1", Title: "First", Priority: 1, Passes: true, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "first", RedHint: "add failing test", Passes: true}}},
+			{ID: "story-2", Title: "Wrong", Priority: 2, Skip: true, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "second", RedHint: "add failing test"}}},
+		},
+	}
+	if err := prd.Save(cfg, testPRD); err != nil {
+		t.Fatalf("Failed to save test PRD: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := Display(cfg); err != nil {
+			t.Errorf("Display() returned error: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "Stories: 2 total, 2 completed, 0 pending") {
+		t.Errorf("skipped story should not count as pending, got: %s", output)
+	}
+	assertTableRow(t, output, "story-2", "story-2  Wrong  2         6           ⊘ skipped  0/1")
+}
+
 func TestDisplay_ShowsSliceProgress(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := &config.Config{PRDFile: "prd.json", WorkDir: tmpDir}
@@ -157,7 +184,7 @@ func TestDisplay_ShowsSliceProgress(t *testing.T) {
 	})
 
 	for _, want := range []string{
-		"1/2 slices complete",
+		"[story-1] 1/2 slices complete",
 		"red first",
 		"refactor second",
 		"extract helper",
@@ -184,3 +211,54 @@ func TestDisplay_CorruptedPRD(t *testing.T) {
 		t.Errorf("expected error containing 'failed to load PRD', got: %v", err)
 	}
 }
+
+func TestDisplay_AlignsTableAndTruncatesLongTitles(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{PRDFile: "prd.json", WorkDir: tmpDir, NoColor: true}
+
+	long := strings.Repeat("very long title ", 5)
+	testPRD := &prd.PRD{
+		ProjectName: "Table",
+		Stories: []*prd.Story{
+			{ID: "s1", Title: long, Priority: 1, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "a", RedHint: "test"}}},
+			{ID: "story-22", Title: "Short", Priority: 10, Passes: true, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "b", RedHint: "test", Passes: true}}},
+		},
+	}
+	if err := prd.Save(cfg, testPRD); err != nil {
+		t.Fatalf("Failed to save test PRD: %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := Display(cfg); err != nil {
+			t.Errorf("Display() returned error: %v", err)
+		}
+	})
+
+	if strings.Contains(output, "\x1b[") {
+		t.Errorf("NoColor output contains escape codes: %q", output)
+	}
+	if strings.Contains(output, long) || !strings.Contains(output, "…") {
+		t.Errorf("long title was not truncated with an ellipsis:\n%s", output)
+	}
+	header := tableLine(output, "ID")
+	row := tableLine(output, "story-22")
+	if header == "" || row == "" || strings.Index(header, "STATUS") != strings.Index(row, "✓ done") {
+		t.Errorf("STATUS column is not aligned:\n%s\n%s", header, row)
+	}
+}
+
+func tableLine(output, prefix string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, prefix+" ") {
+			return line
+		}
+	}
+	return ""
+}
+
+func assertTableRow(t *testing.T, output, id, want string) {
+	t.Helper()
+	if got := tableLine(output, id); got != want {
+		t.Errorf("table row for %s = %q, want %q\ngot: %s", id, got, want, output)
+	}
+}
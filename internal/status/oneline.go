@@ -0,0 +1,37 @@
+package status
+
+import (
+	"fmt"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+)
+
+// Oneline returns a compact progress string for shell prompts and status bars,
+// e.g. "ralph: 3/5 ✓ (1 failed)", or "ralph: idle" when no PRD exists. The
+// failed count is left out when no story has failed. ascii swaps the check
+// mark for plain text.
+func Oneline(cfg *config.Config, ascii bool) (string, error) {
+	exists, err := prd.Exists(cfg)
+	if err != nil {
+		return "", fmt.Errorf("checking PRD file: %w", err)
+	}
+	if !exists {
+		return "ralph: idle", nil
+	}
+
+	p, err := prd.Load(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to load PRD: %w", err)
+	}
+
+	mark := "✓"
+	if ascii {
+		mark = "done"
+	}
+	line := fmt.Sprintf("ralph: %d/%d %s", p.CompletedCount(), len(p.Stories), mark)
+	if failed := len(p.FailedStories()); failed > 0 {
+		line += fmt.Sprintf(" (%d failed)", failed)
+	}
+	return line, nil
+}
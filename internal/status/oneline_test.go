@@ -0,0 +1,55 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+)
+
+func oneSlice(passes bool) []*prd.Slice {
+	return []*prd.Slice{{ID: "slice-1", Behavior: "b", RedHint: "add failing test", Passes: passes}}
+}
+
+func TestOneline(t *testing.T) {
+	mixed := &prd.PRD{ProjectName: "Mixed", Stories: []*prd.Story{
+		{ID: "s1", Title: "One", Priority: 1, Passes: true, Slices: oneSlice(true)},
+		{ID: "s2", Title: "Two", Priority: 2, Passes: true, Slices: oneSlice(true)},
+		{ID: "s3", Title: "Three", Priority: 3, Slices: oneSlice(false)},
+	}}
+	withFailure := &prd.PRD{ProjectName: "Failed", Stories: []*prd.Story{
+		{ID: "s1", Title: "One", Priority: 1, Passes: true, Slices: oneSlice(true)},
+		{ID: "s2", Title: "Two", Priority: 2, StartedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Slices: oneSlice(false)},
+		{ID: "s3", Title: "Three", Priority: 3, Slices: oneSlice(false)},
+	}}
+
+	tests := []struct {
+		name  string
+		prd   *prd.PRD
+		ascii bool
+		want  string
+	}{
+		{name: "no PRD", want: "ralph: idle"},
+		{name: "mixed PRD", prd: mixed, want: "ralph: 2/3 ✓"},
+		{name: "mixed PRD ascii", prd: mixed, ascii: true, want: "ralph: 2/3 done"},
+		{name: "failed story", prd: withFailure, want: "ralph: 1/3 ✓ (1 failed)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{PRDFile: "prd.json", WorkDir: t.TempDir()}
+			if tt.prd != nil {
+				if err := prd.Save(cfg, tt.prd); err != nil {
+					t.Fatalf("Save PRD: %v", err)
+				}
+			}
+			got, err := Oneline(cfg, tt.ascii)
+			if err != nil {
+				t.Fatalf("Oneline() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Oneline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"ralph/internal/shared/constants"
 	"ralph/internal/shared/runner"
 )
 
@@ -16,27 +18,39 @@ type Logger struct {
 	height    int
 	verbose   bool
 	streaming bool
+
+	// userScrolled is set once the user scrolls the log up and cleared when
+	// they press End or scroll back to the bottom; until then new lines do
+	// not pull the view down.
+	userScrolled bool
+
+	// rendered caches the wrapped, styled form of each entry in logs so an
+	// append only renders the new line, keeping large buffers cheap.
+	rendered      []string
+	renderedWidth int
 }
 
-func NewLogger(verbose bool) *Logger {
+// NewLogger keeps the last maxLogs lines, or constants.DefaultTUILogLines
+// when maxLogs is not positive.
+func NewLogger(verbose bool, maxLogs int) *Logger {
 	v := viewport.New(80, 10)
 
 	// Border and padding are applied by the surrounding log panel.
 	v.Style = lipgloss.NewStyle()
 
+	if maxLogs <= 0 {
+		maxLogs = constants.DefaultTUILogLines
+	}
 	return &Logger{
 		logView: v,
 		logs:    make([]string, 0),
-		maxLogs: 500,
+		maxLogs: maxLogs,
 		verbose: verbose,
 	}
 }
 
 func (l *Logger) AddLog(line string) {
-	l.logs = append(l.logs, line)
-	if len(l.logs) > l.maxLogs {
-		l.logs = l.logs[1:]
-	}
+	l.appendLog(line)
 	l.refreshLogView()
 }
 
@@ -47,23 +61,26 @@ func (l *Logger) AddOutputLine(line runner.OutputLine) {
 	if line.Append {
 		if len(l.logs) > 0 && l.streaming {
 			l.logs[len(l.logs)-1] += line.Text
+			l.rendered = l.rendered[:len(l.rendered)-1]
 		} else {
-			l.logs = append(l.logs, line.Text)
-			if len(l.logs) > l.maxLogs {
-				l.logs = l.logs[1:]
-			}
+			l.appendLog(line.Text)
 		}
 		l.streaming = true
 	} else {
-		l.logs = append(l.logs, line.Text)
-		if len(l.logs) > l.maxLogs {
-			l.logs = l.logs[1:]
-		}
+		l.appendLog(line.Text)
 		l.streaming = false
 	}
 	l.refreshLogView()
 }
 
+func (l *Logger) appendLog(line string) {
+	l.logs = append(l.logs, line)
+	if over := len(l.logs) - l.maxLogs; over > 0 {
+		l.logs = l.logs[over:]
+		l.rendered = l.rendered[min(over, len(l.rendered)):]
+	}
+}
+
 func (l *Logger) SetSize(width, logHeight int) {
 	l.width = width
 	l.height = logHeight
@@ -81,43 +98,62 @@ func (l *Logger) GetView() viewport.Model {
 }
 
 func (l *Logger) Update(msg interface{}) (viewport.Model, interface{}) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "end" {
+		l.logView.GotoBottom()
+		l.userScrolled = false
+		return l.logView, nil
+	}
 	var cmd interface{}
 	l.logView, cmd = l.logView.Update(msg)
+	if isScrollUpMsg(msg) {
+		l.userScrolled = true
+	}
+	if l.logView.AtBottom() {
+		l.userScrolled = false
+	}
 	return l.logView, cmd
 }
 
-func (l *Logger) refreshLogView() {
-	wasAtBottom := l.logView.AtBottom()
+// UserScrolled reports whether the log is held where the user scrolled it
+// instead of following new output.
+func (l *Logger) UserScrolled() bool {
+	return l.userScrolled
+}
 
+func (l *Logger) refreshLogView() {
 	w := l.logView.Width
 	if w <= 0 {
 
 		// Fall back before the first window size message arrives.
 		w = max(30, l.width-6)
 	}
-
-	lines := make([]string, 0, len(l.logs))
-	for _, logText := range l.logs {
-
-		line := wrapText(logText, max(10, w-2))
-		style := logLineStyle
-
-		lowerText := strings.ToLower(logText)
-		if strings.Contains(lowerText, "error") || strings.Contains(lowerText, "failed") || strings.Contains(lowerText, "failure") {
-			style = logErrorStyle
-		} else if strings.Contains(lowerText, "completed") || strings.Contains(lowerText, "success") || strings.Contains(lowerText, "done") {
-			style = logSuccessStyle
-		} else if strings.Contains(lowerText, "starting") || strings.Contains(lowerText, "generating") || strings.Contains(lowerText, "running") {
-			style = logInfoStyle
-		} else if strings.Contains(lowerText, "warning") || strings.Contains(lowerText, "warn") {
-			style = logLineStyle.Foreground(warningColor)
-		}
-
-		lines = append(lines, style.Render(line))
+	if w != l.renderedWidth {
+		l.rendered = l.rendered[:0]
+		l.renderedWidth = w
+	}
+	for _, logText := range l.logs[len(l.rendered):] {
+		l.rendered = append(l.rendered, renderLogLine(logText, w))
 	}
 
-	l.logView.SetContent(strings.Join(lines, "\n"))
-	if wasAtBottom {
+	l.logView.SetContent(strings.Join(l.rendered, "\n"))
+	if !l.userScrolled {
 		l.logView.GotoBottom()
 	}
 }
+
+func renderLogLine(logText string, width int) string {
+	line := wrapText(logText, max(10, width-2))
+	style := logLineStyle
+
+	lowerText := strings.ToLower(logText)
+	if strings.Contains(lowerText, "error") || strings.Contains(lowerText, "failed") || strings.Contains(lowerText, "failure") {
+		style = logErrorStyle
+	} else if strings.Contains(lowerText, "completed") || strings.Contains(lowerText, "success") || strings.Contains(lowerText, "done") {
+		style = logSuccessStyle
+	} else if strings.Contains(lowerText, "starting") || strings.Contains(lowerText, "generating") || strings.Contains(lowerText, "running") {
+		style = logInfoStyle
+	} else if strings.Contains(lowerText, "warning") || strings.Contains(lowerText, "warn") {
+		style = logLineStyle.Foreground(warningColor)
+	}
+	return style.Render(line)
+}
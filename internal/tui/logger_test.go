@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+
 	"ralph/internal/shared/runner"
 )
 
@@ -12,7 +15,7 @@ func TestLoggerWrapsLongLine(t *testing.T) {
 	first30 := logText[:30]
 	last30 := logText[len(logText)-30:]
 
-	l := NewLogger(false)
+	l := NewLogger(false, 0)
 	l.SetSize(80, 10)
 	l.AddLog(logText)
 
@@ -29,7 +32,7 @@ func TestLoggerWrapsLongLine(t *testing.T) {
 }
 
 func TestLoggerAppendsStreamingDeltas(t *testing.T) {
-	l := NewLogger(false)
+	l := NewLogger(false, 0)
 	l.SetSize(80, 10)
 
 	for _, chunk := range []string{"I'm ", "fixing ", "that first."} {
@@ -54,7 +57,7 @@ func TestLoggerAppendsStreamingDeltas(t *testing.T) {
 }
 
 func TestLoggerAppendStartsNewLineAfterDiscreteOutput(t *testing.T) {
-	l := NewLogger(false)
+	l := NewLogger(false, 0)
 	l.SetSize(80, 10)
 
 	l.AddOutputLine(runner.OutputLine{Text: "hello", Append: true})
@@ -72,7 +75,7 @@ func TestLoggerAppendStartsNewLineAfterDiscreteOutput(t *testing.T) {
 }
 
 func TestLoggerAppendStartsNewStreamAfterToolLine(t *testing.T) {
-	l := NewLogger(false)
+	l := NewLogger(false, 0)
 	l.SetSize(80, 10)
 
 	l.AddOutputLine(runner.OutputLine{Text: "Using tool: bash"})
@@ -89,3 +92,96 @@ func TestLoggerAppendStartsNewStreamAfterToolLine(t *testing.T) {
 		t.Fatalf("logs[1] = %q, want %q", l.logs[1], "I'm back.")
 	}
 }
+
+func TestLoggerKeepsConfiguredLineCount(t *testing.T) {
+	l := NewLogger(false, 3)
+	l.SetSize(80, 4)
+
+	for _, line := range []string{"one", "two", "three", "four", "five"} {
+		l.AddLog(line)
+	}
+
+	if got := strings.Join(l.logs, ","); got != "three,four,five" {
+		t.Fatalf("logs = %q, want the last 3 lines", got)
+	}
+	if len(l.rendered) != len(l.logs) {
+		t.Fatalf("rendered cache has %d lines, want %d", len(l.rendered), len(l.logs))
+	}
+	view := l.GetView().View()
+	if !strings.Contains(view, "five") || strings.Contains(view, "two") {
+		t.Fatalf("view should stay at the newest line, got %q", view)
+	}
+}
+
+func TestLoggerRerendersOnResize(t *testing.T) {
+	l := NewLogger(false, 0)
+	l.SetSize(80, 10)
+	l.AddLog(strings.Repeat("word ", 20))
+	l.SetSize(40, 10)
+
+	for _, line := range strings.Split(l.GetView().View(), "\n") {
+		if width := len(strings.TrimRight(line, " ")); width > 40 {
+			t.Fatalf("line %q is %d wide after shrinking to 40", line, width)
+		}
+	}
+}
+
+func BenchmarkLoggerAddLogLargeBuffer(b *testing.B) {
+	l := NewLogger(false, 10000)
+	l.SetSize(120, 40)
+	for i := range 10000 {
+		l.AddLog(fmt.Sprintf("line %d of runner output", i))
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		l.AddLog(fmt.Sprintf("line %d of runner output", i))
+	}
+}
+
+func TestLoggerHoldsPositionAfterUserScrollsUp(t *testing.T) {
+	l := NewLogger(false, 0)
+	l.SetSize(80, 6)
+	for i := 0; i < 30; i++ {
+		l.AddLog(fmt.Sprintf("line %d", i))
+	}
+	if !l.GetView().AtBottom() {
+		t.Fatal("log should follow new output before the user scrolls")
+	}
+
+	l.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if !l.UserScrolled() {
+		t.Fatal("PageUp should mark the log as scrolled by the user")
+	}
+	offset := l.GetView().YOffset
+	l.AddLog("new line")
+	l.SetSize(80, 5)
+	if got := l.GetView().YOffset; got != offset || l.GetView().AtBottom() {
+		t.Fatalf("YOffset = %d after new output, want it held at %d", got, offset)
+	}
+
+	l.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if l.UserScrolled() || !l.GetView().AtBottom() {
+		t.Fatal("End should jump to the bottom and resume following")
+	}
+	l.AddLog("another line")
+	if !l.GetView().AtBottom() {
+		t.Fatal("log should follow new output again after End")
+	}
+}
+
+func TestLoggerResumesFollowingWhenScrolledBackToBottom(t *testing.T) {
+	l := NewLogger(false, 0)
+	l.SetSize(80, 6)
+	for i := 0; i < 30; i++ {
+		l.AddLog(fmt.Sprintf("line %d", i))
+	}
+
+	l.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if !l.UserScrolled() {
+		t.Fatal("up should mark the log as scrolled by the user")
+	}
+	l.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if l.UserScrolled() {
+		t.Fatal("scrolling back to the bottom should resume following")
+	}
+}
@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
 	"ralph/internal/prompt"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/session"
@@ -9,12 +13,18 @@ import (
 type (
 	phaseChangeMsg Phase
 
+	// tickMsg drives the once-a-second elapsed timer for the running story.
+	tickMsg time.Time
+
 	resumeStartMsg struct {
 		phase    Phase
 		prd      *prd.PRD
 		snapshot session.RunSnapshot
 	}
 
+	// runnerHeaderMsg is the runner and backend version line logged at startup.
+	runnerHeaderMsg string
+
 	operationErrorMsg struct {
 		err error
 	}
@@ -24,3 +34,10 @@ type (
 		answersCh chan<- []prompt.QuestionAnswer
 	}
 )
+
+// storyTimerInterval is the resolution of the running-story elapsed timer.
+const storyTimerInterval = time.Second
+
+func tickEvery() tea.Cmd {
+	return tea.Tick(storyTimerInterval, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"ralph/internal/shared/config"
@@ -131,9 +133,22 @@ func TestInitEmptyPromptAwaitingPhase(t *testing.T) {
 	}
 }
 
-func TestInitWithPromptStartsWorkflow(t *testing.T) {
+// workflowTestConfig runs a test that starts the workflow in a temp work dir
+// with the mock runner, so it never invokes a real runner CLI or leaves a PRD,
+// lock files and branches behind in the package directory.
+func workflowTestConfig(t *testing.T) *config.Config {
+	t.Helper()
 	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.Runner = "mock"
+	return cfg
+}
+
+func TestInitWithPromptStartsWorkflow(t *testing.T) {
+	cfg := workflowTestConfig(t)
 	m := NewModel(cfg, "build api", false, false, false)
+	om := m.operationManager
+	t.Cleanup(func() { waitSessionDone(t, om) })
 
 	_ = m.Init()
 
@@ -165,8 +180,10 @@ func TestInitResumeNeverPhaseAwaitingPrompt(t *testing.T) {
 }
 
 func TestInitResumeEmptyPromptStartsWorkflow(t *testing.T) {
-	cfg := config.DefaultConfig()
+	cfg := workflowTestConfig(t)
 	m := NewModel(cfg, "", false, true, false)
+	om := m.operationManager
+	t.Cleanup(func() { waitSessionDone(t, om) })
 
 	_ = m.Init()
 
@@ -291,7 +308,7 @@ func TestResumeMainPaneShowsActiveStorySliceProgressFromSnapshot(t *testing.T) {
 
 func awaitingPromptModel(t *testing.T) *Model {
 	t.Helper()
-	cfg := config.DefaultConfig()
+	cfg := workflowTestConfig(t)
 	m := NewModel(cfg, "", false, false, false)
 	_ = m.Init()
 	return m
@@ -341,6 +358,8 @@ func TestUpdateAwaitingPromptEnterStartsWorkflowOnce(t *testing.T) {
 	if cmd == nil {
 		t.Fatal("expected StartFullOperation cmd")
 	}
+	om := m.operationManager
+	t.Cleanup(func() { waitSessionDone(t, om) })
 
 	msg := cmd()
 	pcm, ok := msg.(phaseChangeMsg)
@@ -432,6 +451,29 @@ func TestUpdatePRDGeneratedMsgDryRun(t *testing.T) {
 	}
 }
 
+func TestUpdatePRDGeneratedMsgDryRunLogsCompletionLineOnce(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, "test", true, false, false)
+
+	testPRD := &prd.PRD{ProjectName: "Test", Stories: []*prd.Story{{ID: "1"}, {ID: "2"}}}
+	m.handleWorkflowEvent(events.EventPRDGenerated{PRD: testPRD})
+	m.handleWorkflowEvent(events.EventOutput{Output: events.Output{Text: events.DryRunCompleteLine(2, cfg.PRDFile)}})
+
+	want := "Dry run complete: 2 stories, saved to " + cfg.PRDFile
+	count := 0
+	for _, line := range m.logger.logs {
+		if strings.HasPrefix(line, "Dry run complete") {
+			count++
+			if line != want {
+				t.Errorf("log line = %q, want %q", line, want)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("dry-run completion logged %d times, want 1", count)
+	}
+}
+
 func TestUpdatePRDGeneratedMsgDryRunTUIPromptSubmit(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "", true, false, false)
@@ -552,6 +594,33 @@ func TestUpdateSpinnerTickMsg(t *testing.T) {
 	}
 }
 
+func TestUpdateImplementationPauseKeyTogglesPause(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, "test", false, false, false)
+	m.phase = PhaseImplementation
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	model := newModel.(*Model)
+	if !model.paused || !model.operationManager.Paused() {
+		t.Fatalf("paused = %v, executor paused = %v; want both true", model.paused, model.operationManager.Paused())
+	}
+	if !strings.Contains(model.renderPhase(), "PAUSED") {
+		t.Fatalf("renderPhase() = %q, want PAUSED indicator", model.renderPhase())
+	}
+	if _, cmd := model.Update(model.spinner.Tick()); cmd == nil {
+		t.Fatal("spinner should keep ticking while paused")
+	}
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	model = newModel.(*Model)
+	if model.paused || model.operationManager.Paused() {
+		t.Fatal("second p should resume")
+	}
+	if strings.Contains(model.renderPhase(), "PAUSED") {
+		t.Fatalf("renderPhase() = %q, want no PAUSED indicator after resume", model.renderPhase())
+	}
+}
+
 func TestUpdatePRDReviewCritiqueKeyOpensInputMode(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "test", false, false, false)
@@ -636,3 +705,64 @@ func TestUpdatePRDReviewCritiqueEscClearsDraftInput(t *testing.T) {
 		t.Fatalf("critiqueInput = %q, want cleared draft input", model.critiqueInput.Value())
 	}
 }
+
+func TestSpinnerOffUsesStaticGlyphWithoutTicks(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Spinner = config.SpinnerOff
+	m := NewModel(cfg, "", false, false, false)
+	m.phase = PhaseImplementation
+
+	if m.spinnerEnabled() {
+		t.Fatal("spinner should be disabled when cfg.Spinner is off")
+	}
+	if got := m.renderPhase(); !strings.Contains(got, iconInProgress) {
+		t.Fatalf("renderPhase() = %q, want static glyph %q", got, iconInProgress)
+	}
+
+	batch, ok := m.Init()().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Init() should return a batch command")
+	}
+	for _, cmd := range batch {
+		if cmd == nil {
+			continue
+		}
+		msgCh := make(chan tea.Msg, 1)
+		go func() { msgCh <- cmd() }()
+		select {
+		case msg := <-msgCh:
+			if _, isTick := msg.(spinner.TickMsg); isTick {
+				t.Fatal("Init() should not schedule spinner ticks when the spinner is off")
+			}
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func TestNewSpinnerAdjustsTickInterval(t *testing.T) {
+	tests := []struct {
+		mode string
+		want time.Duration
+	}{
+		{mode: "", want: spinner.Dot.FPS},
+		{mode: config.SpinnerSlow, want: time.Second / 2},
+		{mode: config.SpinnerFast, want: time.Second / 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if got := newSpinner(tt.mode).Spinner.FPS; got != tt.want {
+				t.Fatalf("FPS = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateRunnerHeaderMsgLogsLine(t *testing.T) {
+	m := NewModel(config.DefaultConfig(), "test", false, false, false)
+
+	m.Update(runnerHeaderMsg("Runner: Claude Code (claude 2.0.14)"))
+
+	if n := len(m.logger.logs); n == 0 || m.logger.logs[n-1] != "Runner: Claude Code (claude 2.0.14)" {
+		t.Errorf("logs = %q, want the runner header logged", m.logger.logs)
+	}
+}
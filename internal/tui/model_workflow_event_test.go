@@ -3,9 +3,12 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"ralph/internal/shared/clock/clocktest"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
 	"ralph/internal/shared/session"
 	"ralph/internal/workflow/events"
 )
@@ -23,6 +26,27 @@ func TestHandleWorkflowEventPRDGenerating(t *testing.T) {
 	}
 }
 
+func TestHandleWorkflowEventPRDGeneratingShowsNewProjectIndicator(t *testing.T) {
+	tests := []struct {
+		name       string
+		newProject bool
+	}{
+		{name: "new project", newProject: true},
+		{name: "existing codebase", newProject: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(config.DefaultConfig(), "test", false, false, false)
+
+			m.handleWorkflowEvent(events.EventPRDGenerating{NewProject: tt.newProject})
+
+			if got := strings.Contains(m.renderHeader(), "[new project]"); got != tt.newProject {
+				t.Errorf("header shows new project indicator = %v, want %v", got, tt.newProject)
+			}
+		})
+	}
+}
+
 func TestHandleWorkflowEventPRDGenerated(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "test", false, false, false)
@@ -39,12 +63,20 @@ func TestHandleWorkflowEventPRDLoaded(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "test", false, false, false)
 
-	testPRD := &prd.PRD{ProjectName: "Test", Stories: []*prd.Story{{ID: "1", Passes: true}}}
+	testPRD := &prd.PRD{ProjectName: "Test", Iterations: 4, Stories: []*prd.Story{{ID: "1", Passes: true}}}
 	m.handleWorkflowEvent(events.EventPRDLoaded{PRD: testPRD})
 
 	if m.prd != testPRD {
 		t.Error("prd should be set")
 	}
+	if m.iteration != 4 {
+		t.Errorf("iteration = %d, want 4 from the loaded PRD", m.iteration)
+	}
+
+	m.handleWorkflowEvent(events.EventStoryStarted{Story: &prd.Story{ID: "2", Title: "Next"}})
+	if m.iteration != 5 {
+		t.Errorf("iteration = %d, want 5 after the next story starts", m.iteration)
+	}
 }
 
 func TestHandleWorkflowEventStoryStarted(t *testing.T) {
@@ -207,6 +239,30 @@ func TestHandleWorkflowEventStoryCompletedFailure(t *testing.T) {
 	}
 }
 
+func TestHandleWorkflowEventStoryCompletedLogsResult(t *testing.T) {
+	tests := []struct {
+		result  events.StoryResult
+		success bool
+		want    string
+	}{
+		{result: events.StoryPassed, success: true, want: "Completed: Test"},
+		{result: events.StoryFailedRetryable, want: "Requeued: Test"},
+		{result: events.StoryFailedExhausted, want: "Failed: Test"},
+		{result: events.StoryTimedOut, want: "Timed out: Test"},
+		{result: events.StoryBlocked, want: "Blocked: Test"},
+		{result: events.StorySkipped, want: "Skipped: Test"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.result), func(t *testing.T) {
+			m := NewModel(config.DefaultConfig(), "test", false, false, false)
+			m.handleWorkflowEvent(events.EventStoryCompleted{Story: &prd.Story{ID: "1", Title: "Test"}, Success: tt.success, Result: tt.result})
+			if got := m.logger.logs[len(m.logger.logs)-1]; !strings.Contains(got, tt.want) {
+				t.Fatalf("last log = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestHandleWorkflowEventCompleted(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "test", false, false, false)
@@ -227,6 +283,37 @@ func TestHandleWorkflowEventCompleted(t *testing.T) {
 	}
 }
 
+func TestHandleWorkflowEventTerminalLogsSummaryLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		event events.Event
+		want  string
+	}{
+		{name: "completed", event: events.EventCompleted{}, want: `RALPH_SUMMARY project="Demo" total=2 completed=1 failed=0 iterations=1 status=completed`},
+		{name: "failed", event: events.EventError{Err: &testErrorType{msg: "boom"}}, want: `RALPH_SUMMARY project="Demo" total=2 completed=1 failed=0 iterations=1 status=failed`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = t.TempDir()
+			if err := prd.Save(cfg, &prd.PRD{ProjectName: "Demo", Iterations: 1, Stories: []*prd.Story{
+				{ID: "story-1", Title: "One", Priority: 1, Passes: true, Slices: prdtest.Slices("works")},
+				{ID: "story-2", Title: "Two", Priority: 2, Slices: prdtest.Slices("works")},
+			}}); err != nil {
+				t.Fatal(err)
+			}
+			m := NewModel(cfg, "test", false, false, false)
+			m.phase = PhaseImplementation
+
+			m.handleWorkflowEvent(tt.event)
+
+			if got := m.logger.logs[len(m.logger.logs)-1]; got != tt.want {
+				t.Fatalf("last log = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestHandleWorkflowEventError(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "test", false, false, false)
@@ -347,3 +434,106 @@ func TestUpdateWorkflowEventMsg(t *testing.T) {
 		t.Error("should return command to listen for more events")
 	}
 }
+
+func TestStoryTimerTracksRunningStory(t *testing.T) {
+	m := NewModel(config.DefaultConfig(), "test", false, false, false)
+	story := &prd.Story{ID: "story-1", Title: "One", Slices: prdtest.Slices("works")}
+	m.prd = &prd.PRD{ProjectName: "Timer", Stories: []*prd.Story{story}}
+	m.width, m.height = 100, 40
+
+	m.handleWorkflowEvent(events.EventStoryStarted{Story: story})
+	if m.storyStartedAt.IsZero() {
+		t.Fatal("EventStoryStarted should start the story timer")
+	}
+	m.Update(tickMsg(m.storyStartedAt.Add(65 * time.Second)))
+	if got := m.renderImplementation(); !strings.Contains(got, "current story running for 1m 5s") {
+		t.Fatalf("renderImplementation() missing elapsed timer:\n%s", got)
+	}
+
+	m.handleWorkflowEvent(events.EventStoryCompleted{Story: story, Success: true, Result: events.StoryPassed})
+	if got := m.renderImplementation(); strings.Contains(got, "current story running") {
+		t.Fatalf("timer still shown after the story completed:\n%s", got)
+	}
+}
+
+func TestFormatStoryElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{42*time.Second + 900*time.Millisecond, "42s"},
+		{3*time.Minute + 7*time.Second, "3m 7s"},
+		{2*time.Hour + 5*time.Minute + 30*time.Second, "2h 5m"},
+	}
+	for _, tt := range tests {
+		if got := formatStoryElapsed(tt.d); got != tt.want {
+			t.Errorf("formatStoryElapsed(%s) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestProgressSectionShowsETAAfterFirstStory(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+
+	var stories []*prd.Story
+	for _, id := range []string{"1", "2", "3"} {
+		stories = append(stories, &prd.Story{ID: id, Title: "Story " + id, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "works", RedHint: "add failing test"}}})
+	}
+	if err := prd.Save(cfg, &prd.PRD{ProjectName: "ETA", Stories: stories}); err != nil {
+		t.Fatalf("Save PRD: %v", err)
+	}
+	clk := clocktest.NewFake(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	m := NewModel(cfg, "test", false, false, false)
+	m.prd = &prd.PRD{ProjectName: "ETA", Stories: stories}
+	m.width, m.height = 100, 40
+	m.clock = clk
+
+	if got := m.renderProgressSection(); strings.Contains(got, "remaining") {
+		t.Fatalf("ETA shown before any story completed:\n%s", got)
+	}
+
+	m.handleWorkflowEvent(events.EventStoryStarted{Story: stories[0]})
+	clk.Advance(4 * time.Minute)
+	stories[0].Passes = true
+	stories[0].Slices[0].Passes = true
+	if err := prd.Save(cfg, &prd.PRD{ProjectName: "ETA", Stories: stories}); err != nil {
+		t.Fatalf("Save PRD: %v", err)
+	}
+	m.handleWorkflowEvent(events.EventStoryCompleted{Story: stories[0], Success: true, Result: events.StoryPassed})
+
+	if got := m.renderProgressSection(); !strings.Contains(got, "1/3 stories • ~8m remaining") {
+		t.Fatalf("renderProgressSection() missing ETA for two 4m stories:\n%s", got)
+	}
+}
+
+func TestRemainingEstimate(t *testing.T) {
+	tests := []struct {
+		average   time.Duration
+		timed     int
+		remaining int
+		want      string
+	}{
+		{time.Minute, 0, 3, ""},
+		{time.Minute, 1, 0, ""},
+		{20 * time.Second, 1, 1, "~1m remaining"},
+		{90 * time.Second, 2, 3, "~5m remaining"},
+		{25 * time.Minute, 1, 3, "~1h 15m remaining"},
+	}
+	for _, tt := range tests {
+		m := &Model{averageStoryDuration: tt.average, storiesTimed: tt.timed}
+		if got := m.remainingEstimate(tt.remaining); got != tt.want {
+			t.Errorf("remainingEstimate(%d) with %s average = %q, want %q", tt.remaining, tt.average, got, tt.want)
+		}
+	}
+}
+
+func TestRecordStoryDurationKeepsRunningAverage(t *testing.T) {
+	m := &Model{}
+	m.recordStoryDuration(2 * time.Minute)
+	m.recordStoryDuration(4 * time.Minute)
+	if m.storiesTimed != 2 || m.averageStoryDuration != 3*time.Minute {
+		t.Fatalf("average = %s over %d stories, want 3m0s over 2", m.averageStoryDuration, m.storiesTimed)
+	}
+}
@@ -35,6 +35,14 @@ func (om *OperationManager) StartFullOperation(resume bool, userPrompt string) t
 	}
 }
 
+// RunnerHeader queries the runner's backend version off the UI goroutine, since
+// it shells out to the CLI.
+func (om *OperationManager) RunnerHeader() tea.Cmd {
+	return func() tea.Msg {
+		return runnerHeaderMsg(om.Session.RunnerHeader(context.Background()))
+	}
+}
+
 func (om *OperationManager) resumeStartMsg() tea.Msg {
 	p, err := prd.Load(om.cfg)
 	if err != nil {
@@ -96,9 +104,11 @@ func (om *OperationManager) StartImplementation(p *prd.PRD) tea.Cmd {
 	}
 }
 
-func (om *OperationManager) ApproveReview() tea.Cmd {
+// ApproveReview starts implementation, first marking the stories in skip as
+// skipped.
+func (om *OperationManager) ApproveReview(skip []string) tea.Cmd {
 	return func() tea.Msg {
-		if err := om.Session.ApproveReview(context.Background(), om.cfg); err != nil {
+		if err := om.Session.ApproveReviewSkipping(context.Background(), om.cfg, skip); err != nil {
 			return operationErrorMsg{err: err}
 		}
 		return nil
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"ralph/internal/shared/runner"
 	"ralph/internal/shared/runstate"
 	"ralph/internal/shared/session"
+	"ralph/internal/shared/testgit"
 	"ralph/internal/workflow/events"
 )
 
@@ -266,7 +268,7 @@ func TestPRDReviewEnterApprovesWithoutInMemoryPRD(t *testing.T) {
 	om := m.operationManager
 	t.Cleanup(func() { waitSessionDone(t, om) })
 
-	msg := om.ApproveReview()()
+	msg := om.ApproveReview(nil)()
 	if msg != nil {
 		if errMsg, ok := msg.(operationErrorMsg); ok {
 			t.Fatalf("ApproveReview() error = %v", errMsg.err)
@@ -282,7 +284,7 @@ func TestApproveReviewReportsMissingPRD(t *testing.T) {
 	om := NewOperationManager(cfg)
 	t.Cleanup(func() { waitSessionDone(t, om) })
 
-	msg := om.ApproveReview()()
+	msg := om.ApproveReview(nil)()
 	errMsg, ok := msg.(operationErrorMsg)
 	if !ok {
 		t.Fatalf("ApproveReview() msg = %T, want operationErrorMsg", msg)
@@ -395,3 +397,99 @@ func TestUpdateOperationErrorMsg(t *testing.T) {
 		t.Errorf("err = %v, want archive error", model.err)
 	}
 }
+
+type cancelOnceRunner struct {
+	runner.NoopRunner
+	started  chan struct{}
+	canceled chan error
+	runs     int
+}
+
+func (r *cancelOnceRunner) Run(ctx context.Context, _ string, _ chan<- runner.OutputLine) error {
+	r.runs++
+	if r.runs > 1 {
+		return nil
+	}
+	close(r.started)
+	<-ctx.Done()
+	r.canceled <- ctx.Err()
+	return ctx.Err()
+}
+
+func TestImplementationCancelKeyRequeuesCurrentStory(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.SkipCleanup = true
+	testgit.InitRepo(t, cfg.WorkDir)
+
+	p := &prd.PRD{
+		ProjectName: "Cancel",
+		BranchName:  "feature/cancel",
+		Stories: []*prd.Story{
+			{ID: "s1", Title: "Only story", Description: "desc", Priority: 1, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "b", RedHint: "add failing test"}}},
+		},
+	}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("Save PRD: %v", err)
+	}
+	testgit.CommitFile(t, cfg.WorkDir, cfg.PRDFile, "add prd")
+
+	r := &cancelOnceRunner{started: make(chan struct{}), canceled: make(chan error, 1)}
+	m := NewModel(cfg, "goal", false, false, false)
+	m.operationManager = &OperationManager{Session: session.NewWithRunner(cfg, r), cfg: cfg}
+	m.phase = PhaseImplementation
+	om := m.operationManager
+	om.StartImplementationFromPRD(context.Background(), p)
+
+	select {
+	case <-r.started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for story runner to start")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	model := updated.(*Model)
+	if model.quitting {
+		t.Fatal("c must cancel the story, not quit")
+	}
+
+	select {
+	case err := <-r.canceled:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("story context err = %v, want context.Canceled", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for story context cancellation")
+	}
+	if om.Ctx().Err() != nil {
+		t.Fatal("c must not cancel the whole run")
+	}
+
+	deadline := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case ev := <-om.EventsCh():
+			model.Update(workflowEventMsg{event: ev})
+			switch ev.(type) {
+			case events.EventCompleted:
+				done = true
+			case events.EventError:
+				t.Fatalf("unexpected error event: %v", ev)
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for requeued story to complete")
+		}
+	}
+	om.Cancel()
+	om.Wait()
+
+	logs := strings.Join(model.logger.logs, "\n")
+	requeued := strings.Index(logs, "Requeued: Only story")
+	completed := strings.Index(logs, "Completed: Only story")
+	if requeued < 0 || completed < requeued {
+		t.Fatalf("logs should show requeue then completion, got:\n%s", logs)
+	}
+	if r.runs != 2 {
+		t.Fatalf("runner runs = %d, want 2", r.runs)
+	}
+}
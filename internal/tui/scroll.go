@@ -42,3 +42,16 @@ func isScrollNavMsg(msg tea.Msg) bool {
 		return false
 	}
 }
+
+// isScrollUpMsg reports whether msg moves a viewport toward the top.
+func isScrollUpMsg(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		km := scrollKeyMap
+		return key.Matches(msg, km.Up) || key.Matches(msg, km.PageUp) || key.Matches(msg, km.HalfPageUp)
+	case tea.MouseMsg:
+		return msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelUp
+	default:
+		return false
+	}
+}
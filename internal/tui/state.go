@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"time"
+
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -8,8 +10,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"ralph/internal/prompt"
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/constants"
 	"ralph/internal/shared/prd"
+	"ralph/internal/shared/runlog"
 	"ralph/internal/shared/runstate"
 	"ralph/internal/shared/session"
 )
@@ -57,6 +62,7 @@ func (p Phase) String() string {
 // Model is the root Bubble Tea model for interactive Ralph.
 type Model struct {
 	cfg     *config.Config
+	clock   clock.Clock
 	prompt  string
 	dryRun  bool
 	resume  bool
@@ -65,6 +71,8 @@ type Model struct {
 	phase        Phase
 	prd          *prd.PRD
 	currentStory *prd.Story
+	iteration    int
+	paused       bool
 	snapshot     session.RunSnapshot
 	activity     session.RunActivity
 	err          error
@@ -72,6 +80,17 @@ type Model struct {
 	width        int
 	height       int
 
+	// storyStartedAt is when the running story started, zero between
+	// stories; lastTick is the latest tickMsg, so the elapsed timer only
+	// changes once a second.
+	storyStartedAt time.Time
+	lastTick       time.Time
+
+	// averageStoryDuration is the mean duration of the storiesTimed stories
+	// that completed successfully this session; it drives the progress ETA.
+	averageStoryDuration time.Duration
+	storiesTimed         int
+
 	spinner  spinner.Model
 	progress progress.Model
 
@@ -92,16 +111,36 @@ type Model struct {
 	critiqueInput  textinput.Model
 	revisingPRD    bool
 
+	// reviewCursor is the highlighted story in the PRD review list, and
+	// reviewDeselected holds the story IDs unchecked there; approving the
+	// review marks them skipped.
+	reviewCursor     int
+	reviewDeselected map[string]bool
+
 	retryImplementation bool
+	unfinished          []string
+	newProject          bool
 
 	logger           *Logger
 	operationManager *OperationManager
+	runLog           *runlog.Log
 }
 
-func NewModel(cfg *config.Config, prompt string, dryRun, resume, verbose bool) *Model {
+func newSpinner(mode string) spinner.Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
+	switch mode {
+	case config.SpinnerSlow:
+		s.Spinner.FPS = time.Second / 2
+	case config.SpinnerFast:
+		s.Spinner.FPS = time.Second / 20
+	}
 	s.Style = lipgloss.NewStyle().Foreground(accentColor)
+	return s
+}
+
+func NewModel(cfg *config.Config, prompt string, dryRun, resume, verbose bool) *Model {
+	s := newSpinner(cfg.Spinner)
 
 	p := progress.New(
 		progress.WithGradient("#A855F7", "#10B981"),
@@ -113,7 +152,7 @@ func NewModel(cfg *config.Config, prompt string, dryRun, resume, verbose bool) *
 	mv.Style = lipgloss.NewStyle()
 	mv.MouseWheelEnabled = true
 
-	logger := NewLogger(verbose)
+	logger := NewLogger(verbose, cfg.LogLines())
 	operationManager := NewOperationManager(cfg)
 
 	critiqueInput := configureTextInput(textinput.New())
@@ -126,6 +165,7 @@ func NewModel(cfg *config.Config, prompt string, dryRun, resume, verbose bool) *
 
 	return &Model{
 		cfg:              cfg,
+		clock:            clock.Real{},
 		prompt:           prompt,
 		dryRun:           dryRun,
 		resume:           resume,
@@ -142,8 +182,16 @@ func NewModel(cfg *config.Config, prompt string, dryRun, resume, verbose bool) *
 	}
 }
 
+// SetRunLog tees workflow events to l for --output-dir. The caller closes it.
+func (m *Model) SetRunLog(l *runlog.Log) {
+	m.runLog = l
+}
+
 func (m *Model) ExitCode() int {
 	if m.phase == PhaseCompleted {
+		if len(m.unfinished) > 0 {
+			return constants.ExitPartialSuccess
+		}
 		return 0
 	}
 	return 1
@@ -0,0 +1,67 @@
+package tui
+
+import "ralph/internal/shared/prd"
+
+// resetStorySelection checks every story again when a new or revised PRD
+// comes up for review.
+func (m *Model) resetStorySelection() {
+	m.reviewCursor = 0
+	m.reviewDeselected = nil
+}
+
+// selectableStory reports whether s can be unchecked in review. Done and
+// already skipped stories are left out of the run anyway.
+func selectableStory(s *prd.Story) bool {
+	return s != nil && !s.Passes && !s.Skip
+}
+
+func (m *Model) storyChecked(s *prd.Story) bool {
+	return selectableStory(s) && !m.reviewDeselected[s.ID]
+}
+
+// handleStorySelectKey moves the review cursor or toggles the story under it,
+// reporting whether key was one of the selector keys.
+func (m *Model) handleStorySelectKey(key string) bool {
+	p := m.activePRD()
+	if p == nil || len(p.Stories) == 0 {
+		return false
+	}
+	switch key {
+	case "up", "k":
+		m.reviewCursor = max(m.reviewCursor-1, 0)
+	case "down", "j":
+		m.reviewCursor = min(m.reviewCursor+1, len(p.Stories)-1)
+	case " ":
+		m.reviewCursor = min(m.reviewCursor, len(p.Stories)-1)
+		s := p.Stories[m.reviewCursor]
+		if !selectableStory(s) {
+			return true
+		}
+		if m.reviewDeselected == nil {
+			m.reviewDeselected = make(map[string]bool)
+		}
+		if m.reviewDeselected[s.ID] {
+			delete(m.reviewDeselected, s.ID)
+		} else {
+			m.reviewDeselected[s.ID] = true
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// deselectedStoryIDs lists the stories unchecked in review, in PRD order.
+func (m *Model) deselectedStoryIDs() []string {
+	p := m.activePRD()
+	if p == nil {
+		return nil
+	}
+	var ids []string
+	for _, s := range p.Stories {
+		if selectableStory(s) && m.reviewDeselected[s.ID] {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
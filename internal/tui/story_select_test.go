@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/runner"
+	"ralph/internal/shared/session"
+	"ralph/internal/workflow/events"
+)
+
+func reviewTestPRD() *prd.PRD {
+	return &prd.PRD{
+		ProjectName: "Select",
+		Stories: []*prd.Story{
+			{ID: "a", Title: "Story A", Description: "a", Priority: 1, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "a", RedHint: "add failing test"}}},
+			{ID: "b", Title: "Story B", Description: "b", Priority: 2, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "b", RedHint: "add failing test"}}},
+			{ID: "c", Title: "Story C", Description: "c", Priority: 3, Passes: true, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "c", RedHint: "add failing test", Passes: true}}},
+		},
+	}
+}
+
+func TestPRDReviewStorySelector(t *testing.T) {
+	m := NewModel(config.DefaultConfig(), "goal", false, false, false)
+	m.handleWorkflowEvent(events.EventPRDGenerated{PRD: reviewTestPRD()})
+	if m.phase != PhasePRDReview {
+		t.Fatalf("phase = %v, want PhasePRDReview", m.phase)
+	}
+
+	press := func(keys ...tea.KeyMsg) {
+		for _, k := range keys {
+			m.Update(k)
+		}
+	}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	press(down, space)
+	if got := m.deselectedStoryIDs(); !slices.Equal(got, []string{"b"}) {
+		t.Fatalf("deselected = %v, want [b]", got)
+	}
+	if view := m.renderPRDReview(); !strings.Contains(view, "[ ] P2 Story B") || !strings.Contains(view, "[x] P1 Story A") {
+		t.Errorf("review view does not show the checkboxes:\n%s", view)
+	}
+
+	press(down, down, space)
+	if got := m.deselectedStoryIDs(); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("deselected = %v, want a finished story to stay untoggleable", got)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyUp}, space)
+	if got := m.deselectedStoryIDs(); len(got) != 0 {
+		t.Errorf("deselected = %v, want story b checked again", got)
+	}
+
+	press(space)
+	m.handleWorkflowEvent(events.EventPRDReview{PRD: reviewTestPRD()})
+	if got := m.deselectedStoryIDs(); len(got) != 0 || m.reviewCursor != 0 {
+		t.Errorf("selection = %v cursor %d after a revised PRD, want reset", got, m.reviewCursor)
+	}
+}
+
+func TestApproveReviewSkipsUncheckedStories(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.SkipCleanup = true
+	if err := prd.Save(cfg, reviewTestPRD()); err != nil {
+		t.Fatalf("Save PRD: %v", err)
+	}
+
+	om := &OperationManager{Session: session.NewWithRunner(cfg, runner.NoopRunner{}), cfg: cfg}
+	t.Cleanup(func() { waitSessionDone(t, om) })
+
+	if msg := om.ApproveReview([]string{"b"})(); msg != nil {
+		t.Fatalf("ApproveReview() msg = %#v, want nil", msg)
+	}
+	saved, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.GetStory("b").Skip || saved.GetStory("a").Skip {
+		t.Errorf("skip flags a=%v b=%v, want only b skipped", saved.GetStory("a").Skip, saved.GetStory("b").Skip)
+	}
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
+
+	"ralph/internal/shared/prd"
 )
 
 var (
@@ -32,6 +34,11 @@ var (
 			Foreground(mutedColor).
 			MarginLeft(1)
 
+	newProjectBadgeStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(warningColor).
+				MarginLeft(1)
+
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(accentColor).
@@ -72,6 +79,10 @@ var (
 			Foreground(errorColor).
 			Bold(true)
 
+	pausedStyle = lipgloss.NewStyle().
+			Foreground(warningColor).
+			Bold(true)
+
 	inProgressStyle = lipgloss.NewStyle().
 			Foreground(highlightColor).
 			Bold(true)
@@ -129,6 +140,7 @@ const (
 	iconCompleted  = "●"
 	iconSuccess    = "✓"
 	iconWarning    = "⚠"
+	iconSkipped    = prd.SkippedGlyph
 )
 
 func configureTextInput(ti textinput.Model) textinput.Model {
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -10,9 +11,15 @@ import (
 
 func (m *Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
-		m.spinner.Tick,
 		m.operationManager.ListenForEvents(),
 		tea.WindowSize(),
+		tickEvery(),
+	}
+	if m.operationManager.Session != nil {
+		cmds = append(cmds, m.operationManager.RunnerHeader())
+	}
+	if m.spinnerEnabled() {
+		cmds = append(cmds, m.spinner.Tick)
 	}
 	if m.prompt == "" && !m.resume {
 		m.phase = PhaseAwaitingPrompt
@@ -24,6 +31,25 @@ func (m *Model) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// approveReview leaves PRD review for implementation, skipping the stories
+// unchecked in the review list.
+func (m *Model) approveReview() tea.Cmd {
+	skip := m.deselectedStoryIDs()
+	if len(skip) > 0 {
+		m.logger.AddLog("Skipping unchecked stories: " + strings.Join(skip, ", "))
+	}
+	m.phase = PhaseImplementation
+	if m.width > 0 && m.height > 0 {
+		m.applyLayout(m.width, m.height)
+	}
+	m.rebuildMainScrollContent()
+	m.mainPane.GotoTop()
+	return tea.Batch(
+		m.operationManager.ApproveReview(skip),
+		m.operationManager.ListenForEvents(),
+	)
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	var needsMainRebuild bool
@@ -130,16 +156,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.operationManager.ListenForEvents(),
 						)
 					}
-					m.phase = PhaseImplementation
-					if m.width > 0 && m.height > 0 {
-						m.applyLayout(m.width, m.height)
-					}
-					m.rebuildMainScrollContent()
-					m.mainPane.GotoTop()
-					return m, tea.Batch(
-						m.operationManager.ApproveReview(),
-						m.operationManager.ListenForEvents(),
-					)
+					return m, m.approveReview()
 				default:
 					var cmd tea.Cmd
 					m.critiqueInput, cmd = m.critiqueInput.Update(msg)
@@ -156,19 +173,33 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.rebuildMainScrollContent()
 				return m, tea.Batch(cmds...)
 			}
+			if m.scrollPane == focusMain && m.handleStorySelectKey(msg.String()) {
+				m.rebuildMainScrollContent()
+				return m, nil
+			}
 			if msg.String() == "enter" {
-				m.phase = PhaseImplementation
 				m.scrollPane = focusMain
-				if m.width > 0 && m.height > 0 {
-					m.applyLayout(m.width, m.height)
-				}
-				m.rebuildMainScrollContent()
-				m.mainPane.GotoTop()
-				return m, tea.Batch(
-					m.operationManager.ApproveReview(),
-					m.operationManager.ListenForEvents(),
-				)
+				return m, m.approveReview()
+			}
+		}
+
+		if m.phase == PhaseImplementation && msg.String() == "p" {
+			m.setPaused(!m.paused)
+			if m.paused {
+				m.logger.AddLog("Paused; the current story will finish before the next one starts")
+			} else {
+				m.logger.AddLog("Resumed")
 			}
+			needsMainRebuild = true
+			break
+		}
+
+		if m.phase == PhaseImplementation && msg.String() == "c" {
+			if m.operationManager.CancelCurrentStory() {
+				m.logger.AddLog("Canceling current story; it will be requeued")
+				needsMainRebuild = true
+			}
+			break
 		}
 
 		if m.phase == PhaseCleanup && m.waitingCleanupReview() && msg.String() == "enter" {
@@ -220,6 +251,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		cmds = append(cmds, cmd)
 
+	case tickMsg:
+		m.lastTick = time.Time(msg)
+		needsMainRebuild = !m.storyStartedAt.IsZero()
+		cmds = append(cmds, tickEvery())
+
 	case phaseChangeMsg:
 		m.phase = Phase(msg)
 		needsMainRebuild = true
@@ -227,9 +263,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case resumeStartMsg:
 		m.phase = msg.phase
 		m.prd = msg.prd
+		if msg.prd != nil {
+			m.iteration = msg.prd.Iterations
+		}
 		m.snapshot = msg.snapshot
 		needsMainRebuild = true
 
+	case runnerHeaderMsg:
+		m.logger.AddLog(string(msg))
+		needsMainRebuild = true
+
 	case operationErrorMsg:
 		m.err = msg.err
 		m.phase = PhaseFailed
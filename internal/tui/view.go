@@ -3,8 +3,10 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"ralph/internal/shared/config"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/runstate"
 	"ralph/internal/shared/session"
@@ -42,11 +44,27 @@ func (m *Model) View() string {
 func (m *Model) renderHeader() string {
 	title := headerTitleStyle.Render("RALPH")
 	subtitle := subtitleStyle.Render("Autonomous software development agent")
+	if m.newProject {
+		subtitle += newProjectBadgeStyle.Render("[new project]")
+	}
 	return headerStyle.Render(title + subtitle)
 }
 
+func (m *Model) spinnerEnabled() bool {
+	return m.cfg == nil || m.cfg.Spinner != config.SpinnerOff
+}
+
+// spinnerView returns a static glyph when the spinner is off so views do not
+// depend on tick messages to render.
+func (m *Model) spinnerView() string {
+	if !m.spinnerEnabled() {
+		return m.spinner.Style.Render(iconInProgress)
+	}
+	return m.spinner.View()
+}
+
 func (m *Model) renderPhase() string {
-	icon := m.spinner.View()
+	icon := m.spinnerView()
 	switch m.phase {
 	case PhaseCompleted:
 		icon = iconSuccess
@@ -57,7 +75,11 @@ func (m *Model) renderPhase() string {
 	case PhaseFailed:
 		icon = iconWarning
 	}
-	return renderStyledWrapped(phaseStyle, fmt.Sprintf("%s %s", icon, m.phase.String()), m.contentWidth(2))
+	label := fmt.Sprintf("%s %s", icon, m.phase.String())
+	if m.paused && m.phase == PhaseImplementation {
+		label += " " + pausedStyle.Render("PAUSED")
+	}
+	return renderStyledWrapped(phaseStyle, label, m.contentWidth(2))
 }
 
 func (m *Model) renderClarifying() string {
@@ -126,7 +148,7 @@ func (m *Model) renderGenerating() string {
 		generatingText = inProgressStyle.Render("Revising PRD based on your critique...")
 	}
 
-	content := fmt.Sprintf("%s %s\n\n%s %s", promptLabel, promptText, m.spinner.View(), generatingText)
+	content := fmt.Sprintf("%s %s\n\n%s %s", promptLabel, promptText, m.spinnerView(), generatingText)
 	return infoStyle.Render(content)
 }
 
@@ -143,8 +165,8 @@ func (m *Model) renderPRDReview() string {
 	b.WriteString("\n\n")
 	b.WriteString(titleStyle.Render("Stories"))
 	b.WriteString("\n")
-	for _, s := range prd.Stories {
-		b.WriteString(m.renderReviewStory(s))
+	for i, s := range prd.Stories {
+		b.WriteString(m.renderReviewStory(s, i == m.reviewCursor))
 	}
 	b.WriteString("\n")
 	if m.critiqueActive {
@@ -169,6 +191,10 @@ func (m *Model) renderImplementation() string {
 		b.WriteString(banner)
 		b.WriteString("\n\n")
 	}
+	if timer := m.renderStoryTimer(); timer != "" {
+		b.WriteString(timer)
+		b.WriteString("\n\n")
+	}
 	b.WriteString(m.renderProjectSection())
 	b.WriteString("\n")
 	b.WriteString(m.renderProgressSection())
@@ -202,6 +228,14 @@ func (m *Model) renderCompleted() string {
 			b.WriteString("\n")
 			b.WriteString(infoStyle.Render(wrapText(labelStyle.Render("Stories")+" "+valueStyle.Render(fmt.Sprintf("%d completed", len(prd.Stories))), m.contentWidth(4))))
 			b.WriteString("\n")
+			if m.iteration > 0 {
+				b.WriteString(infoStyle.Render(wrapText(labelStyle.Render("Iterations")+" "+valueStyle.Render(fmt.Sprintf("%d", m.iteration)), m.contentWidth(4))))
+				b.WriteString("\n")
+			}
+			if elapsed := prd.Elapsed(); elapsed > 0 {
+				b.WriteString(infoStyle.Render(wrapText(labelStyle.Render("Elapsed")+" "+valueStyle.Render(elapsed.Round(time.Second).String()), m.contentWidth(4))))
+				b.WriteString("\n")
+			}
 		}
 	}
 
@@ -214,13 +248,35 @@ func (m *Model) renderCleanup() string {
 		b.WriteString(banner)
 		b.WriteString("\n\n")
 	}
-	content := fmt.Sprintf("%s Running post-implementation cleanup…", m.spinner.View())
+	content := fmt.Sprintf("%s Running post-implementation cleanup…", m.spinnerView())
 	b.WriteString(infoStyle.Render(inProgressStyle.Render(wrapText(content, m.contentWidth(4)))))
 	b.WriteString("\n\n")
 	b.WriteString(mutedStyle.Render(wrapText("(check logs for runner output)", m.contentWidth(4))))
 	return b.String()
 }
 
+// renderStoryTimer shows how long the current story has been running, so a
+// long runner call is distinguishable from a stuck one.
+func (m *Model) renderStoryTimer() string {
+	if m.storyStartedAt.IsZero() {
+		return ""
+	}
+	elapsed := max(m.lastTick.Sub(m.storyStartedAt), 0)
+	return mutedStyle.Render(wrapText("current story running for "+formatStoryElapsed(elapsed), m.contentWidth(4)))
+}
+
+func formatStoryElapsed(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
 func (m *Model) renderActivityBanner() string {
 	activity := m.activity
 	if activity.Kind == "" {
@@ -303,7 +359,7 @@ func (m *Model) helpText() string {
 		return "enter: submit  q/ctrl+c: quit"
 	}
 	if m.phase == PhasePRDReview {
-		return "Tab switch pane • ↑/↓ scroll • c critique • Enter continue • q quit • ctrl+c exit"
+		return "Tab switch pane • ↑/↓ select story • space toggle • c critique • Enter continue • q quit • ctrl+c exit"
 	}
 	if m.phase == PhaseFailed {
 		return "Tab switch pane • ↑/↓ scroll • r retry • q quit • ctrl+c exit"
@@ -311,6 +367,9 @@ func (m *Model) helpText() string {
 	if m.waitingCleanupReview() {
 		return "Tab switch pane • ↑/↓ scroll • Enter continue cleanup review • q quit • ctrl+c exit"
 	}
+	if m.phase == PhaseImplementation {
+		return "Tab switch pane • ↑/↓ scroll • p pause/resume • c cancel story • q quit • ctrl+c exit"
+	}
 	return "Tab switch pane • ↑/↓ scroll • q quit • ctrl+c exit"
 }
 
@@ -404,24 +463,57 @@ func (m *Model) renderProgressSection() string {
 		percent = float64(completed) / float64(total)
 	}
 	var b strings.Builder
-	b.WriteString(infoStyle.Render(labelStyle.Render("Progress") + " " + mutedStyle.Render(fmt.Sprintf("%d/%d stories", completed, total))))
+	summary := fmt.Sprintf("%d/%d stories", completed, total)
+	if eta := m.remainingEstimate(total - completed); eta != "" {
+		summary += " • " + eta
+	}
+	b.WriteString(infoStyle.Render(labelStyle.Render("Progress") + " " + mutedStyle.Render(summary)))
 	b.WriteString("\n")
 	b.WriteString(infoStyle.Render(m.progress.ViewAs(percent)))
 	return b.String()
 }
 
-func (m *Model) renderReviewStory(s *prd.Story) string {
+// recordStoryDuration folds one completed story's duration into the running
+// average the progress ETA is based on.
+func (m *Model) recordStoryDuration(d time.Duration) {
+	m.storiesTimed++
+	m.averageStoryDuration += (d - m.averageStoryDuration) / time.Duration(m.storiesTimed)
+}
+
+// remainingEstimate is "~Xm remaining" for the given number of stories left,
+// or "" until a story has completed this session.
+func (m *Model) remainingEstimate(remaining int) string {
+	if m.storiesTimed == 0 || remaining <= 0 {
+		return ""
+	}
+	eta := m.averageStoryDuration * time.Duration(remaining)
+	minutes := int(eta.Round(time.Minute) / time.Minute)
+	if minutes >= 60 {
+		return fmt.Sprintf("~%dh %dm remaining", minutes/60, minutes%60)
+	}
+	return fmt.Sprintf("~%dm remaining", max(minutes, 1))
+}
+
+func (m *Model) renderReviewStory(s *prd.Story, highlighted bool) string {
 	var b strings.Builder
 	status := "[ ]"
-	if s.Passes {
+	if m.storyChecked(s) {
 		status = "[x]"
+	} else if s.Passes {
+		status = "[done]"
+	} else if s.Skip {
+		status = "[" + prd.SkippedGlyph + "]"
 	}
 	deps := ""
 	if len(s.DependsOn) > 0 {
 		deps = " (depends: " + strings.Join(s.DependsOn, ", ") + ")"
 	}
 	storyLine := fmt.Sprintf("%s P%d %s%s", status, s.Priority, s.Title, deps)
-	b.WriteString(renderStyledWrapped(storyItemStyle, storyLine, m.contentWidth(4)))
+	style := storyItemStyle
+	if highlighted {
+		style = selectedStoryStyle
+	}
+	b.WriteString(renderStyledWrapped(style, storyLine, m.contentWidth(4)))
 	b.WriteString("\n")
 	if len(s.Slices) > 0 {
 		b.WriteString(mutedStyle.Render("    Slices:"))
@@ -446,6 +538,10 @@ func (m *Model) renderImplementationStory(s *prd.Story) string {
 	isCurrentStory := currentStory != nil && s.ID == currentStory.ID
 	icon := getStatusIcon(s.Passes, isCurrentStory)
 	status := getStatusText(s.Passes, isCurrentStory)
+	if s.Skip && !s.Passes {
+		icon = mutedStyle.Render(iconSkipped)
+		status = mutedStyle.Render("skipped")
+	}
 	var b strings.Builder
 	storyProgress := s.RunProgress()
 	activity := m.activity
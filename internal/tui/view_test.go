@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
@@ -230,6 +231,28 @@ func TestViewPhaseImplementation(t *testing.T) {
 	}
 }
 
+func TestViewPhaseImplementationShowsSkippedStory(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, "test", false, false, false)
+	m.phase = PhaseImplementation
+	m.prd = &prd.PRD{
+		ProjectName: "Test Project",
+		Stories: []*prd.Story{
+			{ID: "1", Title: "Story One", Skip: true},
+			{ID: "2", Title: "Story Two"},
+		},
+	}
+	m.currentStory = m.prd.Stories[1]
+	m.width = 80
+	m.height = 45
+	prepMainView(m)
+
+	view := m.View()
+	if !strings.Contains(view, iconSkipped) || !strings.Contains(view, "skipped") {
+		t.Errorf("View() should mark the skipped story:\n%s", view)
+	}
+}
+
 func TestViewPhaseImplementationShowsSliceProgress(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "test", false, false, false)
@@ -465,6 +488,29 @@ func TestViewPhaseCompletedWithPRD(t *testing.T) {
 	}
 }
 
+func TestViewPhaseCompletedShowsIterationsAndElapsed(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewModel(cfg, "test", false, false, false)
+	m.phase = PhaseCompleted
+	m.iteration = 3
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	m.prd = &prd.PRD{
+		ProjectName: "Done Project",
+		Iterations:  3,
+		Stories:     []*prd.Story{{ID: "1", Passes: true, StartedAt: start, CompletedAt: start.Add(90 * time.Second)}},
+	}
+	m.width = 80
+	m.height = 24
+	prepMainView(m)
+
+	view := m.View()
+	for _, want := range []string{"Iterations", "3", "Elapsed", "1m30s"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
+
 func TestViewPhaseCleanupShowsContent(t *testing.T) {
 	cfg := config.DefaultConfig()
 	m := NewModel(cfg, "test", false, false, false)
@@ -2,10 +2,15 @@ package tui
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"ralph/internal/prompt"
+	"ralph/internal/shared/cli"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/notify"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/runner"
 	"ralph/internal/shared/runstate"
@@ -64,6 +69,7 @@ func (m *Model) submitClarifyingAnswers(qas []prompt.QuestionAnswer) []tea.Cmd {
 }
 
 func (m *Model) handleWorkflowEvent(event events.Event) tea.Cmd {
+	m.runLog.Record(event)
 	switch e := event.(type) {
 	case events.EventClarifyingQuestions:
 		return func() tea.Msg {
@@ -76,16 +82,17 @@ func (m *Model) handleWorkflowEvent(event events.Event) tea.Cmd {
 	case events.EventPRDGenerating:
 		m.phase = PhasePRDGeneration
 		m.revisingPRD = false
+		m.newProject = e.NewProject
 		m.logger.AddLog("Generating PRD...")
 		m.markMainScrollJump()
 
 	case events.EventPRDGenerated:
 		m.prd = e.PRD
+		m.resetStorySelection()
 		progress := e.PRD.RunProgress()
 		m.logger.AddLog(fmt.Sprintf("PRD generated: %s (%d stories)", e.PRD.ProjectName, progress.Total))
 		if m.dryRun {
 			m.phase = PhaseCompleted
-			m.logger.AddLog("Dry run complete - PRD saved to " + m.cfg.PRDFile)
 		} else if m.cfg.AutoApprove {
 			m.phase = PhasePRDGeneration
 		} else {
@@ -95,6 +102,8 @@ func (m *Model) handleWorkflowEvent(event events.Event) tea.Cmd {
 
 	case events.EventPRDLoaded:
 		m.prd = e.PRD
+		m.resetStorySelection()
+		m.iteration = e.PRD.Iterations
 		progress := e.PRD.RunProgress()
 		m.logger.AddLog(fmt.Sprintf("Loaded PRD: %s (%d/%d completed)",
 			e.PRD.ProjectName, progress.Completed, progress.Total))
@@ -116,6 +125,7 @@ func (m *Model) handleWorkflowEvent(event events.Event) tea.Cmd {
 	case events.EventPRDReview:
 		m.revisingPRD = false
 		m.prd = e.PRD
+		m.resetStorySelection()
 		if m.cfg.AutoApprove {
 			m.logger.AddLog("PRD auto-approved, continuing to implementation")
 		} else {
@@ -126,6 +136,9 @@ func (m *Model) handleWorkflowEvent(event events.Event) tea.Cmd {
 
 	case events.EventStoryStarted:
 		m.currentStory = e.Story
+		m.storyStartedAt = m.clock.Now()
+		m.lastTick = m.storyStartedAt
+		m.iteration++
 		m.phase = PhaseImplementation
 		_, storyID, storyTitle := m.activeStoryForActivity()
 		if e.Story != nil {
@@ -138,14 +151,18 @@ func (m *Model) handleWorkflowEvent(event events.Event) tea.Cmd {
 			StoryTitle: storyTitle,
 		}
 		m.syncPresentation(runstate.PhaseImplement)
-		m.logger.AddLog(fmt.Sprintf("Starting: %s", e.Story.Title))
+		m.logger.AddLog(fmt.Sprintf("Starting: %s (iteration %d)", e.Story.Title, m.iteration))
 		m.markMainScrollJump()
 
 	case events.EventSliceStarted, events.EventSliceCompleted:
 		m.syncPresentation(runstate.PhaseImplement)
 
 	case events.EventStoryCompleted:
-		m.logger.AddLog(fmt.Sprintf("Completed: %s", e.Story.Title))
+		if e.Success && !m.storyStartedAt.IsZero() {
+			m.recordStoryDuration(m.clock.Now().Sub(m.storyStartedAt))
+		}
+		m.storyStartedAt = time.Time{}
+		m.logger.AddLog(storyResultLog(e))
 		m.syncPresentation(runstate.PhaseImplement)
 		m.markMainScrollJump()
 
@@ -237,20 +254,95 @@ func (m *Model) handleWorkflowEvent(event events.Event) tea.Cmd {
 
 	case events.EventError:
 		m.logger.AddLog(fmt.Sprintf("Error: %v", e.Err))
+		m.setPaused(false)
 		m.retryImplementation = m.phase == PhaseImplementation
 		m.revisingPRD = false
 		m.err = e.Err
 		m.phase = PhaseFailed
+		m.logDroppedEvents()
+		m.logger.AddLog(m.summaryLine())
 		m.markMainScrollJump()
+		return notifyCmd(m.cfg, e)
 
 	case events.EventCompleted:
 		m.activity = session.RunActivity{}
+		m.setPaused(false)
 		m.retryImplementation = false
 		m.err = nil
 		m.phase = PhaseCompleted
-		m.logger.AddLog("All stories completed!")
+		m.unfinished = e.Unfinished
+		if e.Partial() {
+			m.logger.AddLog(fmt.Sprintf("Completed with unfinished stories: %s", strings.Join(e.Unfinished, ", ")))
+		} else {
+			m.logger.AddLog("All stories completed!")
+		}
+		m.logDroppedEvents()
+		m.logger.AddLog(m.summaryLine())
 		m.markMainScrollJump()
+		return notifyCmd(m.cfg, e)
 	}
 
 	return nil
 }
+
+// summaryLine is the RALPH_SUMMARY line headless runs print, built from the
+// PRD on disk so it matches what a script would read after the run.
+func (m *Model) summaryLine() string {
+	p, err := prd.Load(m.cfg)
+	if err != nil {
+		p = m.prd
+	}
+	return cli.SummaryLine(p, m.ExitCode())
+}
+
+// logDroppedEvents warns at the end of a run when output events were dropped
+// because the TUI fell behind the runner.
+func (m *Model) logDroppedEvents() {
+	if m.operationManager == nil || m.operationManager.Session == nil {
+		return
+	}
+	if n := m.operationManager.DroppedEvents(); n > 0 {
+		m.logger.AddLog(events.DroppedEventsLine(n))
+	}
+}
+
+// setPaused mirrors the pause toggle onto the story loop. A finished or failed
+// run clears it so a retry does not start out paused.
+func (m *Model) setPaused(paused bool) {
+	if m.paused == paused {
+		return
+	}
+	m.paused = paused
+	m.operationManager.SetPaused(paused)
+}
+
+// notifyCmd posts the webhook off the update loop so a slow endpoint does
+// not freeze the UI.
+func notifyCmd(cfg *config.Config, ev events.Event) tea.Cmd {
+	if cfg == nil || cfg.WebhookURL == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		notify.Run(cfg, ev)
+		return nil
+	}
+}
+
+func storyResultLog(e events.EventStoryCompleted) string {
+	switch e.Result {
+	case events.StoryFailedRetryable:
+		return fmt.Sprintf("Requeued: %s", e.Story.Title)
+	case events.StoryTimedOut:
+		return fmt.Sprintf("Timed out: %s", e.Story.Title)
+	case events.StoryBlocked:
+		return fmt.Sprintf("Blocked: %s", e.Story.Title)
+	case events.StorySkipped:
+		return fmt.Sprintf("Skipped: %s", e.Story.Title)
+	case events.StoryPassed:
+		return fmt.Sprintf("Completed: %s", e.Story.Title)
+	}
+	if e.Success {
+		return fmt.Sprintf("Completed: %s", e.Story.Title)
+	}
+	return fmt.Sprintf("Failed: %s", e.Story.Title)
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/logger"
 	sharedrunner "ralph/internal/shared/runner"
@@ -33,7 +34,7 @@ func NewAPI(cfg *config.Config, registry *runs.Registry) *API {
 		cfg:      cfg,
 		registry: registry,
 		runnerFactory: func(c *config.Config) (sharedrunner.RunnerInterface, error) {
-			return sharedrunner.NewWithError(c)
+			return sharedrunner.NewWithError(c, clock.Real{})
 		},
 		controllerFactory: runctrl.NewControllerWithRunner,
 		controllers:       make(map[string]*runctrl.RunController),
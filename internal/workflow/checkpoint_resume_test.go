@@ -61,7 +61,12 @@ func TestStartCheckpointResumeRestartsExpectedPhase(t *testing.T) {
 			}
 
 			d := NewDriverWithRunner(cfg, newMockRunner())
-			t.Cleanup(d.Cancel)
+			// The run keeps writing to workDir after the events checked here;
+			// let it stop before t.TempDir is removed.
+			t.Cleanup(func() {
+				d.Cancel()
+				d.Wait()
+			})
 			d.SetReviewLoop(runstate.LocalRunID, loop)
 			d.StartCheckpointResume(context.Background())
 
@@ -9,6 +9,7 @@ import (
 	"ralph/internal/prompt"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/constants"
+	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/runner"
 	"ralph/internal/shared/runstate"
@@ -61,6 +62,22 @@ func (d *Driver) Cancel()                       { d.cancel() }
 func (d *Driver) Wait()                         { d.wg.Wait() }
 func (d *Driver) Checkpoint() string            { return d.reviewLoopCheckpoint() }
 
+// CancelCurrentStory cancels the in-flight story without stopping the run.
+func (d *Driver) CancelCurrentStory() bool { return d.executor.CancelCurrentStory() }
+
+// SetPaused holds implementation after the in-flight story until unpaused.
+func (d *Driver) SetPaused(paused bool) { d.executor.SetPaused(paused) }
+
+// Paused reports whether implementation is held by SetPaused.
+func (d *Driver) Paused() bool { return d.executor.Paused() }
+
+// DroppedEvents is how many events were dropped because the consumer of
+// EventsCh fell behind.
+func (d *Driver) DroppedEvents() int { return d.executor.DroppedEvents() }
+
+// RunnerHeader is the startup line naming the runner and its backend version.
+func (d *Driver) RunnerHeader(ctx context.Context) string { return d.executor.RunnerHeader(ctx) }
+
 func (d *Driver) CurrentPRD() *prd.PRD {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -90,6 +107,11 @@ func (d *Driver) StartNew(ctx context.Context, userPrompt string) {
 			if err != nil || p == nil || !d.cfg.AutoApprove || d.cfg.DryRun {
 				return
 			}
+			if d.cfg.OpenEditor {
+				if p, err = d.executor.RunEditPRD(runCtx); err != nil {
+					return
+				}
+			}
 			d.executor.RunImplementation(runCtx, p)
 		})
 	}()
@@ -288,9 +310,20 @@ func (d *Driver) EmitEvent(ev events.Event) {
 }
 
 func (d *Driver) runWithCtx(parent context.Context, fn func(context.Context)) {
+	if err := runner.CheckInstalled(d.executor.runner); err != nil {
+		d.EmitError(err)
+		return
+	}
+
 	runCtx, runCancel := context.WithCancel(parent)
 	defer runCancel()
 
+	if release, err := prd.ClaimLockOwner(d.cfg, d.executor.clock.Now()); err != nil {
+		logger.Warn("failed to record lock owner", "error", err)
+	} else {
+		defer release()
+	}
+
 	go func() {
 		select {
 		case <-d.ctx.Done():
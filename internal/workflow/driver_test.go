@@ -14,6 +14,7 @@ import (
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/runner"
 	"ralph/internal/shared/testgit"
+	"ralph/internal/shared/workdir"
 	"ralph/internal/workflow/events"
 )
 
@@ -398,6 +399,80 @@ func TestDriverStartImplementationChecksOutPRDBranchOnMain(t *testing.T) {
 	}
 }
 
+func TestDriverStartImplementationNoBranchStaysOnCurrentBranch(t *testing.T) {
+	workDir := t.TempDir()
+	testgit.InitRepo(t, workDir)
+
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = workDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	cfg.NoBranch = true
+
+	p := &prd.PRD{
+		ProjectName: "No branch",
+		BranchName:  "feature/target",
+		Stories: []*prd.Story{{
+			ID:          "1",
+			Title:       "Story",
+			Description: "desc",
+			Priority:    1,
+			Slices: []*prd.Slice{{
+				ID:       "slice-1",
+				Behavior: "do work",
+				RedHint:  "test work",
+			}},
+		}},
+	}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("save PRD: %v", err)
+	}
+	commitPRDFile(t, workDir, cfg.PRDFile)
+
+	originalCheckoutBranch := checkoutBranch
+	t.Cleanup(func() { checkoutBranch = originalCheckoutBranch })
+	checkoutCalls := 0
+	checkoutBranch = func(workDir, branchName string) error {
+		checkoutCalls++
+		return nil
+	}
+
+	mock := newMockRunner()
+	mock.runFunc = func(ctx context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if isStoryImplementPrompt(prompt) {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	d := NewDriverWithRunner(cfg, mock)
+	t.Cleanup(d.Cancel)
+	d.StartImplementation(context.Background(), p)
+
+	deadline := time.Now().Add(2 * time.Second)
+	seenSliceStarted := false
+	for time.Now().Before(deadline) && !seenSliceStarted {
+		select {
+		case ev := <-d.EventsCh():
+			_, seenSliceStarted = ev.(events.EventSliceStarted)
+		default:
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if !seenSliceStarted {
+		t.Fatal("expected EventSliceStarted, never received")
+	}
+	d.Cancel()
+	d.Wait()
+	if checkoutCalls != 0 {
+		t.Fatalf("checkout helper called %d times, want 0", checkoutCalls)
+	}
+	if p.BranchName != "feature/target" {
+		t.Fatalf("BranchName = %q, want PRD branch left untouched", p.BranchName)
+	}
+}
+
 func TestDriverSubmitClarify(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.WorkDir = t.TempDir()
@@ -469,3 +544,122 @@ func TestDriverCancel(t *testing.T) {
 		t.Fatal("context should be done after Cancel()")
 	}
 }
+
+func TestDriverStartImplementationSanitizesInvalidBranchName(t *testing.T) {
+	workDir := t.TempDir()
+	testgit.InitRepo(t, workDir)
+
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = workDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+
+	p := &prd.PRD{
+		ProjectName: "Invalid branch",
+		BranchName:  "feature/add login: v2~",
+		Stories: []*prd.Story{{
+			ID:          "1",
+			Title:       "Story",
+			Description: "desc",
+			Priority:    1,
+			Slices:      []*prd.Slice{{ID: "slice-1", Behavior: "do work", RedHint: "test work"}},
+		}},
+	}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("save PRD: %v", err)
+	}
+	commitPRDFile(t, workDir, cfg.PRDFile)
+
+	mock := newMockRunner()
+	mock.runFunc = func(ctx context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if isStoryImplementPrompt(prompt) {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+	d := NewDriverWithRunner(cfg, mock)
+	t.Cleanup(d.Cancel)
+	d.StartImplementation(context.Background(), p)
+	d.Cancel()
+	d.Wait()
+
+	const want = "feature/add-login-v2"
+	current, err := workdir.CurrentBranchName(workDir)
+	if err != nil {
+		t.Fatalf("CurrentBranchName() error = %v", err)
+	}
+	if current != want {
+		t.Fatalf("checked out branch = %q, want %q", current, want)
+	}
+	loaded, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatalf("load PRD: %v", err)
+	}
+	if loaded.BranchName != want {
+		t.Fatalf("saved BranchName = %q, want %q", loaded.BranchName, want)
+	}
+}
+
+func TestDriverStartNewReportsMissingRunnerBinary(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.Runner = "opencode"
+
+	d := NewDriver(cfg)
+	t.Cleanup(d.Cancel)
+	d.StartNew(context.Background(), "build something")
+	d.Wait()
+
+	select {
+	case ev := <-d.EventsCh():
+		errEv, ok := ev.(events.EventError)
+		if !ok || !strings.Contains(errEv.Err.Error(), "opencode not found in PATH") {
+			t.Fatalf("first event = %#v, want EventError naming the missing opencode binary", ev)
+		}
+	default:
+		t.Fatal("expected EventError before any phase started")
+	}
+}
+
+func TestPrepareImplementationBranchChecksOutPRDBranchInDetachedWorktree(t *testing.T) {
+	repo := t.TempDir()
+	testgit.InitRepo(t, repo)
+	worktree := workdir.WorktreePath(repo)
+	if err := workdir.CreateWorktree(repo, "", worktree); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = worktree
+	cfg.PRDFile = "prd.json"
+	cfg.UseWorktree = true
+	p := &prd.PRD{ProjectName: "Isolated", BranchName: "feature/isolated", Stories: []*prd.Story{
+		{ID: "1", Title: "Story", Description: "desc", Priority: 1, Slices: []*prd.Slice{{ID: "slice-1", Behavior: "do work", RedHint: "test work"}}},
+	}}
+
+	d := NewDriverWithRunner(cfg, newMockRunner())
+	t.Cleanup(d.Cancel)
+	if err := d.prepareImplementationBranch(p); err != nil {
+		t.Fatalf("prepareImplementationBranch() error = %v", err)
+	}
+	if got, err := workdir.CurrentBranchName(worktree); err != nil || got != p.BranchName {
+		t.Fatalf("worktree branch = %q, %v, want %q", got, err, p.BranchName)
+	}
+	if got, _ := workdir.CurrentBranchName(repo); got != "main" {
+		t.Fatalf("main checkout branch = %q, want main", got)
+	}
+
+	cfg.UseWorktree = false
+	detachedRepo := t.TempDir()
+	testgit.InitRepo(t, detachedRepo)
+	if out, err := exec.Command("git", "-C", detachedRepo, "checkout", "--detach").CombinedOutput(); err != nil {
+		t.Fatalf("git checkout --detach: %v\n%s", err, out)
+	}
+	cfg.WorkDir = detachedRepo
+	if err := d.prepareImplementationBranch(p); err == nil || !strings.Contains(err.Error(), "detect active branch") {
+		t.Fatalf("prepareImplementationBranch() error = %v, want detached HEAD rejected outside a worktree", err)
+	}
+}
@@ -23,7 +23,9 @@ func eventEnvelopeParts(ev Event) (string, any, error) {
 	case EventOutput:
 		return "EventOutput", e.Output, nil
 	case EventPRDGenerating:
-		return "EventPRDGenerating", struct{}{}, nil
+		return "EventPRDGenerating", struct {
+			NewProject bool `json:"new_project,omitempty"`
+		}{NewProject: e.NewProject}, nil
 	case EventPRDGenerated:
 		return "EventPRDGenerated", e.PRD, nil
 	case EventPRDLoaded:
@@ -38,7 +40,8 @@ func eventEnvelopeParts(ev Event) (string, any, error) {
 		return "EventStoryCompleted", struct {
 			Story   any `json:"Story"`
 			Success bool
-		}{Story: e.Story, Success: e.Success}, nil
+			Result  StoryResult
+		}{Story: e.Story, Success: e.Success, Result: e.Result}, nil
 	case EventSliceStarted:
 		return "EventSliceStarted", e, nil
 	case EventSliceCompleted:
@@ -58,7 +61,9 @@ func eventEnvelopeParts(ev Event) (string, any, error) {
 	case EventCleanupCompleted:
 		return "EventCleanupCompleted", e, nil
 	case EventCompleted:
-		return "EventCompleted", struct{}{}, nil
+		return "EventCompleted", struct {
+			Unfinished []string `json:"unfinished,omitempty"`
+		}{Unfinished: e.Unfinished}, nil
 	case EventError:
 		msg := ""
 		if e.Err != nil {
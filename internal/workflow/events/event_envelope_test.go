@@ -2,7 +2,10 @@ package events
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+
+	"ralph/internal/shared/prd"
 )
 
 func assertEnvelopeType(t *testing.T, ev Event, wantType string) {
@@ -84,3 +87,23 @@ func TestMarshalEventEnvelope_SliceEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalEventEnvelope_PRDGeneratingNewProject(t *testing.T) {
+	data, err := MarshalEventEnvelope(EventPRDGenerating{NewProject: true})
+	if err != nil {
+		t.Fatalf("MarshalEventEnvelope() error = %v", err)
+	}
+	if got, want := string(data), `{"type":"EventPRDGenerating","payload":{"new_project":true}}`; got != want {
+		t.Errorf("envelope = %s, want %s", got, want)
+	}
+}
+
+func TestMarshalEventEnvelope_StoryCompletedResult(t *testing.T) {
+	data, err := MarshalEventEnvelope(EventStoryCompleted{Story: &prd.Story{ID: "s1"}, Result: StoryTimedOut})
+	if err != nil {
+		t.Fatalf("MarshalEventEnvelope() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"Success":false,"Result":"timed_out"`) {
+		t.Errorf("envelope = %s, want Result timed_out", data)
+	}
+}
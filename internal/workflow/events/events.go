@@ -2,6 +2,8 @@
 package events
 
 import (
+	"fmt"
+
 	"ralph/internal/prompt"
 	"ralph/internal/shared/prd"
 )
@@ -17,7 +19,11 @@ type Event interface {
 	isEvent()
 }
 
-type EventPRDGenerating struct{}
+type EventPRDGenerating struct {
+	// NewProject is set when the work dir has no source code, so the PRD is
+	// generated for a brand-new project rather than from codebase analysis.
+	NewProject bool
+}
 
 func (EventPRDGenerating) isEvent() {}
 
@@ -39,9 +45,23 @@ type EventStoryStarted struct {
 
 func (EventStoryStarted) isEvent() {}
 
+// StoryResult classifies how a story attempt ended so consumers get one
+// status instead of inferring it from Success and neighbouring events.
+type StoryResult string
+
+const (
+	StoryPassed          StoryResult = "passed"
+	StoryFailedRetryable StoryResult = "failed_retryable"
+	StoryFailedExhausted StoryResult = "failed_exhausted"
+	StorySkipped         StoryResult = "skipped"
+	StoryBlocked         StoryResult = "blocked"
+	StoryTimedOut        StoryResult = "timed_out"
+)
+
 type EventStoryCompleted struct {
 	Story   *prd.Story
 	Success bool
+	Result  StoryResult
 }
 
 func (EventStoryCompleted) isEvent() {}
@@ -66,13 +86,35 @@ type EventOutput struct {
 
 func (EventOutput) isEvent() {}
 
+// DryRunCompleteFormat is the layout of the line emitted when a dry run
+// finishes; integration checks match on it, so change it deliberately.
+const DryRunCompleteFormat = "Dry run complete: %d stories, saved to %s"
+
+// DryRunCompleteLine renders the dry-run completion line shared by the TUI and headless output.
+func DryRunCompleteLine(stories int, prdFile string) string {
+	return fmt.Sprintf(DryRunCompleteFormat, stories, prdFile)
+}
+
+// DroppedEventsLine is the end-of-run warning shown when output events were
+// dropped because the consumer could not keep up.
+func DroppedEventsLine(n int) string {
+	return fmt.Sprintf("Warning: %d output lines were dropped due to backpressure (raise RALPH_EMIT_TIMEOUT to wait longer)", n)
+}
+
 type EventError struct {
 	Err error
 }
 
 func (EventError) isEvent() {}
 
-type EventCompleted struct{}
+// EventCompleted ends a run. Unfinished lists story IDs left incomplete by a
+// --best-effort run; it is empty when every story passed.
+type EventCompleted struct {
+	Unfinished []string
+}
+
+// Partial reports whether the run completed with unfinished stories.
+func (e EventCompleted) Partial() bool { return len(e.Unfinished) > 0 }
 
 func (EventCompleted) isEvent() {}
 
@@ -3,9 +3,14 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"ralph/internal/shared/clock"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/constants"
+	"ralph/internal/shared/eventsocket"
 	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/runner"
@@ -17,6 +22,8 @@ type Executor struct {
 	eventsCh chan Event
 	runner   runner.RunnerInterface
 	store    PRDStore
+	clock    clock.Clock
+	socket   *eventsocket.Writer
 
 	runID                    string
 	reviewLoop               ReviewLoopUpdater
@@ -27,14 +34,45 @@ type Executor struct {
 	lastReviewTranscriptPath string
 	pendingReviewFindings    []ImplementationFinding
 	recoveryAttempts         int
+	droppedEvents            atomic.Int64
+	unfinishedStories        map[string]bool
+	lastPRD                  atomic.Pointer[prd.PRD]
+	consecutiveFailures      []string
+
+	storyMu     sync.Mutex
+	storyCancel context.CancelFunc
+	storyTime   map[string]time.Duration
+	resumeCh    chan struct{}
+
+	// prdMu serializes PRD read-modify-write and commits, and recoveryMu
+	// serializes recovery sessions, when stories run concurrently. editMu is
+	// held shared by each runner session and exclusively by a commit, so a
+	// commit never sees another story's half-finished edits. sessionStories
+	// names the stories whose sessions ended since the last commit.
+	prdMu          sync.Mutex
+	recoveryMu     sync.Mutex
+	editMu         sync.RWMutex
+	sessionMu      sync.Mutex
+	sessionStories map[string]bool
+
+	// storyRecoveryAttempts counts recovery attempts per story when stories
+	// run concurrently, guarded by recoveryMu; recoveryAttempts counts them
+	// otherwise. rateLimited records whether the last runner session of each
+	// story, or of the run outside any story under "", hit a rate limit.
+	storyRecoveryAttempts map[string]int
+	rateLimitMu           sync.Mutex
+	rateLimited           map[string]bool
 }
 
 func NewExecutor(cfg *config.Config, eventsCh chan Event) *Executor {
+	clk := clock.Real{}
 	return &Executor{
 		cfg:      cfg,
 		eventsCh: eventsCh,
-		runner:   runner.New(cfg),
+		runner:   runner.New(cfg, clk),
 		store:    defaultPRDStore{},
+		clock:    clk,
+		socket:   eventsocket.New(cfg.EventSocket),
 	}
 }
 
@@ -52,6 +90,8 @@ func NewExecutorWithRunnerAndStore(cfg *config.Config, eventsCh chan Event, r ru
 		eventsCh: eventsCh,
 		runner:   r,
 		store:    store,
+		clock:    clock.Real{},
+		socket:   eventsocket.New(cfg.EventSocket),
 	}
 }
 
@@ -59,42 +99,123 @@ func (e *Executor) LoadPRD(cfg *config.Config) (*prd.PRD, error) {
 	return e.store.Load(cfg)
 }
 
+// emit sends event to the consumer. When the channel is full it waits up to
+// RALPH_EMIT_TIMEOUT for room, then drops the event and counts it so the run
+// can report the loss instead of hiding it. RALPH_EVENT_SOCKET gets every
+// event first, whether or not the channel has room.
 func (e *Executor) emit(event Event) {
-	if e.eventsCh != nil {
+	e.socket.Record(event)
+	if e.eventsCh == nil {
+		return
+	}
+	select {
+	case e.eventsCh <- event:
+		return
+	default:
+	}
+	if e.cfg.EmitTimeout > 0 {
 		select {
 		case e.eventsCh <- event:
-		default:
-			logger.Warn("event channel full, dropping event", "event_type", fmt.Sprintf("%T", event))
+			return
+		case <-e.clock.After(e.cfg.EmitTimeout):
 		}
 	}
+	e.droppedEvents.Add(1)
+	logger.Warn("event channel full, dropping event", "event_type", fmt.Sprintf("%T", event))
+}
+
+// DroppedEvents is how many events emit has dropped because the consumer fell
+// behind.
+func (e *Executor) DroppedEvents() int {
+	return int(e.droppedEvents.Load())
+}
+
+// RunnerHeader names the runner and its backend CLI version, or "unknown"
+// when the version cannot be read.
+func (e *Executor) RunnerHeader(ctx context.Context) string {
+	return runner.HeaderLine(ctx, e.runner)
 }
 
 func (e *Executor) forwardOutput(outputCh <-chan runner.OutputLine) {
-	NewOutputForwarder(e.emit).Forward(outputCh)
+	e.forwardObservedOutput(context.Background(), outputCh, nil)
+}
+
+// forwardObservedOutput is forwardOutput that also hands each line to observe
+// when it is not nil. A rate limit on stderr is recorded for ctx's story.
+func (e *Executor) forwardObservedOutput(ctx context.Context, outputCh <-chan runner.OutputLine, observe func(runner.OutputLine)) {
+	f := NewOutputForwarder(e.emit)
+	f.showInternal = e.cfg.ShowInternal
+	f.observe = func(line runner.OutputLine) {
+		if line.IsErr && runner.IsRateLimitMessage(line.Text) {
+			e.setRateLimited(ctx, true)
+		}
+		if observe != nil {
+			observe(line)
+		}
+	}
+	f.Forward(outputCh)
 }
 
 func (e *Executor) RunPrompt(ctx context.Context, prompt string, outputCh chan<- runner.OutputLine) error {
+	logPrompt(prompt)
 	return e.runner.Run(ctx, prompt, outputCh)
 }
 
 func (e *Executor) runWithForwardedOutput(ctx context.Context, prompt string) error {
+	return e.runWithObservedOutput(ctx, prompt, nil)
+}
+
+// runWithObservedOutput is runWithForwardedOutput for callers that also need
+// to read the runner's output lines.
+func (e *Executor) runWithObservedOutput(ctx context.Context, prompt string, observe func(runner.OutputLine)) error {
 	if e.cfg.RunnerTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, e.cfg.RunnerTimeout)
 		defer cancel()
 	}
 
+	if e.cfg.StoryConcurrency() > 1 {
+		e.editMu.RLock()
+		defer e.editMu.RUnlock()
+		if storyID := storyFromContext(ctx); storyID != "" {
+			defer e.recordStorySession(storyID)
+		}
+	}
+
+	e.setRateLimited(ctx, false)
 	outputCh := make(chan runner.OutputLine, constants.EventChannelBuffer)
 	done := make(chan struct{})
 	go func() {
-		e.forwardOutput(outputCh)
+		e.forwardObservedOutput(ctx, outputCh, observe)
 		close(done)
 	}()
+	logPrompt(prompt)
 	runErr := e.runner.Run(ctx, prompt, outputCh)
 	close(outputCh)
 	<-done
 	if ctx.Err() == context.DeadlineExceeded {
 		return fmt.Errorf("runner invocation timed out after %s: %w", e.cfg.RunnerTimeout, ctx.Err())
 	}
+	if runErr != nil && runner.IsRateLimitMessage(runErr.Error()) {
+		e.setRateLimited(ctx, true)
+	}
 	return runErr
 }
+
+// setRateLimited records whether the latest runner session of ctx's story hit
+// a provider rate limit, so one story's limit does not stretch another's
+// retry cooldown.
+func (e *Executor) setRateLimited(ctx context.Context, limited bool) {
+	e.rateLimitMu.Lock()
+	defer e.rateLimitMu.Unlock()
+	if e.rateLimited == nil {
+		e.rateLimited = make(map[string]bool)
+	}
+	e.rateLimited[storyFromContext(ctx)] = limited
+}
+
+func (e *Executor) isRateLimited(ctx context.Context) bool {
+	e.rateLimitMu.Lock()
+	defer e.rateLimitMu.Unlock()
+	return e.rateLimited[storyFromContext(ctx)]
+}
@@ -0,0 +1,37 @@
+package workflow
+
+import "fmt"
+
+// PRDNotGeneratedError is returned by RunGenerate when the runner finished
+// without writing the PRD file.
+type PRDNotGeneratedError struct {
+	File string
+}
+
+func (e *PRDNotGeneratedError) Error() string {
+	return fmt.Sprintf("AI completed but did not generate %s — it may not have understood the request", e.File)
+}
+
+// PRDEmptyError is returned by RunGenerate when the runner left the PRD file
+// empty or whitespace-only, which usually means the model produced nothing.
+type PRDEmptyError struct {
+	File string
+}
+
+func (e *PRDEmptyError) Error() string {
+	return fmt.Sprintf("model returned no output — %s is empty; check your API key or model availability", e.File)
+}
+
+// PRDLoadError is returned by RunGenerate when the runner wrote the PRD file
+// but it could not be loaded. Err wraps *prd.ValidationError when the file
+// parsed but broke a PRD rule; otherwise it was unreadable or not JSON.
+type PRDLoadError struct {
+	File string
+	Err  error
+}
+
+func (e *PRDLoadError) Error() string {
+	return fmt.Sprintf("failed to load generated PRD %s: %v", e.File, e.Err)
+}
+
+func (e *PRDLoadError) Unwrap() error { return e.Err }
@@ -1,7 +1,16 @@
 package workflow
 
-import "ralph/internal/shared/workdir"
+import (
+	"ralph/internal/prompt"
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/workdir"
+)
 
 func workdirContainsSource(workDir string) bool {
 	return workdir.ContainsSource(workDir)
 }
+
+// logPrompt records a runner prompt in the RALPH_LOG_FILE log, if any.
+func logPrompt(text string) {
+	logger.Prompt(prompt.Kind(text), text)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/workdir"
 )
@@ -16,14 +17,20 @@ var savePRD = func(cfg *config.Config, p *prd.PRD) error {
 }
 
 func (d *Driver) prepareImplementationBranch(p *prd.PRD) error {
+	if d.cfg.NoBranch {
+		return nil
+	}
 	if err := workdir.ValidateGit(d.cfg.WorkDir); err != nil {
 		return nil
 	}
 	branchName, err := currentBranchName(d.cfg.WorkDir)
-	if err != nil {
+	// A fresh RALPH_USE_WORKTREE checkout sits on a detached HEAD until the
+	// PRD branch is checked out below.
+	detached := err != nil && d.cfg.UseWorktree
+	if err != nil && !detached {
 		return fmt.Errorf("detect active branch: %w", err)
 	}
-	if !isDefaultBranch(branchName, d.cfg.DefaultBranches) {
+	if !detached && !isDefaultBranch(branchName, d.cfg.DefaultBranches) {
 		if p.BranchName != branchName {
 			p.BranchName = branchName
 			if err := savePRD(d.cfg, p); err != nil {
@@ -35,6 +42,17 @@ func (d *Driver) prepareImplementationBranch(p *prd.PRD) error {
 		}
 		return nil
 	}
+	if sanitized := workdir.SanitizeBranchName(p.BranchName); sanitized != p.BranchName {
+		logger.Warn("sanitized PRD branch name", "from", p.BranchName, "to", sanitized)
+		d.executor.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Branch name %q is not a valid git ref; using %q", p.BranchName, sanitized)}})
+		p.BranchName = sanitized
+		if err := savePRD(d.cfg, p); err != nil {
+			return fmt.Errorf("save PRD branch %q: %w", sanitized, err)
+		}
+		d.mu.Lock()
+		d.currentPRD = p
+		d.mu.Unlock()
+	}
 	if err := checkoutBranch(d.cfg.WorkDir, p.BranchName); err != nil {
 		return fmt.Errorf("checkout PRD branch %q: %w", p.BranchName, err)
 	}
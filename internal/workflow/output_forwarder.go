@@ -2,8 +2,16 @@ package workflow
 
 import "ralph/internal/shared/runner"
 
+// internalLinePrefix tags runner lines classified as internal when
+// --show-internal lets them through to the UI.
+const internalLinePrefix = "[internal] "
+
 type OutputForwarder struct {
-	emit func(Event)
+	emit    func(Event)
+	observe func(runner.OutputLine)
+	// showInternal forwards lines the runner marked Verbose as ordinary
+	// output, tagged with internalLinePrefix, so the UI no longer hides them.
+	showInternal bool
 }
 
 func NewOutputForwarder(emit func(Event)) *OutputForwarder {
@@ -12,10 +20,23 @@ func NewOutputForwarder(emit func(Event)) *OutputForwarder {
 
 func (f *OutputForwarder) Forward(outputCh <-chan runner.OutputLine) {
 	for line := range outputCh {
+		if f.observe != nil {
+			f.observe(line)
+		}
+		if line.Raw {
+			continue
+		}
+		text, verbose := line.Text, line.Verbose
+		if verbose && f.showInternal {
+			verbose = false
+			if !line.Append {
+				text = internalLinePrefix + text
+			}
+		}
 		f.emit(EventOutput{Output: Output{
-			Text:    line.Text,
+			Text:    text,
 			IsErr:   line.IsErr,
-			Verbose: line.Verbose,
+			Verbose: verbose,
 			Append:  line.Append,
 		}})
 	}
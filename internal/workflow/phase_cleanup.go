@@ -112,6 +112,22 @@ func (e *Executor) completeRunAfterCleanup(ctx context.Context, p *prd.PRD) erro
 	if err := e.runTestGateWithRecovery(ctx, p); err != nil {
 		return err
 	}
+	e.writeCompletionSummary()
+	e.openPullRequest(p)
 	e.emit(EventCompleted{})
 	return nil
 }
+
+// writeCompletionSummary records a per-story summary on the saved PRD. A
+// failure here is logged rather than failing an otherwise successful run.
+func (e *Executor) writeCompletionSummary() {
+	p, err := e.store.Load(e.cfg)
+	if err != nil {
+		logger.Warn("skipping PRD summary", "error", err)
+		return
+	}
+	p.Summary = p.BuildSummary(e.effectiveTestCommand(p))
+	if err := e.store.Save(e.cfg, p); err != nil {
+		logger.Warn("failed to save PRD summary", "error", err)
+	}
+}
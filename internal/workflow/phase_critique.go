@@ -45,6 +45,7 @@ func (e *Executor) RunCritiqueRevision(ctx context.Context, userPrompt, critique
 		}
 	}
 
+	e.writeDryRunMarkdown(p)
 	e.emit(EventPRDReview{PRD: p})
 	return nil
 }
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"ralph/internal/shared/constants"
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+)
+
+const defaultEditor = "vi"
+
+// editorCommand resolves the editor from $VISUAL, then $EDITOR, falling back
+// to vi. The value may carry arguments, e.g. "code --wait".
+func editorCommand() []string {
+	for _, key := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(key)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{defaultEditor}
+}
+
+var runEditor = func(ctx context.Context, path string) error {
+	argv := append(editorCommand(), path)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// RunEditPRD opens the generated PRD in the user's editor and reloads it. An
+// edit that fails validation reopens the editor, up to MaxPRDEditAttempts.
+func (e *Executor) RunEditPRD(ctx context.Context) (*prd.PRD, error) {
+	path := e.cfg.PRDPath()
+	var lastErr error
+	for attempt := 1; attempt <= constants.MaxPRDEditAttempts; attempt++ {
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Opening %s in editor (attempt %d of %d)", e.cfg.PRDFile, attempt, constants.MaxPRDEditAttempts)}})
+		if err := runEditor(ctx, path); err != nil {
+			editErr := fmt.Errorf("editor exited with error: %w", err)
+			e.emit(EventError{Err: editErr})
+			return nil, editErr
+		}
+
+		edited, err := e.store.Load(e.cfg)
+		if err == nil {
+			logger.Debug("reloaded edited PRD", "project", edited.ProjectName, "stories", len(edited.Stories))
+			e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Reloaded edited PRD (%d stories)", len(edited.Stories))}})
+			e.emit(EventPRDLoaded{PRD: edited})
+			return edited, nil
+		}
+		lastErr = err
+		logger.Warn("edited PRD failed validation", "attempt", attempt, "error", err)
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Edited PRD is invalid: %v", err), IsErr: true}})
+	}
+
+	abortErr := fmt.Errorf("edited PRD still invalid after %d attempts: %w", constants.MaxPRDEditAttempts, lastErr)
+	e.emit(EventError{Err: abortErr})
+	return nil, abortErr
+}
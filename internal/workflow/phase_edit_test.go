@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+)
+
+// TestFakeEditorHelper acts as $EDITOR when RALPH_FAKE_EDITOR is set: it
+// rewrites the file passed as the last argument according to the mode.
+func TestFakeEditorHelper(t *testing.T) {
+	mode := os.Getenv("RALPH_FAKE_EDITOR")
+	if mode == "" {
+		return
+	}
+	path := os.Args[len(os.Args)-1]
+	if mode == "break" {
+		if err := os.WriteFile(path, []byte(`{"project_name":"Broken","stories":[{"id":""}]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	raw["project_name"] = "Edited"
+	edited, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, edited, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func useFakeEditor(t *testing.T, mode string) {
+	t.Helper()
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", os.Args[0]+" -test.run=^TestFakeEditorHelper$ --")
+	t.Setenv("RALPH_FAKE_EDITOR", mode)
+}
+
+func newEditTestExecutor(t *testing.T) (*Executor, *config.Config, chan Event) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	seeded := &prd.PRD{
+		ProjectName: "Generated",
+		Stories:     []*prd.Story{{ID: "1", Title: "Story", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+	}
+	if err := prd.Save(cfg, seeded); err != nil {
+		t.Fatalf("failed to seed PRD: %v", err)
+	}
+	ch := make(chan Event, 100)
+	return NewExecutorWithRunner(cfg, ch, newMockRunner()), cfg, ch
+}
+
+func TestRunEditPRDReloadsEditedFile(t *testing.T) {
+	useFakeEditor(t, "rename")
+	exec, cfg, ch := newEditTestExecutor(t)
+
+	p, err := exec.RunEditPRD(context.Background())
+	if err != nil {
+		t.Fatalf("RunEditPRD() error = %v", err)
+	}
+	if p.ProjectName != "Edited" {
+		t.Fatalf("ProjectName = %q, want Edited", p.ProjectName)
+	}
+	onDisk, err := prd.Load(cfg)
+	if err != nil || onDisk.ProjectName != "Edited" {
+		t.Fatalf("on-disk PRD = %+v, %v; want edited project name", onDisk, err)
+	}
+
+	foundLoaded := false
+	for _, e := range drainEvents(ch) {
+		if loaded, ok := e.(EventPRDLoaded); ok && loaded.PRD.ProjectName == "Edited" {
+			foundLoaded = true
+		}
+	}
+	if !foundLoaded {
+		t.Error("expected EventPRDLoaded with the edited PRD")
+	}
+}
+
+func TestRunEditPRDAbortsWhenEditStaysInvalid(t *testing.T) {
+	useFakeEditor(t, "break")
+	exec, _, ch := newEditTestExecutor(t)
+
+	if _, err := exec.RunEditPRD(context.Background()); err == nil || !strings.Contains(err.Error(), "still invalid") {
+		t.Fatalf("RunEditPRD() error = %v, want still-invalid abort", err)
+	}
+
+	reopened := 0
+	foundError := false
+	for _, e := range drainEvents(ch) {
+		switch ev := e.(type) {
+		case EventOutput:
+			if strings.Contains(ev.Text, "Opening prd.json in editor") {
+				reopened++
+			}
+		case EventError:
+			foundError = true
+		}
+	}
+	if reopened < 2 {
+		t.Errorf("editor opened %d times, want it reopened after an invalid edit", reopened)
+	}
+	if !foundError {
+		t.Error("expected EventError when the edit is abandoned")
+	}
+}
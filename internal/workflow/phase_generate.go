@@ -1,48 +1,112 @@
 package workflow
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"ralph/internal/prompt"
+	"ralph/internal/shared/config"
 	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
+	"ralph/internal/workflow/events"
 )
 
+// writeDryRunMarkdown refreshes prd.md for --dry-run --format md. The
+// markdown is a derived review copy, so a failed write only warns.
+func (e *Executor) writeDryRunMarkdown(p *prd.PRD) {
+	if !e.cfg.DryRun || e.cfg.PRDFormat != config.PRDFormatMarkdown {
+		return
+	}
+	path, err := prd.WriteMarkdown(e.cfg, p)
+	if err != nil {
+		logger.Warn("failed to write PRD markdown", "error", err)
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Warning: %v", err), IsErr: true}})
+		return
+	}
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Wrote %s", filepath.Base(path))}})
+}
+
+// recordPRDHistory keeps a copy of a freshly generated PRD in
+// RALPH_PRD_HISTORY_DIR. Story progress saves are not recorded, and a failed
+// copy only warns.
+func (e *Executor) recordPRDHistory(p *prd.PRD) {
+	dir := e.cfg.PRDHistoryPath()
+	if dir == "" {
+		return
+	}
+	path, err := prd.SaveHistory(dir, p, e.clock.Now())
+	if err != nil {
+		logger.Warn("failed to record PRD history", "error", err)
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Warning: %v", err), IsErr: true}})
+		return
+	}
+	logger.Debug("recorded PRD history", "path", path)
+}
+
+// generatedPRDBlank reports whether the PRD file on disk exists but holds
+// only whitespace. A file that cannot be read is left for Load to report.
+func generatedPRDBlank(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && len(bytes.TrimSpace(data)) == 0
+}
+
 func (e *Executor) RunGenerate(ctx context.Context, userPrompt string) (*prd.PRD, error) {
 	return e.RunGenerateWithAnswers(ctx, userPrompt, nil)
 }
 
 func (e *Executor) RunGenerateWithAnswers(ctx context.Context, userPrompt string, qas []prompt.QuestionAnswer) (*prd.PRD, error) {
 	logger.Debug("generating PRD", "prompt_length", len(userPrompt))
-	e.emit(EventPRDGenerating{})
-
 	hasSource := workdirContainsSource(e.cfg.WorkDir)
-	if !hasSource {
+	e.emit(EventPRDGenerating{NewProject: !hasSource})
+
+	if hasSource {
+		e.emit(EventOutput{Output: Output{Text: "Analyzing codebase and generating PRD..."}})
+	} else {
 		logger.Info("working directory has no source code, treating as new project", "work_dir", e.cfg.WorkDir)
 		e.emit(EventOutput{Output: Output{Text: "Warning: Working directory appears to have no source code. PRD will be generated for a new project."}})
+		e.emit(EventOutput{Output: Output{Text: "New project: generating PRD from the request alone..."}})
 	}
 
-	e.emit(EventOutput{Output: Output{Text: "Analyzing codebase and generating PRD..."}})
+	base, baseData, err := e.loadAppendBase()
+	if err != nil {
+		e.emit(EventError{Err: err})
+		return nil, err
+	}
 
-	prdPrompt := prompt.PRDGenerationWithAnswers(userPrompt, e.cfg.PRDFile, e.cfg.BranchPrefix, !hasSource, qas)
-	err := e.runWithForwardedOutput(ctx, prdPrompt)
+	note := storyCapPromptNote(e.cfg.MaxStories)
+	if base != nil {
+		note = appendPromptNote(base) + note
+	}
+	prdPrompt := e.prdGenerationPrompt(userPrompt, !hasSource, qas, note)
+	err = e.runWithForwardedOutput(ctx, prdPrompt)
 
 	if err != nil {
 		logger.Error("PRD generation failed", "error", err)
-		e.emit(EventError{Err: fmt.Errorf("PRD generation failed with runner %s: %w", e.cfg.Runner, err)})
-		return nil, fmt.Errorf("PRD generation failed with runner %s: %w", e.cfg.Runner, err)
+		err = e.restoreAppendBase(base, fmt.Errorf("PRD generation failed with runner %s: %w", e.cfg.Runner, err))
+		e.emit(EventError{Err: err})
+		return nil, err
 	}
 
 	exists, err := e.store.Exists(e.cfg)
 	if err != nil {
 		logger.Error("failed to check for generated PRD", "error", err)
-		e.emit(EventError{Err: fmt.Errorf("checking for generated PRD %s: %w", e.cfg.PRDFile, err)})
-		return nil, fmt.Errorf("checking for generated PRD %s: %w", e.cfg.PRDFile, err)
+		err = e.restoreAppendBase(base, fmt.Errorf("checking for generated PRD %s: %w", e.cfg.PRDFile, err))
+		e.emit(EventError{Err: err})
+		return nil, err
 	}
-	if !exists {
-		err := fmt.Errorf("AI completed but did not generate %s — it may not have understood the request", e.cfg.PRDFile)
+	if !exists || (base != nil && prdUnchanged(e.cfg.PRDPath(), baseData)) {
 		logger.Error("AI did not generate PRD file", "file", e.cfg.PRDFile)
+		err := e.restoreAppendBase(base, &PRDNotGeneratedError{File: e.cfg.PRDFile})
+		e.emit(EventError{Err: err})
+		return nil, err
+	}
+
+	if generatedPRDBlank(e.cfg.PRDPath()) {
+		logger.Error("AI left the PRD file empty", "file", e.cfg.PRDFile)
+		err := e.restoreAppendBase(base, &PRDEmptyError{File: e.cfg.PRDFile})
 		e.emit(EventError{Err: err})
 		return nil, err
 	}
@@ -50,21 +114,109 @@ func (e *Executor) RunGenerateWithAnswers(ctx context.Context, userPrompt string
 	p, err := e.store.Load(e.cfg)
 	if err != nil {
 		logger.Error("failed to load generated PRD", "error", err)
-		e.emit(EventError{Err: fmt.Errorf("failed to load generated PRD %s: %w", e.cfg.PRDFile, err)})
-		return nil, fmt.Errorf("failed to load generated PRD %s: %w", e.cfg.PRDFile, err)
+		err = e.restoreAppendBase(base, &PRDLoadError{File: e.cfg.PRDFile, Err: err})
+		e.emit(EventError{Err: err})
+		return nil, err
 	}
 
 	if e.cfg.AutoApprove {
 		p, err = e.runPRDSelfReview(ctx, userPrompt)
 		if err != nil {
 			logger.Error("PRD self-review failed", "error", err)
-			e.emit(EventError{Err: fmt.Errorf("PRD self-review failed: %w", err)})
-			return nil, fmt.Errorf("PRD self-review failed: %w", err)
+			err = e.restoreAppendBase(base, fmt.Errorf("PRD self-review failed: %w", err))
+			e.emit(EventError{Err: err})
+			return nil, err
+		}
+	}
+
+	if err := e.enforceStoryCap(p); err != nil {
+		logger.Error("failed to enforce story cap", "error", err)
+		err = e.restoreAppendBase(base, err)
+		e.emit(EventError{Err: err})
+		return nil, err
+	}
+
+	if base != nil {
+		p, err = e.mergeIntoAppendBase(base, p)
+		if err != nil {
+			logger.Error("failed to merge generated PRD", "error", err)
+			e.emit(EventError{Err: err})
+			return nil, err
 		}
 	}
 
+	if err := e.normalizePriorities(p); err != nil {
+		logger.Error("failed to normalize PRD priorities", "error", err)
+		e.emit(EventError{Err: err})
+		return nil, err
+	}
+
+	p.SortStories()
 	logger.Debug("PRD generated", "project", p.ProjectName, "stories", len(p.Stories))
+	e.recordPRDHistory(p)
 	e.emit(EventPRDGenerated{PRD: p})
+	if e.cfg.DryRun {
+		e.emit(EventOutput{Output: Output{Text: events.DryRunCompleteLine(len(p.Stories), e.cfg.PRDFile)}})
+		for _, line := range p.Stats().Lines() {
+			e.emit(EventOutput{Output: Output{Text: line}})
+		}
+		e.reportTokenEstimate(p, prdPrompt)
+		e.writeDryRunMarkdown(p)
+	}
 	e.emit(EventPRDReview{PRD: p})
 	return p, nil
 }
+
+// prdGenerationPrompt renders the custom PRD prompt template when one exists,
+// falling back to the built-in prompt with a warning if it cannot be read,
+// parsed or executed. A missing default template is not worth a warning.
+// note is appended after the template, and the result is kept within
+// RALPH_MAX_PROMPT_BYTES by fitPromptLimit.
+func (e *Executor) prdGenerationPrompt(userPrompt string, isEmptyCodebase bool, qas []prompt.QuestionAnswer, note string) string {
+	render := func(request string) string {
+		return prompt.PRDGenerationWithAnswers(request, e.cfg.PRDFile, e.cfg.BranchPrefix, isEmptyCodebase, qas) + note
+	}
+	path := e.cfg.PRDPromptPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) || e.cfg.PRDPromptFile != "" {
+			e.warnPRDPromptFallback(path, err)
+		}
+		return e.fitPromptLimit(userPrompt, render)
+	}
+	if _, err := prompt.PRDGenerationFromTemplate(string(data), userPrompt, e.cfg.PRDFile, e.cfg.BranchPrefix, isEmptyCodebase, qas); err != nil {
+		e.warnPRDPromptFallback(path, err)
+		return e.fitPromptLimit(userPrompt, render)
+	}
+	logger.Debug("using custom PRD prompt template", "file", path)
+	return e.fitPromptLimit(userPrompt, func(request string) string {
+		rendered, _ := prompt.PRDGenerationFromTemplate(string(data), request, e.cfg.PRDFile, e.cfg.BranchPrefix, isEmptyCodebase, qas)
+		return rendered + note
+	})
+}
+
+// fitPromptLimit renders the generation prompt and, when it exceeds
+// RALPH_MAX_PROMPT_BYTES, truncates only the user's request and warns. The
+// template's instructions are never cut; if they alone exceed the limit the
+// request is dropped entirely and the prompt stays over.
+func (e *Executor) fitPromptLimit(userPrompt string, render func(string) string) string {
+	rendered := render(userPrompt)
+	limit := e.cfg.MaxPromptBytes
+	if limit <= 0 || len(rendered) <= limit {
+		return rendered
+	}
+	original := len(rendered)
+	request := userPrompt
+	for len(rendered) > limit && request != "" {
+		request = prompt.TruncateUserPrompt(request, len(request)-(len(rendered)-limit))
+		rendered = render(request)
+	}
+	logger.Warn("PRD generation prompt truncated", "bytes", original, "limit", limit)
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Warning: PRD generation prompt is %d bytes, over RALPH_MAX_PROMPT_BYTES=%d; truncated your request to fit", original, limit), IsErr: true}})
+	return rendered
+}
+
+func (e *Executor) warnPRDPromptFallback(path string, err error) {
+	logger.Warn("custom PRD prompt template unusable, using built-in prompt", "file", path, "error", err)
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Warning: PRD prompt template %s unusable (%v); using the built-in prompt", path, err), IsErr: true}})
+}
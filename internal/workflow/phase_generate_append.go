@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+)
+
+// loadAppendBase returns the existing PRD that --append merges the new
+// generation into, or nil when --append is off or there is no PRD yet. The
+// file's bytes are returned too, so RunGenerate can tell when the runner
+// left it untouched.
+func (e *Executor) loadAppendBase() (*prd.PRD, []byte, error) {
+	if !e.cfg.Append {
+		return nil, nil, nil
+	}
+	exists, err := e.store.Exists(e.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checking for existing PRD %s: %w", e.cfg.PRDFile, err)
+	}
+	if !exists {
+		return nil, nil, nil
+	}
+	base, err := e.store.Load(e.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot --append to %s: %w", e.cfg.PRDFile, err)
+	}
+	data, err := os.ReadFile(e.cfg.PRDPath())
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot --append to %s: %w", e.cfg.PRDFile, err)
+	}
+	return base, data, nil
+}
+
+// prdUnchanged reports whether the PRD file still holds exactly data.
+func prdUnchanged(path string, data []byte) bool {
+	current, err := os.ReadFile(path)
+	return err == nil && bytes.Equal(current, data)
+}
+
+// appendPromptNote tells the runner which story IDs are taken so the new
+// stories do not collide with the ones --append keeps.
+func appendPromptNote(base *prd.PRD) string {
+	ids := make([]string, 0, len(base.Stories))
+	for _, story := range base.Stories {
+		ids = append(ids, story.ID)
+	}
+	return fmt.Sprintf("\n\nThe existing PRD is kept and your stories are appended to it. Only write the new stories, and do not reuse these story ids: %s.", strings.Join(ids, ", "))
+}
+
+// mergeIntoAppendBase merges the generated PRD into base and saves the
+// result over the file the runner just wrote. If the merge fails, base is
+// restored so the earlier stories and their progress are not lost.
+func (e *Executor) mergeIntoAppendBase(base, generated *prd.PRD) (*prd.PRD, error) {
+	before := len(base.Stories)
+	if err := base.Merge(generated); err != nil {
+		return nil, e.restoreAppendBase(base, err)
+	}
+	if err := e.store.Save(e.cfg, base); err != nil {
+		return nil, fmt.Errorf("failed to save merged PRD %s: %w", e.cfg.PRDFile, err)
+	}
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Appended %d new stories to %s (%d total)", len(base.Stories)-before, e.cfg.PRDFile, len(base.Stories))}})
+	return base, nil
+}
+
+// restoreAppendBase writes base back after a failed --append generation and
+// returns cause, noting if the restore failed too. A nil base (no --append)
+// returns cause as is.
+func (e *Executor) restoreAppendBase(base *prd.PRD, cause error) error {
+	if base == nil {
+		return cause
+	}
+	if err := e.store.Save(e.cfg, base); err != nil {
+		logger.Error("failed to restore PRD after failed append", "error", err)
+		return fmt.Errorf("%w (and restoring %s failed: %v)", cause, e.cfg.PRDFile, err)
+	}
+	return cause
+}
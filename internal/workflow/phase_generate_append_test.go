@@ -0,0 +1,147 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+	"ralph/internal/shared/runner"
+)
+
+func newAppendTestExecutor(t *testing.T, generated *prd.PRD) (*Executor, *config.Config, *string) {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.Append = true
+
+	existing := &prd.PRD{ProjectName: "Invites", BranchName: "feature/invites", Stories: []*prd.Story{
+		{ID: "story-1", Title: "Invite API", Description: "Desc", Slices: prdtest.Slices("returns 201"), Priority: 1, Passes: true},
+	}}
+	existing.Stories[0].Slices[0].Passes = true
+	if err := prd.Save(cfg, existing); err != nil {
+		t.Fatalf("save existing PRD: %v", err)
+	}
+
+	var gotPrompt string
+	mock := newMockRunner()
+	mock.runFunc = func(_ context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		gotPrompt = prompt
+		data, err := json.Marshal(generated)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(cfg.PRDPath(), data, 0o644)
+	}
+	return NewExecutorWithRunner(cfg, make(chan Event, 100), mock), cfg, &gotPrompt
+}
+
+func TestRunGenerateAppendMergesIntoExistingPRD(t *testing.T) {
+	exec, cfg, gotPrompt := newAppendTestExecutor(t, &prd.PRD{ProjectName: "Accept", BranchName: "feature/accept", Stories: []*prd.Story{
+		{ID: "story-2", Title: "Accept invite", Description: "Desc", Slices: prdtest.Slices("marks accepted"), Priority: 1},
+	}})
+
+	p, err := exec.RunGenerate(context.Background(), "add accepting invites")
+	if err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+	if !strings.Contains(*gotPrompt, "do not reuse these story ids: story-1") {
+		t.Errorf("generation prompt does not list the existing story ids:\n%s", *gotPrompt)
+	}
+
+	saved, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*prd.PRD{p, saved} {
+		if got.BranchName != "feature/invites" || len(got.Stories) != 2 {
+			t.Fatalf("PRD branch=%q stories=%d, want the existing branch and 2 stories", got.BranchName, len(got.Stories))
+		}
+		if !got.GetStory("story-1").Passes || got.GetStory("story-2") == nil {
+			t.Fatalf("stories = %+v, want story-1 still passing and story-2 appended", got.Stories)
+		}
+	}
+}
+
+func TestRunGenerateAppendConflictRestoresExistingPRD(t *testing.T) {
+	exec, cfg, _ := newAppendTestExecutor(t, &prd.PRD{ProjectName: "Other", Stories: []*prd.Story{
+		{ID: "story-1", Title: "Something else", Description: "Desc", Slices: prdtest.Slices("other"), Priority: 1},
+	}})
+
+	_, err := exec.RunGenerate(context.Background(), "add something")
+	if err == nil || !strings.Contains(err.Error(), `story "story-1" already exists with different content`) {
+		t.Fatalf("RunGenerate() error = %v, want an ID collision error", err)
+	}
+
+	saved, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ProjectName != "Invites" || len(saved.Stories) != 1 || !saved.Stories[0].Passes {
+		t.Fatalf("saved PRD = %+v, want the original PRD restored", saved)
+	}
+}
+
+func TestRunGenerateAppendUntouchedPRDIsNotGenerated(t *testing.T) {
+	exec, cfg, _ := newAppendTestExecutor(t, nil)
+	exec.runner.(*mockRunner).runFunc = func(context.Context, string, chan<- runner.OutputLine) error { return nil }
+
+	_, err := exec.RunGenerate(context.Background(), "add something")
+	var notGenerated *PRDNotGeneratedError
+	if !errors.As(err, &notGenerated) {
+		t.Fatalf("RunGenerate() error = %v, want *PRDNotGeneratedError", err)
+	}
+	saved, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ProjectName != "Invites" || len(saved.Stories) != 1 {
+		t.Fatalf("saved PRD = %+v, want the original PRD", saved)
+	}
+}
+
+func TestRunGenerateAppendRunnerErrorRestoresExistingPRD(t *testing.T) {
+	exec, cfg, _ := newAppendTestExecutor(t, nil)
+	exec.runner.(*mockRunner).runFunc = func(context.Context, string, chan<- runner.OutputLine) error {
+		if err := os.WriteFile(cfg.PRDPath(), []byte(`{"project_name": "Half`), 0o644); err != nil {
+			return err
+		}
+		return errors.New("runner crashed")
+	}
+
+	_, err := exec.RunGenerate(context.Background(), "add something")
+	if err == nil || !strings.Contains(err.Error(), "runner crashed") {
+		t.Fatalf("RunGenerate() error = %v, want the runner error", err)
+	}
+	saved, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatalf("existing PRD not restored: %v", err)
+	}
+	if saved.ProjectName != "Invites" || !saved.Stories[0].Passes {
+		t.Fatalf("saved PRD = %+v, want the original PRD restored", saved)
+	}
+}
+
+func TestRunGenerateRecordsPRDHistory(t *testing.T) {
+	exec, cfg, _ := newAppendTestExecutor(t, &prd.PRD{ProjectName: "Accept", BranchName: "feature/accept", Stories: []*prd.Story{
+		{ID: "story-2", Title: "Accept invite", Description: "Desc", Slices: prdtest.Slices("marks accepted"), Priority: 1},
+	}})
+	cfg.PRDHistoryDir = "history"
+
+	if _, err := exec.RunGenerate(context.Background(), "add accepting invites"); err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+	entries, err := prd.ListHistory(cfg.PRDHistoryPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Stories != 2 {
+		t.Fatalf("history = %+v, want one entry with the merged PRD's 2 stories", entries)
+	}
+}
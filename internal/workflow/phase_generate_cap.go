@@ -0,0 +1,34 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+)
+
+// storyCapPromptNote asks the runner for at most --stories N stories, or is
+// empty when there is no cap.
+func storyCapPromptNote(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\nWrite at most %d stories. If the request needs more, keep the %d most important ones and leave the rest out.", limit, limit)
+}
+
+// enforceStoryCap trims a generated PRD that ignored --stories down to its
+// highest-priority stories and saves the result over the runner's file.
+func (e *Executor) enforceStoryCap(p *prd.PRD) error {
+	written := len(p.Stories)
+	removed := p.KeepTopStories(e.cfg.MaxStories)
+	if len(removed) == 0 {
+		return nil
+	}
+	if err := e.store.Save(e.cfg, p); err != nil {
+		return fmt.Errorf("failed to save PRD trimmed to --stories %d: %w", e.cfg.MaxStories, err)
+	}
+	logger.Warn("runner wrote more stories than --stories allows", "written", written, "limit", e.cfg.MaxStories, "removed", removed)
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Warning: runner wrote %d stories; kept the %d highest-priority ones for --stories and dropped %s", written, e.cfg.MaxStories, strings.Join(removed, ", ")), IsErr: true}})
+	return nil
+}
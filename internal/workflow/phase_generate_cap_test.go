@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+	"ralph/internal/shared/runner"
+)
+
+func TestRunGenerateEnforcesStoryCap(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.MaxStories = 2
+
+	generated := &prd.PRD{ProjectName: "Invites", Stories: []*prd.Story{
+		{ID: "story-1", Title: "Emails", Description: "Desc", Slices: prdtest.Slices("sends mail"), Priority: 3},
+		{ID: "story-2", Title: "API", Description: "Desc", Slices: prdtest.Slices("returns 201"), Priority: 1},
+		{ID: "story-3", Title: "Accept", Description: "Desc", Slices: prdtest.Slices("marks accepted"), Priority: 2, DependsOn: []string{"story-2"}},
+		{ID: "story-4", Title: "Audit", Description: "Desc", Slices: prdtest.Slices("logs invites"), Priority: 4},
+	}}
+	var gotPrompt string
+	mock := newMockRunner()
+	mock.runFunc = func(_ context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		gotPrompt = prompt
+		data, err := json.Marshal(generated)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(cfg.PRDPath(), data, 0o644)
+	}
+	ch := make(chan Event, 100)
+
+	p, err := NewExecutorWithRunner(cfg, ch, mock).RunGenerate(context.Background(), "invites")
+	if err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+	if !strings.Contains(gotPrompt, "Write at most 2 stories") {
+		t.Errorf("generation prompt does not ask for at most 2 stories:\n%s", gotPrompt)
+	}
+	saved, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*prd.PRD{p, saved} {
+		if len(got.Stories) != 2 || got.GetStory("story-2") == nil || got.GetStory("story-3") == nil {
+			t.Fatalf("stories = %+v, want only the two highest-priority stories", got.Stories)
+		}
+	}
+	warned := false
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && out.IsErr && strings.Contains(out.Text, "dropped story-1, story-4") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Error("no warning naming the dropped stories")
+	}
+}
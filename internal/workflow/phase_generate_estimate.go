@@ -0,0 +1,37 @@
+package workflow
+
+import (
+	"fmt"
+
+	"ralph/internal/estimate"
+	"ralph/internal/shared/prd"
+)
+
+// tokenizer backs the dry-run token estimate.
+var tokenizer estimate.Tokenizer = estimate.Heuristic{}
+
+// estimateRunTokens approximates the prompt tokens a real run of p sends: the
+// generation prompt plus one implementation prompt per pending slice, built
+// the same way the implementation phase builds them. Runner output, files the
+// runner reads, reviews, and retries are not counted.
+func (e *Executor) estimateRunTokens(p *prd.PRD, generationPrompt string) int {
+	prompts := []string{generationPrompt}
+	for _, story := range p.Stories {
+		if story == nil || story.Done() {
+			continue
+		}
+		for _, slice := range story.Slices {
+			if slice == nil || slice.Passes {
+				continue
+			}
+			text, _ := e.slicePrompt(p, story, slice)
+			prompts = append(prompts, text)
+		}
+	}
+	return estimate.Sum(tokenizer, prompts...)
+}
+
+// reportTokenEstimate prints the dry-run token estimate.
+func (e *Executor) reportTokenEstimate(p *prd.PRD, generationPrompt string) {
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Estimated prompt tokens: ~%d (approximate: about 4 characters per token; excludes runner output, files it reads, reviews, and retries)", e.estimateRunTokens(p, generationPrompt))}})
+}
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"ralph/internal/estimate"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+	"ralph/internal/shared/runner"
+)
+
+func TestEstimateRunTokensCountsPendingSlicePrompts(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	exec := NewExecutorWithRunner(cfg, nil, newMockRunner())
+	p := &prd.PRD{ProjectName: "Invites", Stories: []*prd.Story{
+		{ID: "story-1", Title: "Done", Description: "Desc", Slices: prdtest.Slices("sent"), Passes: true},
+		{ID: "story-2", Title: "Open", Description: "Desc", Slices: []*prd.Slice{
+			{ID: "slice-1", Behavior: "returns 201", RedHint: "POST test"},
+			{ID: "slice-2", Behavior: "returns 409", RedHint: "duplicate test"},
+		}},
+	}}
+	p.Stories[0].Slices[0].Passes = true
+
+	story := p.Stories[1]
+	first, _ := exec.slicePrompt(p, story, story.Slices[0])
+	second, _ := exec.slicePrompt(p, story, story.Slices[1])
+	want := estimate.Sum(estimate.Heuristic{}, "generate", first, second)
+	if got := exec.estimateRunTokens(p, "generate"); got != want {
+		t.Fatalf("estimateRunTokens() = %d, want %d (generation plus the two pending slice prompts)", got, want)
+	}
+}
+
+func TestRunGenerateDryRunReportsTokenEstimate(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.DryRun = true
+	mock := newMockRunner()
+	mock.runFunc = func(context.Context, string, chan<- runner.OutputLine) error {
+		data, err := json.Marshal(&prd.PRD{ProjectName: "Invites", Stories: []*prd.Story{
+			{ID: "story-1", Title: "API", Description: "Desc", Slices: prdtest.Slices("returns 201"), Priority: 1},
+		}})
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(cfg.PRDPath(), data, 0o644)
+	}
+	ch := make(chan Event, 100)
+
+	if _, err := NewExecutorWithRunner(cfg, ch, mock).RunGenerate(context.Background(), "invites"); err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && strings.HasPrefix(out.Text, "Estimated prompt tokens: ~") && strings.Contains(out.Text, "approximate") {
+			return
+		}
+	}
+	t.Fatal("dry run did not report an approximate token estimate")
+}
@@ -2,11 +2,13 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
+	"ralph/internal/workflow/events"
 )
 
 func describeBlockedStories(p *prd.PRD, blocked []*prd.Story) string {
@@ -15,7 +17,7 @@ func describeBlockedStories(p *prd.PRD, blocked []*prd.Story) string {
 		var unsatisfied []string
 		for _, depID := range story.DependsOn {
 			dep := p.GetStory(depID)
-			if dep == nil || !dep.Passes {
+			if dep == nil || !dep.Done() {
 				unsatisfied = append(unsatisfied, depID)
 			}
 		}
@@ -31,6 +33,19 @@ func (e *Executor) RunImplementation(ctx context.Context, p *prd.PRD) error {
 		"total_stories", len(p.Stories),
 		"completed", p.CompletedCount())
 
+	e.unfinishedStories = nil
+	e.consecutiveFailures = nil
+	e.lastPRD.Store(p.Clone())
+	e.reportSkippedStories(p)
+	if e.cfg.NoCommit {
+		e.emit(EventOutput{Output: Output{Text: "Auto-commit disabled (--no-commit): story changes are left uncommitted for you to review."}})
+	}
+	if limit := e.cfg.StoryConcurrency(); limit > 1 {
+		if e.cfg.RollbackOnFail {
+			e.emit(EventOutput{Output: Output{Text: "RALPH_ROLLBACK_ON_FAIL is ignored with RALPH_CONCURRENCY > 1: stories share one work tree.", IsErr: true}})
+		}
+		return e.runStoriesConcurrently(ctx, limit)
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -38,6 +53,9 @@ func (e *Executor) RunImplementation(ctx context.Context, p *prd.PRD) error {
 			return ctx.Err()
 		default:
 		}
+		if err := e.waitWhilePaused(ctx); err != nil {
+			return err
+		}
 
 		p, err := e.store.Load(e.cfg)
 		if err != nil {
@@ -46,26 +64,12 @@ func (e *Executor) RunImplementation(ctx context.Context, p *prd.PRD) error {
 			e.emit(EventError{Err: fmt.Errorf("cannot continue without PRD: %w", wrappedErr)})
 			return wrappedErr
 		}
+		e.reportExternalPRDChanges(p)
 
-		if p.AllCompleted() {
-			logger.Info("all stories completed successfully")
-			if !e.cfg.SkipCleanup {
-				if err := e.RunCleanup(ctx, p); err != nil {
-					return err
-				}
-			}
-
-			return e.completeRunAfterCleanup(ctx, p)
-		}
-
-		story := p.NextReadyStory()
+		story := e.nextReadyStory(p, e.unfinishedStories)
 		if story == nil {
-			blocked := p.BlockedStories()
-			if len(blocked) > 0 {
-				logger.Error("no ready stories, all incomplete stories are dependency-blocked", "blocked_count", len(blocked))
-				blockedErr := fmt.Errorf("all incomplete stories are dependency-blocked: %s", describeBlockedStories(p, blocked))
-				e.emit(EventError{Err: blockedErr})
-				return blockedErr
+			if done, err := e.finishImplementation(ctx, p); done {
+				return err
 			}
 			continue
 		}
@@ -74,17 +78,57 @@ func (e *Executor) RunImplementation(ctx context.Context, p *prd.PRD) error {
 			"story_id", story.ID,
 			"title", story.Title)
 
+		if err := e.markStoryStarted(p, story); err != nil {
+			e.emit(EventError{Err: err})
+			return err
+		}
 		e.emit(EventStoryStarted{Story: story})
 
-		updatedPRD, updatedStory, sliceErr := e.runStorySlices(ctx, p, story)
+		checkpoint := e.storyCheckpoint()
+		statBase := e.storyStatBase()
+		storyCtx, cancelStory := context.WithCancel(ctx)
+		e.setStoryCancel(cancelStory)
+		updatedPRD, updatedStory, sliceErr := e.runStorySlicesWithinBudget(storyCtx, p, story)
+		e.setStoryCancel(nil)
+		storyCanceled := storyCtx.Err() != nil && ctx.Err() == nil
+		cancelStory()
+		if ctx.Err() != nil {
+			e.recordInterruptedStory(story.ID)
+		}
+		if sliceErr != nil && storyCanceled {
+			logger.Info("story canceled, requeueing", "story_id", story.ID)
+			e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Canceled story %s; requeueing it.", story.ID)}})
+			e.emit(EventStoryCompleted{Story: story, Result: events.StoryFailedRetryable})
+			e.rollbackStory(story.ID, checkpoint)
+			e.countStoryInterruption(story.ID)
+			if abortErr := e.recordConsecutiveFailure(story); abortErr != nil {
+				e.emit(EventError{Err: abortErr})
+				return abortErr
+			}
+			continue
+		}
 		if sliceErr != nil {
 			logger.Error("implementation runner failed", "error", sliceErr, "story_id", story.ID)
+			e.emit(EventStoryCompleted{Story: story, Result: classifyStoryFailure(ctx, sliceErr)})
+			if ctx.Err() == nil {
+				e.rollbackStory(story.ID, checkpoint)
+			}
+			if abortErr := e.recordConsecutiveFailure(story); abortErr != nil && ctx.Err() == nil {
+				e.emit(EventError{Err: abortErr})
+				return abortErr
+			}
+			if e.cfg.BestEffort && ctx.Err() == nil {
+				e.skipUnfinishedStory(story, sliceErr)
+				continue
+			}
 			e.emit(EventError{Err: sliceErr})
 			return sliceErr
 		}
 
 		logger.Debug("story completed", "story_id", story.ID)
-		e.emit(EventStoryCompleted{Story: updatedStory, Success: true})
+		e.reportStoryDiffStat(story.ID, statBase)
+		e.emit(EventStoryCompleted{Story: updatedStory, Success: true, Result: events.StoryPassed})
+		e.consecutiveFailures = nil
 
 		e.resetRecoveryAttempts()
 		if err := e.runTestGateWithRecovery(ctx, updatedPRD); err != nil {
@@ -92,3 +136,212 @@ func (e *Executor) RunImplementation(ctx context.Context, p *prd.PRD) error {
 		}
 	}
 }
+
+// finishImplementation handles a pass of the story loop with nothing ready to
+// start. It reports done once the run has completed, a --best-effort run has
+// run out of stories, or the remaining stories are dependency-blocked.
+func (e *Executor) finishImplementation(ctx context.Context, p *prd.PRD) (bool, error) {
+	if p.AllCompleted() {
+		logger.Info("all stories completed successfully")
+		if !e.cfg.SkipCleanup {
+			if err := e.RunCleanup(ctx, p); err != nil {
+				return true, err
+			}
+		}
+
+		return true, e.completeRunAfterCleanup(ctx, p)
+	}
+	if len(e.unfinishedStories) > 0 {
+		return true, e.completeBestEffort(p)
+	}
+	blocked := p.BlockedStories()
+	if len(blocked) == 0 {
+		return false, nil
+	}
+	logger.Error("no ready stories, all incomplete stories are dependency-blocked", "blocked_count", len(blocked))
+	blockedErr := fmt.Errorf("all incomplete stories are dependency-blocked: %s", describeBlockedStories(p, blocked))
+	for _, blockedStory := range blocked {
+		e.emit(EventStoryCompleted{Story: blockedStory, Result: events.StoryBlocked})
+	}
+	e.emit(EventError{Err: blockedErr})
+	return true, blockedErr
+}
+
+// reportSkippedStories reports each story left out of the run, by --skip or by
+// unchecking it in PRD review, as completed with StorySkipped since the loop
+// passes over it without starting it.
+func (e *Executor) reportSkippedStories(p *prd.PRD) {
+	for _, story := range p.Stories {
+		if story.Skip && !story.Passes {
+			e.emit(EventStoryCompleted{Story: story, Result: events.StorySkipped})
+		}
+	}
+}
+
+// markStoryStarted bumps the PRD iteration count and stamps the story's first
+// start time so both survive a resume.
+func (e *Executor) markStoryStarted(p *prd.PRD, story *prd.Story) error {
+	p.Iterations++
+	if story.StartedAt.IsZero() {
+		story.StartedAt = e.clock.Now()
+	}
+	if err := e.savePRD(p); err != nil {
+		return fmt.Errorf("failed to save PRD before starting story %s: %w", story.ID, err)
+	}
+	if story.Interruptions > 0 {
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Resuming story %s after %d interrupted attempt(s).", story.ID, story.Interruptions)}})
+	}
+	return nil
+}
+
+// recordConsecutiveFailure counts distinct stories failing back to back and
+// returns an abort error once RALPH_MAX_CONSECUTIVE_FAILURES is reached, on the
+// assumption that the environment rather than the stories is broken.
+func (e *Executor) recordConsecutiveFailure(story *prd.Story) error {
+	e.consecutiveFailures = append(e.consecutiveFailures, story.ID)
+	limit := e.cfg.MaxConsecutiveFails
+	if limit <= 0 || len(e.consecutiveFailures) < limit {
+		return nil
+	}
+	logger.Error("too many consecutive story failures, aborting", "limit", limit, "stories", e.consecutiveFailures)
+	return fmt.Errorf("aborting after %d consecutive story failures (%s); the environment may be broken", len(e.consecutiveFailures), strings.Join(e.consecutiveFailures, ", "))
+}
+
+// skipUnfinishedStory sets a failed story aside for the rest of a --best-effort
+// run so the loop moves on to stories that do not depend on it.
+func (e *Executor) skipUnfinishedStory(story *prd.Story, err error) {
+	if e.unfinishedStories == nil {
+		e.unfinishedStories = make(map[string]bool)
+	}
+	e.unfinishedStories[story.ID] = true
+	e.resetRecoveryAttempts()
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Story %s failed; continuing with the remaining stories (--best-effort): %v", story.ID, err), IsErr: true}})
+}
+
+// completeBestEffort ends a --best-effort run once only failed stories and
+// their dependents remain. Cleanup and the final test gate are skipped since
+// the tree is known to be incomplete.
+func (e *Executor) completeBestEffort(p *prd.PRD) error {
+	var unfinished []string
+	for _, story := range p.Stories {
+		if !story.Done() {
+			unfinished = append(unfinished, story.ID)
+		}
+	}
+	logger.Warn("best-effort run finished with unfinished stories", "unfinished", unfinished)
+	e.writeCompletionSummary()
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Completed %d/%d stories; unfinished: %s", p.CompletedCount(), len(p.Stories), strings.Join(unfinished, ", "))}})
+	e.emit(EventCompleted{Unfinished: unfinished})
+	return nil
+}
+
+// classifyStoryFailure maps a story error that ended the run to a StoryResult.
+// A canceled run leaves the story pending for --resume, so it stays retryable.
+func classifyStoryFailure(ctx context.Context, err error) events.StoryResult {
+	switch {
+	case ctx.Err() != nil:
+		return events.StoryFailedRetryable
+	case errors.Is(err, context.DeadlineExceeded):
+		return events.StoryTimedOut
+	case errors.As(err, new(*StoryBudgetError)):
+		return events.StoryTimedOut
+	default:
+		return events.StoryFailedExhausted
+	}
+}
+
+// CancelCurrentStory cancels only the in-flight story's runner. The story is
+// reported as a failed attempt, counted in its Interruptions and toward
+// RALPH_MAX_CONSECUTIVE_FAILURES, and left incomplete so the loop picks it up
+// again. It reports whether a story was running.
+func (e *Executor) CancelCurrentStory() bool {
+	e.storyMu.Lock()
+	defer e.storyMu.Unlock()
+	if e.storyCancel == nil {
+		return false
+	}
+	e.storyCancel()
+	return true
+}
+
+func (e *Executor) setStoryCancel(cancel context.CancelFunc) {
+	e.storyMu.Lock()
+	e.storyCancel = cancel
+	e.storyMu.Unlock()
+}
+
+// SetPaused holds the story loop before it starts another story. The story
+// in flight is not interrupted; unpausing lets the loop continue.
+func (e *Executor) SetPaused(paused bool) {
+	e.storyMu.Lock()
+	defer e.storyMu.Unlock()
+	switch {
+	case paused && e.resumeCh == nil:
+		e.resumeCh = make(chan struct{})
+	case !paused && e.resumeCh != nil:
+		close(e.resumeCh)
+		e.resumeCh = nil
+	}
+}
+
+// Paused reports whether SetPaused(true) is holding the story loop.
+func (e *Executor) Paused() bool {
+	e.storyMu.Lock()
+	defer e.storyMu.Unlock()
+	return e.resumeCh != nil
+}
+
+func (e *Executor) waitWhilePaused(ctx context.Context) error {
+	e.storyMu.Lock()
+	resumeCh := e.resumeCh
+	e.storyMu.Unlock()
+	if resumeCh == nil {
+		return nil
+	}
+	logger.Info("story loop paused")
+	select {
+	case <-resumeCh:
+		logger.Info("story loop resumed")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// savePRD saves p and remembers it as the version this run last wrote, so
+// reportExternalPRDChanges can tell its own saves from outside edits.
+func (e *Executor) savePRD(p *prd.PRD) error {
+	if err := e.store.Save(e.cfg, p); err != nil {
+		return err
+	}
+	e.lastPRD.Store(p.Clone())
+	return nil
+}
+
+// reportExternalPRDChanges emits what changed when a reloaded PRD is a
+// different version from the one this run last saw, e.g. after a hand edit
+// or a validation pass rewrote it mid-run.
+func (e *Executor) reportExternalPRDChanges(p *prd.PRD) {
+	last := e.lastPRD.Swap(p.Clone())
+	if last == nil || last.Version == p.Version {
+		return
+	}
+	changes := last.Diff(p)
+	if len(changes) == 0 {
+		return
+	}
+	logger.Info("PRD changed outside this run", "from_version", last.Version, "to_version", p.Version, "changes", len(changes))
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("PRD %s changed outside this run (version %d -> %d):", e.cfg.PRDFile, last.Version, p.Version)}})
+	for _, change := range changes {
+		e.emit(EventOutput{Output: Output{Text: "  " + change}})
+	}
+}
+
+// nextReadyStory picks the next story to implement, breaking priority ties by
+// complexity when RALPH_SIMPLE_FIRST is set.
+func (e *Executor) nextReadyStory(p *prd.PRD, except map[string]bool) *prd.Story {
+	if e.cfg.SimpleFirst {
+		return p.NextSimplestReadyStoryExcept(except)
+	}
+	return p.NextReadyStoryExcept(except)
+}
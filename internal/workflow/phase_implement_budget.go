@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+	"ralph/internal/workflow/events"
+)
+
+// StoryBudgetError is returned for a story that used up RALPH_STORY_TIME_BUDGET.
+// The story is failed without further retries or recovery.
+type StoryBudgetError struct {
+	StoryID string
+	Budget  time.Duration
+}
+
+func (e *StoryBudgetError) Error() string {
+	return fmt.Sprintf("story %s exceeded its time budget of %s", e.StoryID, e.Budget)
+}
+
+// runStorySlicesWithinBudget runs runStorySlices under what is left of the
+// story's time budget. Time is counted per story for the whole run, so a
+// requeued story picks up where it left off rather than getting a fresh
+// budget.
+func (e *Executor) runStorySlicesWithinBudget(ctx context.Context, p *prd.PRD, story *prd.Story) (*prd.PRD, *prd.Story, error) {
+	budget := e.cfg.StoryTimeBudget
+	if budget <= 0 {
+		return e.runStorySlices(ctx, p, story)
+	}
+	budgetErr := &StoryBudgetError{StoryID: story.ID, Budget: budget}
+	remaining := budget - e.storyElapsed(story.ID)
+	if remaining <= 0 {
+		e.reportStoryBudgetExceeded(budgetErr)
+		return nil, nil, budgetErr
+	}
+
+	budgetCtx, cancel := context.WithTimeoutCause(ctx, remaining, budgetErr)
+	started := e.clock.Now()
+	updatedPRD, updatedStory, err := e.runStorySlices(budgetCtx, p, story)
+	e.addStoryElapsed(story.ID, e.clock.Now().Sub(started))
+	cancel()
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(budgetCtx), budgetErr) {
+		e.reportStoryBudgetExceeded(budgetErr)
+		return nil, nil, budgetErr
+	}
+	return updatedPRD, updatedStory, err
+}
+
+func (e *Executor) reportStoryBudgetExceeded(err *StoryBudgetError) {
+	logger.Warn("story time budget exceeded", "story_id", err.StoryID, "budget", err.Budget)
+	e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Story %s ran past its %s time budget (RALPH_STORY_TIME_BUDGET); failing it without further retries.", err.StoryID, err.Budget), IsErr: true}})
+}
+
+func (e *Executor) storyElapsed(storyID string) time.Duration {
+	e.storyMu.Lock()
+	defer e.storyMu.Unlock()
+	return e.storyTime[storyID]
+}
+
+func (e *Executor) addStoryElapsed(storyID string, d time.Duration) {
+	e.storyMu.Lock()
+	defer e.storyMu.Unlock()
+	if e.storyTime == nil {
+		e.storyTime = make(map[string]time.Duration)
+	}
+	e.storyTime[storyID] += d
+}
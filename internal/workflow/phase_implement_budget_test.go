@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ralph/internal/shared/runner"
+	"ralph/internal/workflow/events"
+)
+
+func TestRunImplementationFailsStoryOverTimeBudget(t *testing.T) {
+	var calls int
+	exec, p, ch := newResultTestExecutor(t, func(ctx context.Context, _ string, _ chan<- runner.OutputLine) error {
+		calls++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	exec.cfg.StoryTimeBudget = 20 * time.Millisecond
+
+	err := exec.RunImplementation(context.Background(), p)
+	var budgetErr *StoryBudgetError
+	if !errors.As(err, &budgetErr) || budgetErr.StoryID != "1" {
+		t.Fatalf("RunImplementation() error = %v, want *StoryBudgetError for story 1", err)
+	}
+	if calls != 1 {
+		t.Errorf("runner calls = %d, want 1 (no retry or recovery once the budget is spent)", calls)
+	}
+
+	var completed []EventStoryCompleted
+	var explained bool
+	for _, e := range drainEvents(ch) {
+		switch ev := e.(type) {
+		case EventStoryCompleted:
+			completed = append(completed, ev)
+		case EventOutput:
+			explained = explained || strings.Contains(ev.Text, "RALPH_STORY_TIME_BUDGET")
+		}
+	}
+	if len(completed) != 1 || completed[0].Success || completed[0].Result != events.StoryTimedOut {
+		t.Errorf("story completions = %+v, want one failed, timed-out completion", completed)
+	}
+	if !explained {
+		t.Error("no output explained the exceeded time budget")
+	}
+}
+
+func TestRunImplementationSkipsStoryWithSpentTimeBudget(t *testing.T) {
+	var calls int
+	exec, p, ch := newResultTestExecutor(t, func(context.Context, string, chan<- runner.OutputLine) error {
+		calls++
+		return nil
+	})
+	exec.cfg.StoryTimeBudget = time.Minute
+	exec.cfg.BestEffort = true
+	exec.addStoryElapsed("1", time.Minute)
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v, want nil with --best-effort", err)
+	}
+	if calls != 0 {
+		t.Errorf("runner calls = %d, want 0 for a story whose budget was already spent", calls)
+	}
+	if got := storyResults(ch); len(got) != 1 || got[0] != events.StoryTimedOut {
+		t.Errorf("story results = %v, want [%s]", got, events.StoryTimedOut)
+	}
+}
@@ -0,0 +1,208 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+	"ralph/internal/workflow/events"
+)
+
+type storyRunResult struct {
+	story        *prd.Story
+	updatedStory *prd.Story
+	err          error
+}
+
+// runStoriesConcurrently is the RunImplementation loop for RALPH_CONCURRENCY > 1.
+// Up to limit ready stories run at once, each in its own runner session, and a
+// new story starts as soon as one finishes. PRD updates and commits go through
+// prdMu. The per-story test gate is skipped because other stories may be
+// mid-edit; the final gate still runs before the run completes. Stories cannot
+// be canceled individually in this mode, and pausing lets running stories
+// finish without starting new ones. Runner sessions overlap, but a commit
+// waits until none is editing (see commitConcurrentStoryChanges).
+func (e *Executor) runStoriesConcurrently(ctx context.Context, limit int) error {
+	workCtx, cancelWork := context.WithCancel(ctx)
+	defer cancelWork()
+
+	var wg sync.WaitGroup
+	results := make(chan storyRunResult, limit)
+	running := make(map[string]bool)
+	stop := func(err error) error {
+		cancelWork()
+		wg.Wait()
+		if ctx.Err() != nil {
+			for storyID := range running {
+				e.recordInterruptedStory(storyID)
+			}
+		}
+		return err
+	}
+
+	for {
+		if ctx.Err() != nil {
+			logger.Debug("context cancelled")
+			return stop(ctx.Err())
+		}
+
+		if len(running) == 0 {
+			if err := e.waitWhilePaused(ctx); err != nil {
+				return err
+			}
+		}
+
+		for len(running) < limit && !e.Paused() {
+			storyPRD, story, err := e.startNextStory(running)
+			if err != nil {
+				e.emit(EventError{Err: err})
+				return stop(err)
+			}
+			if story == nil {
+				break
+			}
+			running[story.ID] = true
+			e.emit(EventStoryStarted{Story: story})
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, updatedStory, err := e.runStorySlicesWithinBudget(withStory(workCtx, story.ID), storyPRD, story)
+				results <- storyRunResult{story: story, updatedStory: updatedStory, err: err}
+			}()
+		}
+
+		if len(running) == 0 && e.Paused() {
+			continue
+		}
+		if len(running) == 0 {
+			p, err := e.store.Load(e.cfg)
+			if err != nil {
+				wrappedErr := fmt.Errorf("failed to reload PRD %s: %w", e.cfg.PRDFile, err)
+				e.emit(EventError{Err: fmt.Errorf("cannot continue without PRD: %w", wrappedErr)})
+				return wrappedErr
+			}
+			e.reportExternalPRDChanges(p)
+			if done, err := e.finishImplementation(ctx, p); done {
+				return err
+			}
+			continue
+		}
+
+		var res storyRunResult
+		select {
+		case res = <-results:
+		case <-ctx.Done():
+			return stop(ctx.Err())
+		}
+		delete(running, res.story.ID)
+
+		if res.err != nil {
+			logger.Error("implementation runner failed", "error", res.err, "story_id", res.story.ID)
+			e.emit(EventStoryCompleted{Story: res.story, Result: classifyStoryFailure(ctx, res.err)})
+			if abortErr := e.recordConsecutiveFailure(res.story); abortErr != nil && ctx.Err() == nil {
+				e.emit(EventError{Err: abortErr})
+				return stop(abortErr)
+			}
+			if e.cfg.BestEffort && ctx.Err() == nil {
+				e.recoveryMu.Lock()
+				e.skipUnfinishedStory(res.story, res.err)
+				e.recoveryMu.Unlock()
+				e.forgetStoryRecoveryAttempts(res.story.ID)
+				continue
+			}
+			e.emit(EventError{Err: res.err})
+			return stop(res.err)
+		}
+
+		logger.Debug("story completed", "story_id", res.story.ID)
+		e.emit(EventStoryCompleted{Story: res.updatedStory, Success: true, Result: events.StoryPassed})
+		e.consecutiveFailures = nil
+
+		e.forgetStoryRecoveryAttempts(res.story.ID)
+	}
+}
+
+type storyContextKey struct{}
+
+// withStory tags ctx with the story its runner sessions work on.
+func withStory(ctx context.Context, storyID string) context.Context {
+	return context.WithValue(ctx, storyContextKey{}, storyID)
+}
+
+func storyFromContext(ctx context.Context) string {
+	storyID, _ := ctx.Value(storyContextKey{}).(string)
+	return storyID
+}
+
+// recordStorySession notes that a session for storyID ended. It runs before
+// the session releases editMu, so the next commit knows whose work is on disk.
+func (e *Executor) recordStorySession(storyID string) {
+	e.sessionMu.Lock()
+	defer e.sessionMu.Unlock()
+	if e.sessionStories == nil {
+		e.sessionStories = make(map[string]bool)
+	}
+	e.sessionStories[storyID] = true
+}
+
+// commitConcurrentStoryChanges is commitStoryChanges for RALPH_CONCURRENCY > 1,
+// where every story edits the same work tree. The commit waits for editMu,
+// so no runner session is mid-edit when it stages. Sessions of other stories
+// that ended since the last commit left finished work on disk that goes into
+// the same commit, so those stories are named in the message body; their own
+// commit then finds nothing left to commit.
+func (e *Executor) commitConcurrentStoryChanges(storyID, message string) (bool, error) {
+	e.editMu.Lock()
+	defer e.editMu.Unlock()
+
+	e.sessionMu.Lock()
+	var others []string
+	for id := range e.sessionStories {
+		if id != storyID {
+			others = append(others, id)
+		}
+	}
+	e.sessionStories = nil
+	e.sessionMu.Unlock()
+
+	if len(others) > 0 {
+		sort.Strings(others)
+		message += "\n\nAlso includes finished runner sessions of " + strings.Join(others, ", ")
+	}
+	return commitStory(e.cfg.WorkDir, message, e.storyCommitOptions())
+}
+
+// startNextStory picks the next ready story that is neither running nor set
+// aside by --best-effort and records its start, or returns nil when none is
+// ready. The freshly loaded PRD it returns belongs to the story's worker.
+func (e *Executor) startNextStory(running map[string]bool) (*prd.PRD, *prd.Story, error) {
+	e.prdMu.Lock()
+	defer e.prdMu.Unlock()
+
+	p, err := e.store.Load(e.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reload PRD %s: %w", e.cfg.PRDFile, err)
+	}
+	e.reportExternalPRDChanges(p)
+	except := make(map[string]bool, len(running)+len(e.unfinishedStories))
+	for id := range running {
+		except[id] = true
+	}
+	for id := range e.unfinishedStories {
+		except[id] = true
+	}
+	story := e.nextReadyStory(p, except)
+	if story == nil {
+		return nil, nil, nil
+	}
+	logger.Debug("starting story", "story_id", story.ID, "title", story.Title)
+	if err := e.markStoryStarted(p, story); err != nil {
+		return nil, nil, err
+	}
+	return p, story, nil
+}
@@ -0,0 +1,274 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	promptpkg "ralph/internal/prompt"
+	"ralph/internal/shared/clock/clocktest"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+	"ralph/internal/shared/runner"
+	"ralph/internal/shared/testgit"
+)
+
+func newConcurrentTestExecutor(t *testing.T, concurrency int, stories []*prd.Story, runFunc func(context.Context, string, chan<- runner.OutputLine) error) (*Executor, *prd.PRD, chan Event) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	cfg.Concurrency = concurrency
+
+	p := &prd.PRD{ProjectName: "Test", Stories: stories}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 500)
+	mock := newMockRunner()
+	mock.runFunc = runFunc
+	return NewExecutorWithRunner(cfg, ch, mock), p, ch
+}
+
+func TestRunImplementationRunsIndependentStoriesConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	bothStarted := make(chan struct{})
+	var workDir string
+
+	exec, p, ch := newConcurrentTestExecutor(t, 2, []*prd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+		{ID: "story-2", Title: "Two", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2},
+		{ID: "story-3", Title: "Three", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 3},
+	}, func(ctx context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if !isStoryImplementPrompt(prompt) {
+			return nil
+		}
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		n := maxInFlight
+		mu.Unlock()
+		if n == 2 {
+			select {
+			case <-bothStarted:
+			default:
+				close(bothStarted)
+			}
+		}
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		select {
+		case <-bothStarted:
+		case <-time.After(5 * time.Second):
+			return errors.New("second story never started")
+		}
+		name := fmt.Sprintf("%d.txt", time.Now().UnixNano())
+		return os.WriteFile(filepath.Join(workDir, name), []byte(name), 0o644)
+	})
+	workDir = exec.cfg.WorkDir
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	var started, passed int
+	for _, ev := range drainEvents(ch) {
+		switch ev := ev.(type) {
+		case EventStoryStarted:
+			started++
+		case EventStoryCompleted:
+			if ev.Success {
+				passed++
+			}
+		}
+	}
+	if started != 3 || passed != 3 {
+		t.Fatalf("started/passed = %d/%d, want 3/3", started, passed)
+	}
+	if maxInFlight != 2 {
+		t.Fatalf("max concurrent stories = %d, want 2", maxInFlight)
+	}
+
+	saved, err := prd.Load(exec.cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.AllCompleted() || saved.Iterations != 3 {
+		t.Fatalf("saved PRD completed=%v iterations=%d, want all completed after 3 iterations", saved.AllCompleted(), saved.Iterations)
+	}
+}
+
+func TestRunImplementationConcurrentRespectsDependencies(t *testing.T) {
+	exec, p, ch := newConcurrentTestExecutor(t, 3, []*prd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+		{ID: "story-2", Title: "Two", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2, DependsOn: []string{"story-1"}},
+	}, func(context.Context, string, chan<- runner.OutputLine) error { return nil })
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	var sequence []string
+	for _, ev := range drainEvents(ch) {
+		switch ev := ev.(type) {
+		case EventStoryStarted:
+			sequence = append(sequence, "start "+ev.Story.ID)
+		case EventStoryCompleted:
+			sequence = append(sequence, "done "+ev.Story.ID)
+		}
+	}
+	want := []string{"start story-1", "done story-1", "start story-2", "done story-2"}
+	if fmt.Sprint(sequence) != fmt.Sprint(want) {
+		t.Fatalf("story events = %v, want %v", sequence, want)
+	}
+}
+
+func TestRunImplementationConcurrentStopsOnStoryFailure(t *testing.T) {
+	exec, p, ch := newConcurrentTestExecutor(t, 2, []*prd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+		{ID: "story-2", Title: "Two", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2},
+	}, func(ctx context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if isStoryImplementPrompt(prompt) || isRecoveryPrompt(prompt) {
+			return errors.New("runner failed")
+		}
+		return nil
+	})
+	exec.cfg.RecoveryAttempts = 1
+
+	if err := exec.RunImplementation(context.Background(), p); err == nil {
+		t.Fatal("RunImplementation() error = nil, want story failure")
+	}
+
+	var sawError bool
+	for _, ev := range drainEvents(ch) {
+		if _, ok := ev.(EventError); ok {
+			sawError = true
+		}
+	}
+	if !sawError {
+		t.Fatal("expected EventError for the failed story")
+	}
+}
+
+func TestRunImplementationConcurrentCommitsWaitForRunningSessions(t *testing.T) {
+	started := make(chan string, 2)
+	var workDir string
+	executor, p, _ := newConcurrentTestExecutor(t, 2, []*prd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+		{ID: "story-2", Title: "Two", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2},
+	}, func(ctx context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if !isStoryImplementPrompt(prompt) {
+			return nil
+		}
+		if strings.Contains(prompt, "story-1") {
+			started <- "story-1"
+			return os.WriteFile(filepath.Join(workDir, "one.txt"), []byte("done"), 0o644)
+		}
+		started <- "story-2"
+		if err := os.WriteFile(filepath.Join(workDir, "two.txt"), []byte("half"), 0o644); err != nil {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+		return os.WriteFile(filepath.Join(workDir, "two.txt"), []byte("done"), 0o644)
+	})
+	workDir = executor.cfg.WorkDir
+
+	if err := executor.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	out, err := exec.Command("git", "-C", workDir, "log", "--format=%H%x00%B%x00").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Split(string(out), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		sha, message := strings.TrimSpace(fields[i]), fields[i+1]
+		content, err := exec.Command("git", "-C", workDir, "show", sha+":two.txt").Output()
+		if err != nil {
+			continue
+		}
+		if string(content) != "done" {
+			t.Fatalf("commit %q has two.txt = %q, want only the finished content", message, content)
+		}
+		if !strings.Contains(message, "story-2") {
+			t.Fatalf("commit %q contains story-2's work without naming it", message)
+		}
+	}
+}
+
+func TestRunImplementationConcurrentResetsRecoveryAttemptsPerStory(t *testing.T) {
+	failed := make(map[string]bool)
+	var mu sync.Mutex
+	executor, p, _ := newConcurrentTestExecutor(t, 2, []*prd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+		{ID: "story-2", Title: "Two", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2, DependsOn: []string{"story-1"}},
+	}, func(_ context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if !isStoryImplementPrompt(prompt) {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, id := range []string{"story-2", "story-1"} {
+			if strings.Contains(prompt, id) && !failed[id] {
+				failed[id] = true
+				return errors.New("runner failed")
+			}
+		}
+		return nil
+	})
+	executor.cfg.RecoveryAttempts = 1
+
+	if err := executor.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v, want story-2 to get its own recovery attempt", err)
+	}
+}
+
+func TestConcurrentStoriesKeepTheirOwnRecoveryAndRateLimitState(t *testing.T) {
+	executor, p, _ := newConcurrentTestExecutor(t, 2, []*prd.Story{
+		{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+		{ID: "story-2", Title: "Two", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2},
+	}, nil)
+	executor.cfg.RecoveryAttempts = 1
+	executor.cfg.RateLimitCooldown = time.Minute
+	executor.clock = clocktest.NewFake(time.Unix(0, 0))
+	story1 := withStory(context.Background(), "story-1")
+	story2 := withStory(context.Background(), "story-2")
+
+	if recovered, err := executor.runRecovery(story1, p, promptpkg.RecoveryReasonStoryFailure, "boom", nil); !recovered || err != nil {
+		t.Fatalf("story-1 first recovery = %v, %v, want a recovery", recovered, err)
+	}
+	executor.forgetStoryRecoveryAttempts("story-2")
+	if recovered, err := executor.runRecovery(story1, p, promptpkg.RecoveryReasonStoryFailure, "boom", nil); recovered || err != nil {
+		t.Fatalf("story-1 second recovery = %v, %v, want its attempts still spent after story-2 finished", recovered, err)
+	}
+	if recovered, err := executor.runRecovery(story2, p, promptpkg.RecoveryReasonStoryFailure, "boom", nil); !recovered || err != nil {
+		t.Fatalf("story-2 recovery = %v, %v, want its own attempt", recovered, err)
+	}
+
+	executor.setRateLimited(story1, true)
+	if got := executor.retryCooldown(story2, time.Second); got != time.Second {
+		t.Errorf("story-2 cooldown = %v, want the base 1s despite story-1's rate limit", got)
+	}
+	if got := executor.retryCooldown(story1, time.Second); got != time.Minute {
+		t.Errorf("story-1 cooldown = %v, want the 1m rate-limit cooldown", got)
+	}
+}
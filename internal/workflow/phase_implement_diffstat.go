@@ -0,0 +1,51 @@
+package workflow
+
+import (
+	"fmt"
+
+	"ralph/internal/shared/gitdiff"
+	"ralph/internal/shared/logger"
+)
+
+var storyDiffStat = gitdiff.DiffStat
+
+// storyStatBase records HEAD before a story so its diff stat can be reported
+// afterwards. Under --no-commit earlier stories stay uncommitted, so it
+// snapshots the working tree instead. It returns "" outside a git repo and
+// when stories run concurrently, since their changes would be counted
+// together.
+func (e *Executor) storyStatBase() string {
+	if e.cfg.StoryConcurrency() > 1 {
+		return ""
+	}
+	base := headSHA
+	if e.cfg.NoCommit {
+		base = gitdiff.WorkingTreeSnapshot
+	}
+	sha, err := base(e.cfg.WorkDir)
+	if err != nil {
+		return ""
+	}
+	return sha
+}
+
+// reportStoryDiffStat says how much a finished story changed since base, so
+// a suspiciously large or empty story stands out.
+func (e *Executor) reportStoryDiffStat(storyID, base string) {
+	if base == "" {
+		return
+	}
+	files, insertions, deletions, err := storyDiffStat(e.cfg.WorkDir, base)
+	if err != nil {
+		logger.Warn("cannot compute story diff stat", "story_id", storyID, "error", err)
+		return
+	}
+	text := fmt.Sprintf("%s touched %d files (+%d/-%d)", storyID, files, insertions, deletions)
+	switch files {
+	case 0:
+		text = fmt.Sprintf("%s changed no tracked files", storyID)
+	case 1:
+		text = fmt.Sprintf("%s touched 1 file (+%d/-%d)", storyID, insertions, deletions)
+	}
+	e.emit(EventOutput{Output: Output{Text: text}})
+}
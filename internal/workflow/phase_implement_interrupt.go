@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"fmt"
+
+	"ralph/internal/shared/logger"
+)
+
+// recordInterruptedStory counts a run of the story that the run's context
+// cut short, so a resumed run knows the story was already attempted.
+func (e *Executor) recordInterruptedStory(storyID string) {
+	if e.countStoryInterruption(storyID) {
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Interrupted story %s; recorded in %s so a resume picks it up where it stopped.", storyID, e.cfg.PRDFile)}})
+	}
+}
+
+// countStoryInterruption bumps the story's Interruptions in the saved PRD and
+// reports whether it did. The PRD is reloaded first to keep any slice progress
+// saved before the interrupt; a story that finished before the cancel landed
+// is left alone.
+func (e *Executor) countStoryInterruption(storyID string) bool {
+	e.prdMu.Lock()
+	defer e.prdMu.Unlock()
+
+	p, err := e.store.Load(e.cfg)
+	if err != nil {
+		logger.Warn("cannot record interrupted story: failed to load PRD", "story_id", storyID, "error", err)
+		return false
+	}
+	story := p.GetStory(storyID)
+	if story == nil || story.Done() {
+		return false
+	}
+	story.Interruptions++
+	if err := e.savePRD(p); err != nil {
+		logger.Error("failed to save PRD after interrupt", "story_id", storyID, "error", err)
+		return false
+	}
+	logger.Info("story interrupted", "story_id", storyID, "interruptions", story.Interruptions)
+	return true
+}
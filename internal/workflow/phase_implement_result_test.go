@@ -0,0 +1,604 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/clock/clocktest"
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/gitdiff"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+	"ralph/internal/shared/runner"
+	"ralph/internal/shared/testgit"
+	"ralph/internal/workflow/events"
+)
+
+func storyResults(ch chan Event) []events.StoryResult {
+	var results []events.StoryResult
+	for _, e := range drainEvents(ch) {
+		if done, ok := e.(EventStoryCompleted); ok {
+			results = append(results, done.Result)
+		}
+	}
+	return results
+}
+
+func newResultTestExecutor(t *testing.T, runFunc func(context.Context, string, chan<- runner.OutputLine) error) (*Executor, *prd.PRD, chan Event) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+
+	p := &prd.PRD{
+		ProjectName: "Test",
+		Stories:     []*prd.Story{{ID: "1", Title: "Story", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+	}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 200)
+	mock := newMockRunner()
+	mock.runFunc = runFunc
+	exec := NewExecutorWithRunner(cfg, ch, mock)
+	exec.clock = clocktest.NewFake(time.Unix(0, 0))
+	return exec, p, ch
+}
+
+func TestRunImplementationRecordsIterationsAndStoryTimestamps(t *testing.T) {
+	exec, p, ch := newResultTestExecutor(t, func(context.Context, string, chan<- runner.OutputLine) error { return nil })
+	fake := clocktest.NewFake(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	exec.clock = fake
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+	drainEvents(ch)
+
+	saved, err := prd.Load(exec.cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Iterations != 1 {
+		t.Errorf("Iterations = %d, want 1", saved.Iterations)
+	}
+	story := saved.GetStory("1")
+	if !story.StartedAt.Equal(fake.Now()) || !story.CompletedAt.Equal(fake.Now()) {
+		t.Errorf("story timestamps = %v/%v, want both %v", story.StartedAt, story.CompletedAt, fake.Now())
+	}
+}
+
+func TestRunImplementationNoCommitSkipsCommits(t *testing.T) {
+	exec, p, ch := newResultTestExecutor(t, func(context.Context, string, chan<- runner.OutputLine) error { return nil })
+	exec.cfg.NoCommit = true
+	exec.cfg.RollbackOnFail = true
+	originalCommitStory := commitStory
+	t.Cleanup(func() { commitStory = originalCommitStory })
+	commitStory = func(string, string, gitdiff.StoryCommitOptions) (bool, error) {
+		t.Fatal("commitStory called with --no-commit")
+		return false, nil
+	}
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+	if sha := exec.storyCheckpoint(); sha != "" {
+		t.Errorf("storyCheckpoint() = %q, want no rollback point without commits", sha)
+	}
+
+	notes := 0
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && strings.Contains(out.Text, "Auto-commit disabled") {
+			notes++
+		}
+	}
+	if notes != 1 {
+		t.Errorf("auto-commit notes = %d, want 1", notes)
+	}
+	saved, err := prd.Load(exec.cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.GetStory("1").Passes {
+		t.Error("story not marked complete with --no-commit")
+	}
+}
+
+func TestRunImplementationReportsStoryDiffStat(t *testing.T) {
+	var workDir string
+	exec, p, ch := newResultTestExecutor(t, func(_ context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if !isStoryImplementPrompt(prompt) {
+			return nil
+		}
+		return os.WriteFile(filepath.Join(workDir, "feature.go"), []byte("package feature\n\nconst Enabled = true\n"), 0o644)
+	})
+	workDir = exec.cfg.WorkDir
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	var stats []string
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && strings.HasPrefix(out.Text, "1 ") {
+			stats = append(stats, out.Text)
+		}
+	}
+	if len(stats) != 1 || stats[0] != "1 touched 1 file (+3/-0)" {
+		t.Fatalf("diff stat lines = %q, want [\"1 touched 1 file (+3/-0)\"]", stats)
+	}
+}
+
+func TestRunImplementationNoCommitDiffStatCountsOnlyTheStory(t *testing.T) {
+	var workDir string
+	exec, p, ch := newResultTestExecutor(t, func(_ context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if !isStoryImplementPrompt(prompt) {
+			return nil
+		}
+		f, err := os.OpenFile(filepath.Join(workDir, "notes.txt"), os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = f.WriteString("note\n")
+		return err
+	})
+	workDir = exec.cfg.WorkDir
+	exec.cfg.NoCommit = true
+	p.Stories = append(p.Stories, &prd.Story{ID: "2", Title: "Second", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2})
+	if err := prd.Save(exec.cfg, p); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "notes.txt"), []byte("notes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	testgit.CommitFile(t, workDir, "notes.txt", "add notes")
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	var stats []string
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && strings.Contains(out.Text, " touched ") {
+			stats = append(stats, out.Text)
+		}
+	}
+	want := []string{"1 touched 1 file (+1/-0)", "2 touched 1 file (+1/-0)"}
+	if !slices.Equal(stats, want) {
+		t.Fatalf("diff stat lines = %q, want %q: story 2 should not count story 1's uncommitted line", stats, want)
+	}
+}
+
+func TestRunImplementationReportsExternalPRDChanges(t *testing.T) {
+	var cfg *config.Config
+	exec, p, ch := newResultTestExecutor(t, func(_ context.Context, prompt string, _ chan<- runner.OutputLine) error {
+		if !isStoryImplementPrompt(prompt) {
+			return nil
+		}
+		edited, err := prd.Load(cfg)
+		if err != nil {
+			return err
+		}
+		edited.GetStory("1").Title = "Edited story"
+		return prd.Save(cfg, edited)
+	})
+	cfg = exec.cfg
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	var lines []string
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok {
+			lines = append(lines, out.Text)
+		}
+	}
+	header := slices.IndexFunc(lines, func(line string) bool { return strings.Contains(line, "changed outside this run") })
+	if header < 0 || header+1 >= len(lines) || lines[header+1] != `  1: title "Story" -> "Edited story"` {
+		t.Fatalf("output should report the external title change, got %q", lines)
+	}
+	if strings.Count(strings.Join(lines, "\n"), "changed outside this run") != 1 {
+		t.Fatalf("external change should be reported once, got %q", lines)
+	}
+}
+
+func TestRunImplementationClassifiesStoryResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		runFunc func(ctx context.Context, prompt string, outputCh chan<- runner.OutputLine) error
+		wantErr bool
+		want    events.StoryResult
+	}{
+		{
+			name:    "passed",
+			runFunc: func(context.Context, string, chan<- runner.OutputLine) error { return nil },
+			want:    events.StoryPassed,
+		},
+		{
+			name:    "failed exhausted",
+			runFunc: func(context.Context, string, chan<- runner.OutputLine) error { return errors.New("runner failed") },
+			wantErr: true,
+			want:    events.StoryFailedExhausted,
+		},
+		{
+			name:    "timed out",
+			timeout: 10 * time.Millisecond,
+			runFunc: func(ctx context.Context, prompt string, _ chan<- runner.OutputLine) error {
+				if isRecoveryPrompt(prompt) {
+					return nil
+				}
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantErr: true,
+			want:    events.StoryTimedOut,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec, p, ch := newResultTestExecutor(t, tt.runFunc)
+			exec.cfg.RunnerTimeout = tt.timeout
+
+			err := exec.RunImplementation(context.Background(), p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunImplementation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			results := storyResults(ch)
+			if len(results) != 1 || results[0] != tt.want {
+				t.Fatalf("story results = %v, want [%s]", results, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunImplementationClassifiesCanceledStoryAsRetryable(t *testing.T) {
+	var exec *Executor
+	runs := 0
+	exec, p, ch := newResultTestExecutor(t, func(ctx context.Context, _ string, _ chan<- runner.OutputLine) error {
+		runs++
+		if runs == 1 {
+			exec.CancelCurrentStory()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+	results := storyResults(ch)
+	want := []events.StoryResult{events.StoryFailedRetryable, events.StoryPassed}
+	if len(results) != len(want) || results[0] != want[0] || results[1] != want[1] {
+		t.Fatalf("story results = %v, want %v", results, want)
+	}
+}
+
+func TestRunImplementationClassifiesBlockedStories(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+
+	blocked := newAllBlockedPRD()
+	ch := make(chan Event, 100)
+	exec := NewExecutorWithRunnerAndStore(cfg, ch, newMockRunner(), blockedPRDStore{p: blocked})
+
+	if err := exec.RunImplementation(context.Background(), blocked); err == nil {
+		t.Fatal("RunImplementation() should fail when every story is blocked")
+	}
+	results := storyResults(ch)
+	if len(results) != 1 || results[0] != events.StoryBlocked {
+		t.Fatalf("story results = %v, want [blocked]", results)
+	}
+}
+
+func TestClassifyStoryFailureCanceledRunIsRetryable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := classifyStoryFailure(ctx, context.Canceled); got != events.StoryFailedRetryable {
+		t.Fatalf("classifyStoryFailure() = %s, want %s", got, events.StoryFailedRetryable)
+	}
+}
+
+func TestRunImplementationWarnsWhenStoryPromptTrimmed(t *testing.T) {
+	var prompts []string
+	exec, p, ch := newResultTestExecutor(t, func(ctx context.Context, promptText string, outputCh chan<- runner.OutputLine) error {
+		if isDiffReviewPrompt(promptText) {
+			outputCh <- runner.OutputLine{Text: cleanReviewTranscript}
+			return nil
+		}
+		prompts = append(prompts, promptText)
+		return nil
+	})
+	p.Context = strings.Repeat("verbose context ", 500)
+	if err := prd.Save(exec.cfg, p); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	exec.cfg.StoryPromptBudget = 5000
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	if len(prompts) == 0 || len(prompts[0]) > 5000 {
+		t.Fatalf("story prompt should fit the 5000-character budget, got %d prompts", len(prompts))
+	}
+	warned := false
+	for _, e := range drainEvents(ch) {
+		if out, ok := e.(EventOutput); ok && out.IsErr && strings.Contains(out.Text, "trimmed context") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Fatal("expected a warning when the story prompt was trimmed")
+	}
+}
+
+func TestRunImplementationBestEffortSkipsFailedStoryAndDependents(t *testing.T) {
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	cfg.BestEffort = true
+
+	p := &prd.PRD{
+		ProjectName: "Test",
+		Stories: []*prd.Story{
+			{ID: "1", Title: "Fails", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+			{ID: "2", Title: "Depends", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2, DependsOn: []string{"1"}},
+			{ID: "3", Title: "Independent", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 3},
+		},
+	}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 200)
+	mock := newMockRunner()
+	mock.runFunc = func(ctx context.Context, promptText string, outputCh chan<- runner.OutputLine) error {
+		if isDiffReviewPrompt(promptText) {
+			outputCh <- runner.OutputLine{Text: cleanReviewTranscript}
+			return nil
+		}
+		if isStoryImplementPrompt(promptText) && strings.Contains(promptText, "(ID: 1)") {
+			return errors.New("runner failed")
+		}
+		return nil
+	}
+	exec := NewExecutorWithRunner(cfg, ch, mock)
+	exec.clock = clocktest.NewFake(time.Unix(0, 0))
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v, want nil in best-effort mode", err)
+	}
+
+	var completed *EventCompleted
+	for _, e := range drainEvents(ch) {
+		switch ev := e.(type) {
+		case EventCompleted:
+			completed = &ev
+		case EventError:
+			t.Fatalf("unexpected EventError: %v", ev.Err)
+		}
+	}
+	if completed == nil {
+		t.Fatal("expected EventCompleted")
+	}
+	if strings.Join(completed.Unfinished, ",") != "1,2" {
+		t.Fatalf("Unfinished = %v, want [1 2]", completed.Unfinished)
+	}
+	saved, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.GetStory("3").Passes {
+		t.Fatal("independent story should still be implemented")
+	}
+}
+
+func TestRunImplementationAbortsAfterMaxConsecutiveFailures(t *testing.T) {
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	cfg.BestEffort = true
+	cfg.MaxConsecutiveFails = 3
+
+	p := &prd.PRD{
+		ProjectName: "Test",
+		Stories: []*prd.Story{
+			{ID: "1", Title: "First", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+			{ID: "2", Title: "Second", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2},
+			{ID: "3", Title: "Third", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 3},
+			{ID: "4", Title: "Fourth", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 4},
+		},
+	}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 200)
+	var attempted []string
+	mock := newMockRunner()
+	mock.runFunc = func(ctx context.Context, promptText string, outputCh chan<- runner.OutputLine) error {
+		if isDiffReviewPrompt(promptText) {
+			outputCh <- runner.OutputLine{Text: cleanReviewTranscript}
+			return nil
+		}
+		if isStoryImplementPrompt(promptText) {
+			for _, id := range []string{"1", "2", "3", "4"} {
+				if strings.Contains(promptText, "(ID: "+id+")") && !slices.Contains(attempted, id) {
+					attempted = append(attempted, id)
+				}
+			}
+			return errors.New("runner binary missing")
+		}
+		return nil
+	}
+	exec := NewExecutorWithRunner(cfg, ch, mock)
+	exec.clock = clocktest.NewFake(time.Unix(0, 0))
+
+	err := exec.RunImplementation(context.Background(), p)
+	if err == nil || !strings.Contains(err.Error(), "3 consecutive story failures (1, 2, 3)") {
+		t.Fatalf("RunImplementation() error = %v, want abort after 3 consecutive failures", err)
+	}
+	if strings.Join(attempted, ",") != "1,2,3" {
+		t.Fatalf("attempted stories = %v, want [1 2 3] with story 4 never started", attempted)
+	}
+	for _, e := range drainEvents(ch) {
+		if _, ok := e.(EventCompleted); ok {
+			t.Fatal("aborted run should not emit EventCompleted")
+		}
+	}
+}
+
+func TestRunImplementationRetriesTransientFailuresWithoutRecovery(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		wantRecovery  bool
+		wantTransient int
+	}{
+		{name: "recovers within transient cap", failures: 2, wantRecovery: false, wantTransient: 2},
+		{name: "falls back to recovery past the cap", failures: 10, wantRecovery: true, wantTransient: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storyRuns := 0
+			recoveryRuns := 0
+			exec, p, ch := newResultTestExecutor(t, func(_ context.Context, prompt string, _ chan<- runner.OutputLine) error {
+				if isRecoveryPrompt(prompt) {
+					recoveryRuns++
+					return nil
+				}
+				if !isStoryImplementPrompt(prompt) {
+					return nil
+				}
+				storyRuns++
+				if storyRuns <= tt.failures {
+					return errors.New("read tcp: connection reset by peer")
+				}
+				return nil
+			})
+
+			err := exec.RunImplementation(context.Background(), p)
+			if got := recoveryRuns > 0; got != tt.wantRecovery {
+				t.Fatalf("recovery ran = %v, want %v (err %v)", got, tt.wantRecovery, err)
+			}
+			if !tt.wantRecovery && err != nil {
+				t.Fatalf("RunImplementation() error = %v", err)
+			}
+
+			transient := 0
+			for _, ev := range drainEvents(ch) {
+				if out, ok := ev.(EventOutput); ok && strings.Contains(out.Text, "Transient runner error") {
+					transient++
+				}
+			}
+			if transient != tt.wantTransient {
+				t.Fatalf("transient retry messages = %d, want %d", transient, tt.wantTransient)
+			}
+		})
+	}
+}
+
+func TestRetryTransientFailureStopsWaitingOnCancel(t *testing.T) {
+	exec, _, _ := newResultTestExecutor(t, func(context.Context, string, chan<- runner.OutputLine) error {
+		t.Error("runner invoked after the cooldown was canceled")
+		return nil
+	})
+	exec.clock = clock.Real{}
+	exec.cfg.RateLimitCooldown = time.Hour
+	exec.setRateLimited(context.Background(), true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := exec.retryTransientFailure(ctx, errors.New("API Error: 429 rate limit exceeded"), "story", "story 1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("retryTransientFailure() error = %v, want context deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retryTransientFailure() took %v after cancel, want a prompt return", elapsed)
+	}
+}
+
+func TestRunImplementationRecordsInterruptedStory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exec, p, ch := newResultTestExecutor(t, func(runCtx context.Context, _ string, _ chan<- runner.OutputLine) error {
+		cancel()
+		<-runCtx.Done()
+		return runCtx.Err()
+	})
+
+	if err := exec.RunImplementation(ctx, p); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunImplementation() error = %v, want context.Canceled", err)
+	}
+	drainEvents(ch)
+
+	saved, err := prd.Load(exec.cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	story := saved.GetStory("1")
+	if story.Passes || story.Interruptions != 1 {
+		t.Fatalf("story Passes=%v Interruptions=%d, want an unfinished story with 1 interruption", story.Passes, story.Interruptions)
+	}
+	if saved.Iterations != 1 {
+		t.Errorf("Iterations = %d, want 1", saved.Iterations)
+	}
+
+	exec.runner.(*mockRunner).runFunc = func(context.Context, string, chan<- runner.OutputLine) error { return nil }
+	if err := exec.RunImplementation(context.Background(), saved); err != nil {
+		t.Fatalf("resumed RunImplementation() error = %v", err)
+	}
+	resumed := false
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && strings.Contains(out.Text, "Resuming story 1 after 1 interrupted attempt(s)") {
+			resumed = true
+		}
+	}
+	if !resumed {
+		t.Error("resumed run did not report the interrupted attempt")
+	}
+}
+
+func TestStoryPromptListsRecentCommits(t *testing.T) {
+	var storyPrompt string
+	exec, p, ch := newResultTestExecutor(t, func(_ context.Context, promptText string, _ chan<- runner.OutputLine) error {
+		if isStoryImplementPrompt(promptText) && storyPrompt == "" {
+			storyPrompt = promptText
+		}
+		return nil
+	})
+
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+	drainEvents(ch)
+
+	if !strings.Contains(storyPrompt, "RECENT COMMITS") || !strings.Contains(storyPrompt, "- add prd\n") {
+		t.Fatalf("story prompt should list the branch's recent commits, got:\n%s", storyPrompt)
+	}
+}
@@ -3,10 +3,8 @@ package workflow
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"ralph/internal/prompt"
-	"ralph/internal/shared/constants"
 	"ralph/internal/shared/gitdiff"
 	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
@@ -21,9 +19,9 @@ func (e *Executor) SetReviewLoop(runID string, updater ReviewLoopUpdater) {
 
 func (e *Executor) runImplementationReview(ctx context.Context, p *prd.PRD) (blocked bool, err error) {
 	for round := 0; ; round++ {
-		if round >= constants.MaxImplementationReviewRounds {
+		if round >= e.cfg.ReviewRoundLimit() {
 			e.stopImplementationReview(runstate.StopReasonRecoveryExhausted)
-			return false, fmt.Errorf("implementation review: exceeded %d review rounds", constants.MaxImplementationReviewRounds)
+			return false, fmt.Errorf("implementation review: exceeded %d review rounds", e.cfg.ReviewRoundLimit())
 		}
 
 		blocked, err = e.runImplementationReviewOnce(ctx, p)
@@ -52,7 +50,7 @@ func (e *Executor) runImplementationReview(ctx context.Context, p *prd.PRD) (blo
 			return false, recErr
 		}
 		if !recovered {
-			if err != nil && e.recoveryAttemptsSnapshot() >= constants.MaxRecoveryAttempts {
+			if err != nil && e.recoveryAttemptsSnapshot() >= e.cfg.RecoveryAttemptLimit() {
 				e.stopImplementationReview(runstate.StopReasonRecoveryExhausted)
 				return false, fmt.Errorf("implementation review: %s", runstate.StopReasonRecoveryExhausted)
 			}
@@ -87,7 +85,7 @@ func (e *Executor) runImplementationReviewOnce(ctx context.Context, p *prd.PRD)
 		defer cancel()
 	}
 
-	start := time.Now()
+	start := e.clock.Now()
 	result, err := review.ReviewDiffWithChanged(reviewCtx, review.Params{
 		WorkDir:   e.cfg.WorkDir,
 		RunID:     e.runID,
@@ -96,7 +94,7 @@ func (e *Executor) runImplementationReviewOnce(ctx context.Context, p *prd.PRD)
 		Context:   p.Context,
 		Runner:    e.runner,
 	}, changed)
-	elapsedMs += time.Since(start).Milliseconds()
+	elapsedMs += e.clock.Now().Sub(start).Milliseconds()
 	if err != nil {
 		if reviewCtx.Err() == context.DeadlineExceeded {
 			err = fmt.Errorf("runner invocation timed out after %s: %w", e.cfg.RunnerTimeout, reviewCtx.Err())
@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/gitdiff"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/prd/prdtest"
+	"ralph/internal/shared/runner"
+	"ralph/internal/shared/testgit"
+)
+
+func runScaffoldImplementation(t *testing.T, scaffold bool, stories []*prd.Story) (*mockRunner, []string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	cfg.ScaffoldTests = scaffold
+
+	p := &prd.PRD{ProjectName: "Test", TestSpec: "go test ./...", Stories: stories}
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	var commitMessages []string
+	originalCommitStory := commitStory
+	t.Cleanup(func() { commitStory = originalCommitStory })
+	commitStory = func(workDir, message string, opts gitdiff.StoryCommitOptions) (bool, error) {
+		commitMessages = append(commitMessages, message)
+		return true, nil
+	}
+
+	mock := newMockRunner()
+	mock.runFunc = func(ctx context.Context, promptText string, outputCh chan<- runner.OutputLine) error {
+		if isDiffReviewPrompt(promptText) {
+			outputCh <- runner.OutputLine{Text: cleanReviewTranscript}
+		}
+		return nil
+	}
+	exec := NewExecutorWithRunner(cfg, make(chan Event, 200), mock)
+	if err := exec.RunImplementation(context.Background(), p); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+	return mock, commitMessages
+}
+
+func TestRunImplementationScaffoldsTestsBeforeStory(t *testing.T) {
+	mock, commits := runScaffoldImplementation(t, true, []*prd.Story{
+		{ID: "story-1", Title: "Story", Description: "Desc", Slices: prdtest.Slices("first behavior"), Priority: 1},
+	})
+
+	var kinds []string
+	for _, call := range mock.calls {
+		switch {
+		case isTestScaffoldPrompt(call):
+			kinds = append(kinds, "scaffold")
+		case isStoryImplementPrompt(call):
+			kinds = append(kinds, "implement")
+		}
+	}
+	if len(kinds) != 2 || kinds[0] != "scaffold" || kinds[1] != "implement" {
+		t.Fatalf("prompt order = %v, want [scaffold implement]", kinds)
+	}
+	if len(commits) == 0 || commits[0] != "ralph: story-1 test scaffold" {
+		t.Fatalf("commits = %v, want scaffold commit first", commits)
+	}
+}
+
+func TestRunImplementationSkipsScaffold(t *testing.T) {
+	tests := []struct {
+		name     string
+		scaffold bool
+		slices   []*prd.Slice
+	}{
+		{name: "flag off", scaffold: false, slices: prdtest.Slices("first behavior")},
+		{name: "story already started", scaffold: true, slices: []*prd.Slice{
+			{ID: "slice-1", Behavior: "first behavior", RedHint: "red", Passes: true},
+			{ID: "slice-2", Behavior: "second behavior", RedHint: "red"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, _ := runScaffoldImplementation(t, tt.scaffold, []*prd.Story{
+				{ID: "story-1", Title: "Story", Description: "Desc", Slices: tt.slices, Priority: 1},
+			})
+			for _, call := range mock.calls {
+				if isTestScaffoldPrompt(call) {
+					t.Fatalf("unexpected scaffold prompt:\n%s", call)
+				}
+			}
+		})
+	}
+}
@@ -3,14 +3,63 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"ralph/internal/prompt"
+	"ralph/internal/shared/constants"
 	"ralph/internal/shared/gitdiff"
+	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
+	"ralph/internal/shared/runner"
 	"ralph/internal/workflow/events"
 )
 
-var commitChangedFiles = gitdiff.CommitChangedFiles
+var (
+	commitStory   = gitdiff.CommitStory
+	recentCommits = gitdiff.RecentCommits
+)
+
+// recentCommitLimit is how many commit subjects the story prompt shows.
+const recentCommitLimit = 5
+
+// storyCommitOptions stages the PRD and adds the co-author trailer to story
+// commits when RALPH_COMMIT_COAUTHOR=1.
+func (e *Executor) storyCommitOptions() gitdiff.StoryCommitOptions {
+	if !e.cfg.CommitCoauthor {
+		return gitdiff.StoryCommitOptions{}
+	}
+	opts := gitdiff.StoryCommitOptions{Coauthor: true}
+	if rel, err := filepath.Rel(e.cfg.WorkDir, e.cfg.PRDPath()); err == nil && !strings.HasPrefix(rel, "..") {
+		opts.PRDFile = rel
+	}
+	return opts
+}
+
+// commitStoryCompletion commits just the PRD recording storyID as complete,
+// when story commits stage the PRD, so history does not stop at the last
+// slice commit with the story still marked pending.
+func (e *Executor) commitStoryCompletion(storyID string) error {
+	opts := e.storyCommitOptions()
+	if e.cfg.NoCommit || opts.PRDFile == "" {
+		return nil
+	}
+	opts.PRDOnly = true
+	_, err := commitStory(e.cfg.WorkDir, fmt.Sprintf("ralph: %s complete", storyID), opts)
+	return err
+}
+
+// commitStoryChanges commits storyID's work with message, or does nothing
+// and reports no commit under --no-commit.
+func (e *Executor) commitStoryChanges(storyID, message string) (bool, error) {
+	if e.cfg.NoCommit {
+		return false, nil
+	}
+	if e.cfg.StoryConcurrency() > 1 {
+		return e.commitConcurrentStoryChanges(storyID, message)
+	}
+	return commitStory(e.cfg.WorkDir, message, e.storyCommitOptions())
+}
 
 func storyImplementationSliceData(slice *prd.Slice) []prompt.SliceData {
 	if slice == nil {
@@ -25,36 +74,134 @@ func storyImplementationSliceData(slice *prd.Slice) []prompt.SliceData {
 	}}
 }
 
+func storyScaffoldSliceData(story *prd.Story) []prompt.SliceData {
+	var slices []prompt.SliceData
+	for _, slice := range story.Slices {
+		if slice == nil {
+			continue
+		}
+		slices = append(slices, prompt.SliceData{
+			ID:       slice.ID,
+			Behavior: slice.Behavior,
+			RedHint:  slice.RedHint,
+		})
+	}
+	return slices
+}
+
+func storyHasPassingSlice(story *prd.Story) bool {
+	for _, slice := range story.Slices {
+		if slice != nil && slice.Passes {
+			return true
+		}
+	}
+	return false
+}
+
+// scaffoldStoryTests runs a stubs-only session for a fresh story and commits
+// whatever failing tests it produced so implementation starts from them.
+func (e *Executor) scaffoldStoryTests(ctx context.Context, p *prd.PRD, story *prd.Story) error {
+	scaffoldPrompt := prompt.TestScaffold(
+		story.ID,
+		story.Title,
+		story.Description,
+		storyScaffoldSliceData(story),
+		p.TestSpec,
+		p.Context,
+		e.cfg.PRDFile,
+	)
+
+	e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Scaffolding failing tests for story %s", story.ID)}})
+	if err := e.runWithForwardedOutput(ctx, scaffoldPrompt); err != nil {
+		return fmt.Errorf("test scaffold failed for story %s: %w", story.ID, err)
+	}
+
+	e.prdMu.Lock()
+	committed, err := e.commitStoryChanges(story.ID, fmt.Sprintf("ralph: %s test scaffold", story.ID))
+	e.prdMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("commit story %s test scaffold: %w", story.ID, err)
+	}
+	if committed {
+		e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Committed test scaffold for story %s.", story.ID)}})
+	}
+	return nil
+}
+
+// slicePrompt builds the implementation prompt for one slice of story, kept
+// within RALPH_STORY_PROMPT_BUDGET; trimmed reports whether context was cut.
+func (e *Executor) slicePrompt(p *prd.PRD, story *prd.Story, slice *prd.Slice) (text string, trimmed bool) {
+	return prompt.StoryImplementationWithinBudget(
+		e.cfg.StoryPromptBudget,
+		story.ID,
+		story.Title,
+		story.Description,
+		storyImplementationSliceData(slice),
+		p.TestSpec,
+		p.Context,
+		e.cfg.PRDFile,
+		p.CompletedCount(),
+		len(p.Stories),
+		story.DependsOn,
+		e.recentCommitSubjects(),
+	)
+}
+
+// recentCommitSubjects lists the branch's latest commit subjects for the
+// story prompt, so the runner sees what earlier stories already did. Outside
+// a git repo it returns nil and the prompt omits the section.
+func (e *Executor) recentCommitSubjects() []string {
+	subjects, err := recentCommits(e.cfg.WorkDir, recentCommitLimit)
+	if err != nil {
+		logger.Debug("omitting recent commits from story prompt", "error", err)
+		return nil
+	}
+	return subjects
+}
+
 func (e *Executor) runStorySlices(ctx context.Context, p *prd.PRD, story *prd.Story) (*prd.PRD, *prd.Story, error) {
+	if e.cfg.ScaffoldTests && !storyHasPassingSlice(story) {
+		if err := e.scaffoldStoryTests(ctx, p, story); err != nil {
+			return nil, nil, err
+		}
+	}
 	for {
 		currentSlice := story.NextPendingSlice()
 		if currentSlice == nil {
-			if !story.Passes {
-				story.Passes = story.AllSlicesPassed()
-				if err := e.store.Save(e.cfg, p); err != nil {
-					return nil, nil, fmt.Errorf("failed to save PRD after completing story %s: %w", story.ID, err)
-				}
+			if story.Passes {
+				return p, story, nil
+			}
+			completedPRD, completedStory, err := e.completeStory(story.ID)
+			if err != nil || !completedStory.Passes || !e.cfg.Verify {
+				return completedPRD, completedStory, err
+			}
+			reopenedPRD, reopenedStory, err := e.verifyStoryWithRecovery(ctx, completedPRD, completedStory)
+			if err != nil {
+				return nil, nil, err
+			}
+			if reopenedStory == nil {
+				return completedPRD, completedStory, nil
 			}
-			return p, story, nil
+			p, story = reopenedPRD, reopenedStory
+			continue
 		}
 
-		storyPrompt := prompt.StoryImplementation(
-			story.ID,
-			story.Title,
-			story.Description,
-			storyImplementationSliceData(currentSlice),
-			p.TestSpec,
-			p.Context,
-			e.cfg.PRDFile,
-			p.CompletedCount(),
-			len(p.Stories),
-			story.DependsOn,
-		)
+		storyPrompt, trimmed := e.slicePrompt(p, story, currentSlice)
 
+		if trimmed {
+			logger.Warn("story prompt trimmed to budget", "story_id", story.ID, "budget", e.cfg.StoryPromptBudget)
+			e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Warning: story %s prompt exceeded %d characters; trimmed context to fit", story.ID, e.cfg.StoryPromptBudget), IsErr: true}})
+		}
 		e.emit(events.EventSliceStarted{StoryID: story.ID, SliceID: currentSlice.ID})
 		runErr := e.runWithForwardedOutput(ctx, storyPrompt)
+		runErr = e.retryTransientFailure(ctx, runErr, storyPrompt, fmt.Sprintf("story %s slice %s", story.ID, currentSlice.ID))
+		if runErr != nil && ctx.Err() != nil {
+			return nil, nil, fmt.Errorf("implementation canceled for story %s slice %s: %w", story.ID, currentSlice.ID, runErr)
+		}
 		if runErr != nil {
+			e.recoveryMu.Lock()
 			recovered, recErr := e.runRecovery(ctx, p, prompt.RecoveryReasonStoryFailure, runErr.Error(), nil)
+			e.recoveryMu.Unlock()
 			if recErr != nil {
 				return nil, nil, recErr
 			}
@@ -66,38 +213,9 @@ func (e *Executor) runStorySlices(ctx context.Context, p *prd.PRD, story *prd.St
 			}
 		}
 
-		committed, commitErr := commitChangedFiles(e.cfg.WorkDir, fmt.Sprintf("ralph: %s/%s", story.ID, currentSlice.ID))
-		if commitErr != nil {
-			return nil, nil, fmt.Errorf("commit story %s slice %s changes: %w", story.ID, currentSlice.ID, commitErr)
-		}
-		if committed {
-			e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Committed story %s slice %s changes before next slice.", story.ID, currentSlice.ID)}})
-		}
-
-		updatedPRD, loadErr := e.store.Load(e.cfg)
-		if loadErr != nil {
-			return nil, nil, fmt.Errorf("failed to reload PRD %s after story %s slice %s: %w", e.cfg.PRDFile, story.ID, currentSlice.ID, loadErr)
-		}
-		updatedStory := updatedPRD.GetStory(story.ID)
-		if updatedStory == nil {
-			return nil, nil, fmt.Errorf("story %s disappeared after slice %s implementation", story.ID, currentSlice.ID)
-		}
-		var updatedSlice *prd.Slice
-		for _, slice := range updatedStory.Slices {
-			if slice != nil && slice.ID == currentSlice.ID {
-				updatedSlice = slice
-				break
-			}
-		}
-		if updatedSlice == nil {
-			return nil, nil, fmt.Errorf("story %s missing slice %s after implementation", story.ID, currentSlice.ID)
-		}
-		if !updatedSlice.Passes {
-			updatedSlice.Passes = true
-		}
-
-		if saveErr := e.store.Save(e.cfg, updatedPRD); saveErr != nil {
-			return nil, nil, fmt.Errorf("failed to save PRD after completing story %s slice %s: %w", story.ID, currentSlice.ID, saveErr)
+		updatedPRD, updatedStory, err := e.recordSlicePassed(story.ID, currentSlice.ID)
+		if err != nil {
+			return nil, nil, err
 		}
 		e.emit(events.EventSliceCompleted{StoryID: story.ID, SliceID: currentSlice.ID})
 
@@ -105,3 +223,94 @@ func (e *Executor) runStorySlices(ctx context.Context, p *prd.PRD, story *prd.St
 		story = updatedStory
 	}
 }
+
+// recordSlicePassed marks a finished slice passing and commits it together
+// with the saved PRD. The PRD is reloaded under prdMu so stories running
+// concurrently do not overwrite each other's progress or race on the index.
+func (e *Executor) recordSlicePassed(storyID, sliceID string) (*prd.PRD, *prd.Story, error) {
+	e.prdMu.Lock()
+	defer e.prdMu.Unlock()
+
+	updatedPRD, loadErr := e.store.Load(e.cfg)
+	if loadErr != nil {
+		return nil, nil, fmt.Errorf("failed to reload PRD %s after story %s slice %s: %w", e.cfg.PRDFile, storyID, sliceID, loadErr)
+	}
+	e.reportExternalPRDChanges(updatedPRD)
+	updatedStory := updatedPRD.GetStory(storyID)
+	if updatedStory == nil {
+		return nil, nil, fmt.Errorf("story %s disappeared after slice %s implementation", storyID, sliceID)
+	}
+	var updatedSlice *prd.Slice
+	for _, slice := range updatedStory.Slices {
+		if slice != nil && slice.ID == sliceID {
+			updatedSlice = slice
+			break
+		}
+	}
+	if updatedSlice == nil {
+		return nil, nil, fmt.Errorf("story %s missing slice %s after implementation", storyID, sliceID)
+	}
+	if !updatedSlice.Passes {
+		updatedSlice.Passes = true
+	}
+
+	if saveErr := e.savePRD(updatedPRD); saveErr != nil {
+		return nil, nil, fmt.Errorf("failed to save PRD after completing story %s slice %s: %w", storyID, sliceID, saveErr)
+	}
+
+	committed, commitErr := e.commitStoryChanges(storyID, fmt.Sprintf("ralph: %s/%s", storyID, sliceID))
+	if commitErr != nil {
+		return nil, nil, fmt.Errorf("commit story %s slice %s changes: %w", storyID, sliceID, commitErr)
+	}
+	if committed {
+		e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Committed story %s slice %s changes before next slice.", storyID, sliceID)}})
+	}
+	return updatedPRD, updatedStory, nil
+}
+
+// completeStory marks a story whose slices have all run as passing and commits
+// the saved PRD, reloading it under prdMu for the same reason as
+// recordSlicePassed.
+func (e *Executor) completeStory(storyID string) (*prd.PRD, *prd.Story, error) {
+	e.prdMu.Lock()
+	defer e.prdMu.Unlock()
+
+	p, err := e.store.Load(e.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reload PRD %s before completing story %s: %w", e.cfg.PRDFile, storyID, err)
+	}
+	e.reportExternalPRDChanges(p)
+	story := p.GetStory(storyID)
+	if story == nil {
+		return nil, nil, fmt.Errorf("story %s disappeared before completion", storyID)
+	}
+	story.Passes = story.AllSlicesPassed()
+	if story.Passes {
+		story.CompletedAt = e.clock.Now()
+	}
+	if err := e.savePRD(p); err != nil {
+		return nil, nil, fmt.Errorf("failed to save PRD after completing story %s: %w", storyID, err)
+	}
+	if err := e.commitStoryCompletion(storyID); err != nil {
+		return nil, nil, fmt.Errorf("commit story %s completion: %w", storyID, err)
+	}
+	return p, story, nil
+}
+
+// retryTransientFailure reruns a prompt whose runner failure the runner
+// classifies as transient, up to constants.MaxTransientRetries times, without
+// spending a recovery attempt. It returns the last error.
+func (e *Executor) retryTransientFailure(ctx context.Context, runErr error, promptText, label string) error {
+	for attempt := 1; attempt <= constants.MaxTransientRetries; attempt++ {
+		if runErr == nil || ctx.Err() != nil || !runner.IsTransient(e.runner, runErr) {
+			return runErr
+		}
+		logger.Warn("transient runner failure, retrying", "target", label, "attempt", attempt, "error", runErr)
+		e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Transient runner error on %s: %v; retrying without using a recovery attempt (%d/%d)", label, runErr, attempt, constants.MaxTransientRetries), IsErr: true}})
+		if err := e.waitRetryCooldown(ctx, constants.RunnerFastFailRetryDelay); err != nil {
+			return err
+		}
+		runErr = e.runWithForwardedOutput(ctx, promptText)
+	}
+	return runErr
+}
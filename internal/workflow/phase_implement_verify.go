@@ -0,0 +1,130 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"ralph/internal/prompt"
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/runner"
+	"ralph/internal/workflow/events"
+)
+
+// StoryVerificationError is returned for a story whose RALPH_VERIFY pass did
+// not confirm every slice behavior. The story and its slices are pending again.
+type StoryVerificationError struct {
+	StoryID string
+	Err     error
+}
+
+func (e *StoryVerificationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("story %s failed verification: %v", e.StoryID, e.Err)
+	}
+	return fmt.Sprintf("story %s failed verification: the runner did not confirm it with %s", e.StoryID, prompt.StoryVerifiedMarker)
+}
+
+func (e *StoryVerificationError) Unwrap() error { return e.Err }
+
+// verifyStory runs the RALPH_VERIFY pass for a story that just passed. When
+// the runner does not print a prompt.StoryVerifiedMarker line, the story is
+// reopened and a *StoryVerificationError is returned so the caller treats it
+// as a failed attempt.
+func (e *Executor) verifyStory(ctx context.Context, p *prd.PRD, story *prd.Story) error {
+	var slices []prompt.SliceData
+	for _, slice := range story.Slices {
+		if slice != nil {
+			slices = append(slices, prompt.SliceData{ID: slice.ID, Behavior: slice.Behavior})
+		}
+	}
+	verifyPrompt := prompt.StoryVerification(story.ID, story.Title, story.Description, slices, p.Context, e.cfg.PRDFile)
+
+	e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Verifying story %s against its slice behaviors (RALPH_VERIFY)", story.ID)}})
+	var confirmed atomic.Bool
+	runErr := e.runWithObservedOutput(ctx, verifyPrompt, func(line runner.OutputLine) {
+		if strings.HasPrefix(strings.TrimSpace(line.Text), prompt.StoryVerifiedMarker) {
+			confirmed.Store(true)
+		}
+	})
+	if runErr == nil && confirmed.Load() {
+		e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Story %s verified.", story.ID)}})
+		return nil
+	}
+	if runErr != nil && ctx.Err() != nil {
+		return fmt.Errorf("verification canceled for story %s: %w", story.ID, runErr)
+	}
+
+	verifyErr := &StoryVerificationError{StoryID: story.ID, Err: runErr}
+	logger.Warn("story failed verification", "story_id", story.ID, "error", verifyErr)
+	if err := e.reopenStory(story.ID); err != nil {
+		return err
+	}
+	e.emit(EventOutput{Output: events.Output{Text: fmt.Sprintf("Story %s did not pass verification; marking it and its slices pending again.", story.ID), IsErr: true}})
+	return verifyErr
+}
+
+// verifyStoryWithRecovery runs verifyStory and, when the story fails it,
+// spends a recovery attempt the way a failed slice does. It returns the
+// reloaded PRD and reopened story when the story should be implemented
+// again, or nil and the *StoryVerificationError once recovery attempts have
+// run out.
+func (e *Executor) verifyStoryWithRecovery(ctx context.Context, p *prd.PRD, story *prd.Story) (*prd.PRD, *prd.Story, error) {
+	err := e.verifyStory(ctx, p, story)
+	var verifyErr *StoryVerificationError
+	if err == nil || !errors.As(err, &verifyErr) {
+		return nil, nil, err
+	}
+	e.recoveryMu.Lock()
+	recovered, recErr := e.runRecovery(ctx, p, prompt.RecoveryReasonStoryFailure, verifyErr.Error(), nil)
+	e.recoveryMu.Unlock()
+	if recErr != nil {
+		return nil, nil, recErr
+	}
+	if !recovered {
+		return nil, nil, verifyErr
+	}
+
+	e.prdMu.Lock()
+	defer e.prdMu.Unlock()
+	p, err = e.store.Load(e.cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reload PRD %s after recovering story %s: %w", e.cfg.PRDFile, story.ID, err)
+	}
+	reopened := p.GetStory(story.ID)
+	if reopened == nil {
+		return nil, nil, fmt.Errorf("story %s disappeared after recovery", story.ID)
+	}
+	return p, reopened, nil
+}
+
+// reopenStory clears a story's passes flags so a retry implements it again,
+// reloading the PRD under prdMu like completeStory.
+func (e *Executor) reopenStory(storyID string) error {
+	e.prdMu.Lock()
+	defer e.prdMu.Unlock()
+
+	p, err := e.store.Load(e.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to reload PRD %s to reopen story %s: %w", e.cfg.PRDFile, storyID, err)
+	}
+	story := p.GetStory(storyID)
+	if story == nil {
+		return fmt.Errorf("story %s disappeared before it could be reopened", storyID)
+	}
+	story.Passes = false
+	story.CompletedAt = time.Time{}
+	for _, slice := range story.Slices {
+		if slice != nil {
+			slice.Passes = false
+		}
+	}
+	if err := e.savePRD(p); err != nil {
+		return fmt.Errorf("failed to save PRD after reopening story %s: %w", storyID, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	promptpkg "ralph/internal/prompt"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/runner"
+)
+
+func TestRunImplementationVerifiesCompletedStory(t *testing.T) {
+	tests := []struct {
+		name              string
+		verifyLines       []string
+		raw               bool
+		wantErr           bool
+		wantPassing       bool
+		wantVerifications int
+		wantRecoveries    int
+	}{
+		{name: "confirmed", verifyLines: []string{"COMPLETED: 1"}, wantPassing: true, wantVerifications: 1},
+		{name: "confirmed under --raw-output", verifyLines: []string{"COMPLETED: 1"}, raw: true, wantPassing: true, wantVerifications: 1},
+		{name: "confirmed after a failed verification", verifyLines: []string{"slice-1 has no test covering it", "COMPLETED: 1"}, wantPassing: true, wantVerifications: 2, wantRecoveries: 1},
+		{name: "not confirmed", verifyLines: []string{"slice-1 has no test covering it"}, wantErr: true, wantVerifications: 3, wantRecoveries: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verifications, recoveries int
+			exec, p, _ := newResultTestExecutor(t, func(_ context.Context, prompt string, outputCh chan<- runner.OutputLine) error {
+				switch {
+				case promptpkg.HasKind(prompt, promptpkg.KindStoryVerify):
+					outputCh <- runner.OutputLine{Text: tt.verifyLines[min(verifications, len(tt.verifyLines)-1)], Raw: tt.raw}
+					verifications++
+				case promptpkg.HasKind(prompt, promptpkg.KindRecovery):
+					recoveries++
+				}
+				return nil
+			})
+			exec.cfg.Verify = true
+			exec.cfg.RawOutput = tt.raw
+
+			err := exec.RunImplementation(context.Background(), p)
+			var verifyErr *StoryVerificationError
+			if got := errors.As(err, &verifyErr); got != tt.wantErr {
+				t.Fatalf("RunImplementation() error = %v, want StoryVerificationError: %v", err, tt.wantErr)
+			}
+			if verifications != tt.wantVerifications || recoveries != tt.wantRecoveries {
+				t.Errorf("verification runs = %d, recoveries = %d, want %d and %d", verifications, recoveries, tt.wantVerifications, tt.wantRecoveries)
+			}
+
+			saved, loadErr := prd.Load(exec.cfg)
+			if loadErr != nil {
+				t.Fatal(loadErr)
+			}
+			story := saved.GetStory("1")
+			if story.Passes != tt.wantPassing || story.AllSlicesPassed() != tt.wantPassing {
+				t.Errorf("story passes = %v, slices passed = %v, want both %v", story.Passes, story.AllSlicesPassed(), tt.wantPassing)
+			}
+		})
+	}
+}
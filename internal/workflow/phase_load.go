@@ -21,6 +21,11 @@ func (e *Executor) RunLoad(ctx context.Context) (*prd.PRD, error) {
 		return nil, fmt.Errorf("failed to load PRD %s: %w", e.cfg.PRDFile, err)
 	}
 
+	if err := e.normalizePriorities(p); err != nil {
+		e.emit(EventError{Err: err})
+		return nil, err
+	}
+
 	logger.Debug("PRD loaded", "project", p.ProjectName, "stories", len(p.Stories))
 	e.emit(EventPRDLoaded{PRD: p})
 	e.emit(EventPRDReview{PRD: p})
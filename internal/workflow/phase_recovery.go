@@ -11,12 +11,15 @@ import (
 	"ralph/internal/prompt"
 	"ralph/internal/shared/constants"
 	"ralph/internal/shared/gitdiff"
+	"ralph/internal/shared/logger"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/runstate"
 	"ralph/internal/workflow/events"
 	"ralph/internal/workflow/review"
 )
 
+var workingDiff = gitdiff.WorkingDiff
+
 func isDuplicateFindingsError(err error) bool {
 	return err != nil && strings.Contains(err.Error(), runstate.StopReasonDuplicateFindings)
 }
@@ -45,6 +48,24 @@ func (e *Executor) resetRecoveryAttempts() {
 	}
 }
 
+// recoveryAttemptsFor returns the recovery attempts ctx's story has spent when
+// stories run concurrently, or the run's shared count otherwise. Callers in a
+// story context hold recoveryMu.
+func (e *Executor) recoveryAttemptsFor(ctx context.Context) int {
+	if storyID := storyFromContext(ctx); storyID != "" {
+		return e.storyRecoveryAttempts[storyID]
+	}
+	return e.recoveryAttemptsSnapshot()
+}
+
+// forgetStoryRecoveryAttempts drops storyID's recovery count once the story
+// has finished or been set aside.
+func (e *Executor) forgetStoryRecoveryAttempts(storyID string) {
+	e.recoveryMu.Lock()
+	defer e.recoveryMu.Unlock()
+	delete(e.storyRecoveryAttempts, storyID)
+}
+
 func (e *Executor) recoveryAttemptsSnapshot() int {
 	if e.reviewLoop == nil {
 		return e.recoveryAttempts
@@ -114,8 +135,8 @@ func (e *Executor) runRecovery(
 	errMsg string,
 	findings []ImplementationFinding,
 ) (bool, error) {
-	attempts := e.recoveryAttemptsSnapshot()
-	if attempts >= constants.MaxRecoveryAttempts {
+	attempts := e.recoveryAttemptsFor(ctx)
+	if attempts >= e.cfg.RecoveryAttemptLimit() {
 		return false, nil
 	}
 
@@ -123,9 +144,13 @@ func (e *Executor) runRecovery(
 	e.emit(EventRecoveryStarted{
 		Reason:  string(reason),
 		Attempt: attempt,
-		Max:     constants.MaxRecoveryAttempts,
+		Max:     e.cfg.RecoveryAttemptLimit(),
 	})
 
+	if err := e.waitRetryBackoff(ctx, attempt); err != nil {
+		return false, err
+	}
+
 	e.applyMechanicalCleanup(findings)
 
 	changed, err := gitdiff.ChangedFiles(e.cfg.WorkDir)
@@ -138,10 +163,11 @@ func (e *Executor) runRecovery(
 		e.cfg.PRDFile,
 		reason,
 		attempt,
-		constants.MaxRecoveryAttempts,
+		e.cfg.RecoveryAttemptLimit(),
 		errMsg,
 		recoveryFindingsFromEvents(findings),
 		changed,
+		e.recoveryDiffContext(),
 	)
 
 	runErr := e.runRecoveryPrompt(ctx, recoveryPrompt)
@@ -152,17 +178,24 @@ func (e *Executor) runRecovery(
 		Success: success,
 	})
 
-	e.recoveryAttempts = attempt
-	u := ReviewLoopUpdate{RecoveryAttempts: attempt}
-	if e.reviewLoop != nil {
-		iteration, fingerprint, elapsed, filesHash := e.reviewLoop.Snapshot()
-		u.Checkpoint = runstate.CheckpointImplReview
-		u.ReviewIteration = iteration
-		u.ReviewFingerprint = fingerprint
-		u.ReviewElapsedMs = elapsed
-		u.LastReviewChangedFilesHash = filesHash
+	if storyID := storyFromContext(ctx); storyID != "" {
+		if e.storyRecoveryAttempts == nil {
+			e.storyRecoveryAttempts = make(map[string]int)
+		}
+		e.storyRecoveryAttempts[storyID] = attempt
+	} else {
+		e.recoveryAttempts = attempt
+		u := ReviewLoopUpdate{RecoveryAttempts: attempt}
+		if e.reviewLoop != nil {
+			iteration, fingerprint, elapsed, filesHash := e.reviewLoop.Snapshot()
+			u.Checkpoint = runstate.CheckpointImplReview
+			u.ReviewIteration = iteration
+			u.ReviewFingerprint = fingerprint
+			u.ReviewElapsedMs = elapsed
+			u.LastReviewChangedFilesHash = filesHash
+		}
+		e.applyReviewLoopBestEffort(u)
 	}
-	e.applyReviewLoopBestEffort(u)
 
 	if !success {
 		return false, runErr
@@ -170,19 +203,96 @@ func (e *Executor) runRecovery(
 	return true, nil
 }
 
+// recoveryDiffContext returns the capped uncommitted diff for --diff-context,
+// or "" when the option is off or the diff cannot be read.
+func (e *Executor) recoveryDiffContext() string {
+	if !e.cfg.DiffContext {
+		return ""
+	}
+	diff, err := workingDiff(e.cfg.WorkDir, constants.MaxRecoveryDiffBytes)
+	if err != nil {
+		logger.Warn("could not capture diff for recovery prompt", "error", err)
+		return ""
+	}
+	return diff
+}
+
 func (e *Executor) runRecoveryPrompt(ctx context.Context, recoveryPrompt string) error {
-	time.Sleep(constants.RunnerRecoveryCooldown)
+	if err := e.waitRetryCooldown(ctx, constants.RunnerRecoveryCooldown); err != nil {
+		return err
+	}
 
-	start := time.Now()
+	start := e.clock.Now()
 	runErr := e.runWithForwardedOutput(ctx, recoveryPrompt)
-	if runErr == nil || time.Since(start) >= constants.MinRunnerInvokeDuration {
+	if runErr == nil || (!e.isRateLimited(ctx) && e.clock.Now().Sub(start) >= constants.MinRunnerInvokeDuration) {
 		return runErr
 	}
 
-	time.Sleep(constants.RunnerFastFailRetryDelay)
+	if err := e.waitRetryCooldown(ctx, constants.RunnerFastFailRetryDelay); err != nil {
+		return err
+	}
 	return e.runWithForwardedOutput(ctx, recoveryPrompt)
 }
 
+// retryCooldown returns base, or the longer rate-limit cooldown when the last
+// runner invocation for ctx's story reported a provider rate limit.
+func (e *Executor) retryCooldown(ctx context.Context, base time.Duration) time.Duration {
+	if !e.isRateLimited(ctx) || e.cfg.RateLimitCooldown <= base {
+		return base
+	}
+	logger.Warn("runner rate limited, extending cooldown", "cooldown", e.cfg.RateLimitCooldown)
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Rate limited, waiting %s before retrying", e.cfg.RateLimitCooldown), IsErr: true}})
+	return e.cfg.RateLimitCooldown
+}
+
+// waitRetryCooldown waits out retryCooldown(base), returning early with the
+// context's error once ctx is done.
+func (e *Executor) waitRetryCooldown(ctx context.Context, base time.Duration) error {
+	delay := e.retryCooldown(ctx, base)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-e.clock.After(delay):
+		return nil
+	}
+}
+
+// retryBackoff doubles base for each earlier attempt, capped at
+// constants.MaxRetryBackoff. A zero base disables the backoff.
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	delay := base
+	for i := 1; i < attempt && delay < constants.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, constants.MaxRetryBackoff)
+}
+
+// waitRetryBackoff pauses before a recovery attempt when RALPH_RETRY_BACKOFF
+// is set, returning early if ctx is canceled.
+func (e *Executor) waitRetryBackoff(ctx context.Context, attempt int) error {
+	delay := retryBackoff(e.cfg.RetryBackoff, attempt)
+	if delay == 0 {
+		return nil
+	}
+	logger.Info("backing off before recovery attempt", "attempt", attempt, "delay", delay)
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Backing off %s before recovery attempt %d/%d", delay, attempt, e.cfg.RecoveryAttemptLimit())}})
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-e.clock.After(delay):
+		return nil
+	}
+}
+
 func (e *Executor) recoverFromReviewFailure(
 	ctx context.Context,
 	p *prd.PRD,
@@ -212,7 +322,7 @@ func (e *Executor) recoverFromReviewFailure(
 	agentProgress := afterHash != beforeHash
 
 	if agentProgress {
-		committed, commitErr := gitdiff.CommitRecoveryChanges(e.cfg.WorkDir, "ralph: recovery fixes")
+		committed, commitErr := e.commitRecoveryFixes()
 		if commitErr != nil {
 			return false, commitErr
 		}
@@ -227,7 +337,7 @@ func (e *Executor) recoverFromReviewFailure(
 		return false, nil
 	}
 
-	committed, commitErr := gitdiff.CommitRecoveryChanges(e.cfg.WorkDir, "ralph: recovery fixes")
+	committed, commitErr := e.commitRecoveryFixes()
 	if commitErr != nil {
 		return false, commitErr
 	}
@@ -277,3 +387,12 @@ type recoveryAttemptsReader interface {
 type transcriptPathReader interface {
 	LastReviewTranscriptPath() string
 }
+
+// commitRecoveryFixes commits what a recovery session changed, or does nothing
+// under --no-commit.
+func (e *Executor) commitRecoveryFixes() (bool, error) {
+	if e.cfg.NoCommit {
+		return false, nil
+	}
+	return gitdiff.CommitRecoveryChanges(e.cfg.WorkDir, "ralph: recovery fixes")
+}
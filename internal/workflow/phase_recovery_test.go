@@ -7,9 +7,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"ralph/internal/prompt"
+	"ralph/internal/shared/clock/clocktest"
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/constants"
 	"ralph/internal/shared/gitdiff"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/prd/prdtest"
@@ -406,3 +409,140 @@ func TestApplyMechanicalCleanupRemovesUntrackedArtifact(t *testing.T) {
 		t.Fatalf("expected generated.js removed, err = %v", err)
 	}
 }
+
+func TestRunRecoveryIncludesDiffContextWhenEnabled(t *testing.T) {
+	const mockedDiff = "diff --git a/app.go b/app.go\n+half-finished change"
+
+	tests := []struct {
+		name        string
+		diffContext bool
+		wantDiff    bool
+	}{
+		{name: "enabled", diffContext: true, wantDiff: true},
+		{name: "disabled", diffContext: false, wantDiff: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := workingDiff
+			workingDiff = func(string, int) (string, error) { return mockedDiff, nil }
+			t.Cleanup(func() { workingDiff = prev })
+
+			workDir := t.TempDir()
+			testgit.InitRepo(t, workDir)
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = workDir
+			cfg.PRDFile = "prd.json"
+			cfg.DiffContext = tt.diffContext
+
+			var recoveryPrompt string
+			mock := newMockRunner()
+			mock.runFunc = func(_ context.Context, p string, _ chan<- runner.OutputLine) error {
+				if isRecoveryPrompt(p) {
+					recoveryPrompt = p
+				}
+				return nil
+			}
+			executor := NewExecutorWithRunner(cfg, make(chan Event, 100), mock)
+			executor.clock = clocktest.NewFake(time.Unix(0, 0))
+
+			if _, err := executor.runRecovery(context.Background(), &prd.PRD{}, prompt.RecoveryReasonStoryFailure, "tests failed", nil); err != nil {
+				t.Fatalf("runRecovery() error = %v", err)
+			}
+			if recoveryPrompt == "" {
+				t.Fatal("recovery prompt was not sent to the runner")
+			}
+			if got := strings.Contains(recoveryPrompt, "+half-finished change"); got != tt.wantDiff {
+				t.Fatalf("recovery prompt contains diff = %v, want %v", got, tt.wantDiff)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    time.Duration
+		attempt int
+		want    time.Duration
+	}{
+		{name: "disabled", base: 0, attempt: 2, want: 0},
+		{name: "first attempt uses base", base: 10 * time.Second, attempt: 1, want: 10 * time.Second},
+		{name: "doubles per attempt", base: 10 * time.Second, attempt: 3, want: 40 * time.Second},
+		{name: "capped", base: time.Minute, attempt: 10, want: constants.MaxRetryBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryBackoff(tt.base, tt.attempt); got != tt.want {
+				t.Fatalf("retryBackoff(%s, %d) = %s, want %s", tt.base, tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunRecoveryBacksOffBeforeAttempt(t *testing.T) {
+	workDir := t.TempDir()
+	testgit.InitRepo(t, workDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = workDir
+	cfg.PRDFile = "prd.json"
+	cfg.RetryBackoff = 20 * time.Second
+
+	ch := make(chan Event, 100)
+	executor := NewExecutorWithRunner(cfg, ch, newMockRunner())
+	fake := clocktest.NewFake(time.Unix(0, 0))
+	executor.clock = fake
+	executor.recoveryAttempts = 1
+
+	if _, err := executor.runRecovery(context.Background(), &prd.PRD{}, prompt.RecoveryReasonStoryFailure, "tests failed", nil); err != nil {
+		t.Fatalf("runRecovery() error = %v", err)
+	}
+	if fake.Slept() < 40*time.Second {
+		t.Fatalf("slept %s, want at least the 40s backoff for attempt 2", fake.Slept())
+	}
+	announced := false
+	for _, e := range drainEvents(ch) {
+		if out, ok := e.(EventOutput); ok && strings.Contains(out.Text, "Backing off 40s before recovery attempt 2/") {
+			announced = true
+		}
+	}
+	if !announced {
+		t.Fatal("expected an output event announcing the backoff")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	executor.recoveryAttempts = 0
+	if _, err := executor.runRecovery(ctx, &prd.PRD{}, prompt.RecoveryReasonStoryFailure, "tests failed", nil); err == nil {
+		t.Fatal("runRecovery() should stop when ctx is canceled during the backoff")
+	}
+}
+
+func TestRunRecoveryHonorsRetryAttemptsOverride(t *testing.T) {
+	workDir := t.TempDir()
+	testgit.InitRepo(t, workDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = workDir
+	cfg.PRDFile = "prd.json"
+	cfg.RecoveryAttempts = 3
+
+	calls := 0
+	mock := newMockRunner()
+	mock.runFunc = func(_ context.Context, p string, _ chan<- runner.OutputLine) error {
+		if isRecoveryPrompt(p) {
+			calls++
+		}
+		return nil
+	}
+	executor := NewExecutorWithRunner(cfg, make(chan Event, 100), mock)
+	executor.clock = clocktest.NewFake(time.Unix(0, 0))
+
+	for i := 0; i < 4; i++ {
+		if _, err := executor.runRecovery(context.Background(), &prd.PRD{}, prompt.RecoveryReasonStoryFailure, "tests failed", nil); err != nil {
+			t.Fatalf("runRecovery() error = %v", err)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("recovery prompts = %d, want 3 with --retry-attempts=3", calls)
+	}
+}
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -14,6 +15,8 @@ import (
 // runPRDSelfReview has the agent critique and revise the PRD against the
 // rubric in prompt.PRDSelfReview, looping until it approves or rounds run out.
 // Round failures degrade to the current on-disk PRD rather than failing the run.
+// Two rejecting rounds in a row that leave the PRD untouched end the loop early,
+// since further rounds would only repeat the same review.
 func (e *Executor) runPRDSelfReview(ctx context.Context, userPrompt string) (*prd.PRD, error) {
 	maxRounds := constants.MaxPRDSelfReviewRounds
 	if err := ensureStateDir(e.cfg.WorkDir); err != nil {
@@ -25,6 +28,8 @@ func (e *Executor) runPRDSelfReview(ctx context.Context, userPrompt string) (*pr
 	}
 
 	approved := false
+	unchangedRejections := 0
+	before := e.prdFingerprint()
 	for round := 1; round <= maxRounds; round++ {
 		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("PRD self-review round %d of %d", round, maxRounds)}})
 
@@ -61,6 +66,19 @@ func (e *Executor) runPRDSelfReview(ctx context.Context, userPrompt string) (*pr
 			approved = true
 			break
 		}
+
+		after := e.prdFingerprint()
+		if after == "" || after != before {
+			unchangedRejections = 0
+			before = after
+			continue
+		}
+		unchangedRejections++
+		if unchangedRejections >= 2 && round < maxRounds {
+			logger.Warn("PRD self-review rejected without revising the PRD, stopping early", "round", round)
+			e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Self-review round %d left the PRD unchanged again; stopping early", round)}})
+			break
+		}
 	}
 	if !approved {
 		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("PRD self-review did not approve within %d rounds; proceeding with last PRD revision", maxRounds)}})
@@ -72,3 +90,19 @@ func (e *Executor) runPRDSelfReview(ctx context.Context, userPrompt string) (*pr
 	}
 	return p, nil
 }
+
+// prdFingerprint returns the on-disk PRD content, ignoring the save counter, or
+// "" when it cannot be read.
+func (e *Executor) prdFingerprint() string {
+	p, err := e.store.Load(e.cfg)
+	if err != nil {
+		return ""
+	}
+	snapshot := *p
+	snapshot.Version = 0
+	data, err := json.Marshal(&snapshot)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
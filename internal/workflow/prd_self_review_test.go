@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -141,7 +142,10 @@ func TestRunPRDSelfReviewStopsAtMaxRounds(t *testing.T) {
 
 	ch := make(chan Event, 100)
 	mock := newMockRunner()
+	round := 0
 	mock.runFunc = func(ctx context.Context, p string, outputCh chan<- runner.OutputLine) error {
+		round++
+		revisePRDContext(t, cfg, round)
 		return writeVerdictFile(t, cfg.WorkDir, false, "still not good enough")
 	}
 
@@ -158,6 +162,55 @@ func TestRunPRDSelfReviewStopsAtMaxRounds(t *testing.T) {
 	}
 }
 
+func revisePRDContext(t *testing.T, cfg *config.Config, round int) {
+	t.Helper()
+	p, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatalf("load PRD for revision: %v", err)
+	}
+	p.Context = fmt.Sprintf("revision %d", round)
+	if err := prd.Save(cfg, p); err != nil {
+		t.Fatalf("save revised PRD: %v", err)
+	}
+}
+
+func TestRunPRDSelfReviewStopsEarlyWhenPRDUnchanged(t *testing.T) {
+	cfg := newSelfReviewConfig(t)
+
+	ch := make(chan Event, 100)
+	mock := newMockRunner()
+	mock.runFunc = func(ctx context.Context, p string, outputCh chan<- runner.OutputLine) error {
+		return writeVerdictFile(t, cfg.WorkDir, false, "too vague")
+	}
+
+	exec := NewExecutorWithRunner(cfg, ch, mock)
+	p, err := exec.runPRDSelfReview(context.Background(), "build feature")
+	if err != nil {
+		t.Fatalf("runPRDSelfReview() error = %v", err)
+	}
+	if p == nil || p.ProjectName != "Test" {
+		t.Fatalf("runPRDSelfReview() PRD = %+v, want current PRD", p)
+	}
+	if mock.CallCount() != 2 {
+		t.Errorf("runner calls = %d, want 2 (stop after the PRD comes back unchanged twice)", mock.CallCount())
+	}
+
+	texts := drainOutputTexts(ch)
+	foundEarlyStop := false
+	foundBestEffort := false
+	for _, text := range texts {
+		if strings.Contains(text, "left the PRD unchanged again") {
+			foundEarlyStop = true
+		}
+		if strings.Contains(text, "did not approve within") {
+			foundBestEffort = true
+		}
+	}
+	if !foundEarlyStop || !foundBestEffort {
+		t.Errorf("expected early-stop and best-effort messages in outputs %v", texts)
+	}
+}
+
 func TestRunPRDSelfReviewMissingVerdictRetriesUntilMaxRounds(t *testing.T) {
 	cfg := newSelfReviewConfig(t)
 
@@ -384,11 +437,14 @@ func TestRunGenerateSelfReviewNeverApprovedStillEmitsPRDReview(t *testing.T) {
 
 	ch := make(chan Event, 100)
 	mock := newMockRunner()
+	reviews := 0
 	mock.runFunc = func(ctx context.Context, p string, outputCh chan<- runner.OutputLine) error {
 		if !strings.Contains(p, prompt.PRDSelfReviewVerdictFile) {
 			data := `{"project_name":"Generated","stories":[{"id":"1","title":"Test","description":"Desc","slices":[{"id":"slice-1","behavior":"AC","red_hint":"add failing test"}],"priority":1}]}`
 			return os.WriteFile(filepath.Join(tmpDir, "prd.json"), []byte(data), 0644)
 		}
+		reviews++
+		revisePRDContext(t, cfg, reviews)
 		return writeVerdictFile(t, tmpDir, false, "never satisfied")
 	}
 
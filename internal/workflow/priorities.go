@@ -0,0 +1,22 @@
+package workflow
+
+import (
+	"fmt"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+)
+
+// normalizePriorities renumbers PRD priorities to 1..N and persists the result
+// when --normalize-priorities is set.
+func (e *Executor) normalizePriorities(p *prd.PRD) error {
+	if !e.cfg.NormalizePriorities || !p.NormalizePriorities() {
+		return nil
+	}
+	logger.Info("normalized story priorities", "stories", len(p.Stories))
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Normalized priorities for %d stories", len(p.Stories))}})
+	if err := e.store.Save(e.cfg, p); err != nil {
+		return fmt.Errorf("saving normalized priorities: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+)
+
+type savingPRDStore struct {
+	p     *prd.PRD
+	saved []*prd.PRD
+}
+
+func (s *savingPRDStore) Load(cfg *config.Config) (*prd.PRD, error) { return s.p, nil }
+func (s *savingPRDStore) Save(cfg *config.Config, p *prd.PRD) error {
+	s.saved = append(s.saved, p)
+	return nil
+}
+func (s *savingPRDStore) Exists(cfg *config.Config) (bool, error) { return true, nil }
+
+func TestRunLoadNormalizesPrioritiesWhenEnabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		normalize bool
+		want      []int
+		wantSaves int
+	}{
+		{name: "enabled renumbers and saves", normalize: true, want: []int{1, 2, 3}, wantSaves: 1},
+		{name: "disabled leaves priorities alone", normalize: false, want: []int{1, 1, 3}, wantSaves: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = t.TempDir()
+			cfg.NormalizePriorities = tt.normalize
+			store := &savingPRDStore{p: &prd.PRD{Stories: []*prd.Story{
+				{ID: "a", Priority: 1},
+				{ID: "b", Priority: 1},
+				{ID: "c", Priority: 3},
+			}}}
+			exec := NewExecutorWithRunnerAndStore(cfg, make(chan Event, 10), newMockRunner(), store)
+
+			p, err := exec.RunLoad(context.Background())
+			if err != nil {
+				t.Fatalf("RunLoad() error = %v", err)
+			}
+			for i, story := range p.Stories {
+				if story.Priority != tt.want[i] {
+					t.Errorf("story %q priority = %d, want %d", story.ID, story.Priority, tt.want[i])
+				}
+			}
+			if len(store.saved) != tt.wantSaves {
+				t.Errorf("saves = %d, want %d", len(store.saved), tt.wantSaves)
+			}
+		})
+	}
+}
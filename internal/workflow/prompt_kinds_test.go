@@ -17,3 +17,7 @@ func isCleanupPrompt(p string) bool {
 func isRecoveryPrompt(p string) bool {
 	return promptpkg.HasKind(p, promptpkg.KindRecovery)
 }
+
+func isTestScaffoldPrompt(p string) bool {
+	return promptpkg.HasKind(p, promptpkg.KindTestScaffold)
+}
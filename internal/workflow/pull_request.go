@@ -0,0 +1,39 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/workdir"
+)
+
+var openPR = workdir.OpenPR
+
+// openPullRequest opens a PR for the finished PRD branch when RALPH_OPEN_PR is
+// set. The run has already succeeded, so a missing or logged-out gh, or any
+// other failure, only warns.
+func (e *Executor) openPullRequest(p *prd.PRD) {
+	if !e.cfg.OpenPR || e.cfg.NoBranch {
+		return
+	}
+	if saved, err := e.store.Load(e.cfg); err == nil {
+		p = saved
+	}
+	if p.BranchName == "" {
+		return
+	}
+	url, err := openPR(e.cfg.WorkDir, p.BranchName, p.ProjectName, p.BuildSummary(e.effectiveTestCommand(p)))
+	var unavailable *workdir.GHUnavailableError
+	switch {
+	case errors.As(err, &unavailable):
+		logger.Info("skipping pull request", "reason", unavailable.Err)
+		e.emit(EventOutput{Output: Output{Text: "Hint: RALPH_OPEN_PR is set but " + unavailable.Hint}})
+	case err != nil:
+		logger.Warn("failed to open pull request", "branch", p.BranchName, "error", err)
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Warning: %v", err), IsErr: true}})
+	default:
+		e.emit(EventOutput{Output: Output{Text: "Opened pull request: " + url}})
+	}
+}
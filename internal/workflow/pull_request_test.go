@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/config"
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/workdir"
+)
+
+func TestOpenPullRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  bool
+		branch   string
+		err      error
+		wantCall bool
+		wantLine string
+	}{
+		{name: "disabled", branch: "feature/invites"},
+		{name: "no branch", enabled: true},
+		{name: "opened", enabled: true, branch: "feature/invites", wantCall: true, wantLine: "Opened pull request: https://github.com/acme/app/pull/7"},
+		{name: "gh unavailable", enabled: true, branch: "feature/invites", err: &workdir.GHUnavailableError{Hint: "run gh auth login"}, wantCall: true, wantLine: "Hint: RALPH_OPEN_PR is set but run gh auth login"},
+		{name: "push failed", enabled: true, branch: "feature/invites", err: errors.New("push rejected"), wantCall: true, wantLine: "Warning: push rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTitle, gotBody string
+			called := false
+			orig := openPR
+			t.Cleanup(func() { openPR = orig })
+			openPR = func(_, _, title, body string) (string, error) {
+				called, gotTitle, gotBody = true, title, body
+				return "https://github.com/acme/app/pull/7", tt.err
+			}
+
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = t.TempDir()
+			cfg.OpenPR = tt.enabled
+			p := &prd.PRD{ProjectName: "Invites", BranchName: tt.branch, Stories: []*prd.Story{{ID: "story-1", Title: "Invite API", Passes: true}}}
+			ch := make(chan Event, 10)
+			exec := NewExecutorWithRunnerAndStore(cfg, ch, newMockRunner(), inMemoryPRDStore{p: p})
+
+			exec.openPullRequest(p)
+
+			if called != tt.wantCall {
+				t.Fatalf("openPR called = %v, want %v", called, tt.wantCall)
+			}
+			if called && (gotTitle != "Invites" || !strings.Contains(gotBody, "story-1 Invite API (done")) {
+				t.Errorf("openPR(title=%q, body=%q), want the project name and a story summary", gotTitle, gotBody)
+			}
+			var lines []string
+			for _, ev := range drainEvents(ch) {
+				if out, ok := ev.(EventOutput); ok {
+					lines = append(lines, out.Text)
+				}
+			}
+			if tt.wantLine == "" && len(lines) > 0 || tt.wantLine != "" && (len(lines) != 1 || lines[0] != tt.wantLine) {
+				t.Errorf("output = %q, want %q", lines, tt.wantLine)
+			}
+		})
+	}
+}
@@ -74,7 +74,24 @@ func extractFindingsBlock(transcript string) (string, bool) {
 	if end < 0 {
 		return "", false
 	}
-	return strings.TrimSpace(rest[:end]), true
+	return stripMarkdownWrapping(rest[:end]), true
+}
+
+// stripMarkdownWrapping removes the code fences, blockquote prefixes, and
+// inline backticks models sometimes wrap around the findings JSON.
+func stripMarkdownWrapping(block string) string {
+	var kept []string
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimSpace(line)
+		for strings.HasPrefix(line, ">") {
+			line = strings.TrimSpace(strings.TrimPrefix(line, ">"))
+		}
+		if strings.HasPrefix(line, "```") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Trim(strings.TrimSpace(strings.Join(kept, "\n")), "`")
 }
 
 func findingID(category, path string, line int, summary string) string {
@@ -93,6 +93,46 @@ func TestParseFindings(t *testing.T) {
 	}
 }
 
+func TestParseFindingsToleratesMarkdownWrapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantLen int
+	}{
+		{
+			name:    "json code fence",
+			input:   "===ralph-findings===\n```json\n[{\"category\":\"bug\",\"path\":\"a.go\",\"summary\":\"nil deref\"}]\n```\n===/ralph-findings===",
+			wantLen: 1,
+		},
+		{
+			name:    "fenced markers",
+			input:   "```\n===ralph-findings===\n[]\n===/ralph-findings===\n```",
+			wantLen: 0,
+		},
+		{
+			name:    "inline backticks",
+			input:   "===ralph-findings=== `[{\"category\":\"bug\",\"path\":\"a.go\",\"summary\":\"x\"}]` ===/ralph-findings===",
+			wantLen: 1,
+		},
+		{
+			name:    "blockquote prefixes",
+			input:   "> ===ralph-findings===\n> [{\"category\":\"bug\",\"path\":\"a.go\",\"summary\":\"x\"}]\n> ===/ralph-findings===",
+			wantLen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFindings(tt.input, true)
+			if err != nil {
+				t.Fatalf("ParseFindings() err = %v", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Fatalf("len = %d, want %d", len(got), tt.wantLen)
+			}
+		})
+	}
+}
+
 func TestParseFindingsRequiresBlockWhenNonEmptyDiff(t *testing.T) {
 	_, err := ParseFindings("Review done without markers.", true)
 	if err == nil {
@@ -8,6 +8,7 @@ import (
 
 	"ralph/internal/prompt"
 	"ralph/internal/shared/gitdiff"
+	"ralph/internal/shared/logger"
 	"ralph/internal/shared/runner"
 	"ralph/internal/shared/runpaths"
 )
@@ -85,6 +86,7 @@ func runRunner(ctx context.Context, r runner.RunnerInterface, reviewPrompt strin
 		}
 	}()
 
+	logger.Prompt(prompt.Kind(reviewPrompt), reviewPrompt)
 	err := r.Run(ctx, reviewPrompt, outputCh)
 	close(outputCh)
 	<-done
@@ -0,0 +1,68 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"ralph/internal/shared/logger"
+	"ralph/internal/shared/workdir"
+)
+
+var headSHA = workdir.HeadSHA
+var resetHard = workdir.ResetHard
+
+// storyCheckpoint records HEAD before a story starts so RALPH_ROLLBACK_ON_FAIL
+// can undo a failed attempt. It returns "" when rollback is off, when stories
+// run concurrently (a reset would discard the other stories' work), or when
+// HEAD cannot be read. Under --no-commit earlier stories are never committed,
+// so a reset would discard them too and rollback is off.
+func (e *Executor) storyCheckpoint() string {
+	if !e.cfg.RollbackOnFail || e.cfg.StoryConcurrency() > 1 || e.cfg.NoCommit {
+		return ""
+	}
+	sha, err := headSHA(e.cfg.WorkDir)
+	if err != nil {
+		logger.Warn("cannot record story checkpoint; rollback disabled for this story", "error", err)
+		return ""
+	}
+	return sha
+}
+
+// rollbackStory resets the work tree to the commit recorded before the story
+// started, dropping its slice commits and uncommitted edits, and marks its
+// slices pending again so the next attempt starts clean. The PRD is read
+// before the reset and saved after it, so Iterations and StartedAt keep
+// counting the failed attempt even when the PRD file is tracked in git.
+func (e *Executor) rollbackStory(storyID, sha string) {
+	if sha == "" {
+		return
+	}
+	e.prdMu.Lock()
+	defer e.prdMu.Unlock()
+
+	p, err := e.store.Load(e.cfg)
+	if err != nil {
+		logger.Warn("skipping story rollback: cannot load PRD", "story_id", storyID, "error", err)
+		return
+	}
+	if err := resetHard(e.cfg.WorkDir, sha); err != nil {
+		logger.Error("story rollback failed", "story_id", storyID, "error", err)
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Could not roll back story %s: %v", storyID, err), IsErr: true}})
+		return
+	}
+	if story := p.GetStory(storyID); story != nil {
+		story.Passes = false
+		story.CompletedAt = time.Time{}
+		story.ResetSlicePasses()
+	}
+	if err := e.savePRD(p); err != nil {
+		logger.Error("failed to save PRD after story rollback", "story_id", storyID, "error", err)
+		e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Rolled back story %s but could not save the PRD: %v", storyID, err), IsErr: true}})
+		return
+	}
+	e.emit(EventOutput{Output: Output{Text: fmt.Sprintf("Rolled back story %s to %s (RALPH_ROLLBACK_ON_FAIL); its slices will be retried from a clean tree.", storyID, shortSHA(sha))}})
+}
+
+func shortSHA(sha string) string {
+	return sha[:min(len(sha), 12)]
+}
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ralph/internal/shared/prd"
+	"ralph/internal/shared/runner"
+	"ralph/internal/shared/workdir"
+)
+
+func TestRunImplementationRollsBackFailedStory(t *testing.T) {
+	tests := []struct {
+		name         string
+		rollback     bool
+		wantRollback bool
+	}{
+		{name: "enabled", rollback: true, wantRollback: true},
+		{name: "disabled", rollback: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var workDir string
+			implementCalls := 0
+			exec, p, ch := newConcurrentTestExecutor(t, 1, []*prd.Story{
+				{ID: "story-1", Title: "One", Description: "Desc", Priority: 1, Slices: []*prd.Slice{
+					{ID: "slice-1", Behavior: "writes the first file", RedHint: "first test"},
+					{ID: "slice-2", Behavior: "writes the second file", RedHint: "second test"},
+				}},
+			}, func(ctx context.Context, prompt string, _ chan<- runner.OutputLine) error {
+				switch {
+				case isStoryImplementPrompt(prompt):
+					implementCalls++
+					if implementCalls == 1 {
+						return os.WriteFile(filepath.Join(workDir, "first.txt"), []byte("first\n"), 0o644)
+					}
+					if err := os.WriteFile(filepath.Join(workDir, "README.md"), []byte("broken\n"), 0o644); err != nil {
+						return err
+					}
+					return errors.New("build broke")
+				case isRecoveryPrompt(prompt):
+					return errors.New("still broken")
+				}
+				return nil
+			})
+			workDir = exec.cfg.WorkDir
+			exec.cfg.RecoveryAttempts = 1
+			exec.cfg.RollbackOnFail = tt.rollback
+			base, err := workdir.HeadSHA(workDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := exec.RunImplementation(context.Background(), p); err == nil {
+				t.Fatal("RunImplementation() error = nil, want story failure")
+			}
+
+			head, _ := workdir.HeadSHA(workDir)
+			readme, _ := os.ReadFile(filepath.Join(workDir, "README.md"))
+			saved, err := prd.Load(exec.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			story := saved.GetStory("story-1")
+			var sawRollback bool
+			for _, ev := range drainEvents(ch) {
+				if out, ok := ev.(EventOutput); ok && strings.Contains(out.Text, "Rolled back story story-1") {
+					sawRollback = true
+				}
+			}
+
+			if !tt.wantRollback {
+				if head == base || sawRollback || !story.Slices[0].Passes {
+					t.Fatalf("rollback ran while disabled: head moved=%v event=%v slice-1 passes=%v", head != base, sawRollback, story.Slices[0].Passes)
+				}
+				return
+			}
+			if head != base || string(readme) != "ok\n" {
+				t.Fatalf("HEAD = %s, README.md = %q; want reset to %s with README restored", head, readme, base)
+			}
+			if story.CompletedSliceCount() != 0 || saved.Iterations != 1 || story.StartedAt.IsZero() {
+				t.Fatalf("story after rollback: slices passed=%d iterations=%d started=%v; want slices pending with the attempt still counted",
+					story.CompletedSliceCount(), saved.Iterations, story.StartedAt)
+			}
+			if !sawRollback {
+				t.Fatal("expected an output event describing the rollback")
+			}
+		})
+	}
+}
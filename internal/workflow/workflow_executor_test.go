@@ -2,11 +2,14 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"ralph/internal/shared/clock"
+	"ralph/internal/shared/clock/clocktest"
 	"ralph/internal/shared/config"
 	"ralph/internal/shared/constants"
 	"ralph/internal/shared/prd"
@@ -118,6 +121,64 @@ func TestForwardOutput(t *testing.T) {
 	}
 }
 
+func TestForwardOutputShowInternal(t *testing.T) {
+	tests := []struct {
+		name         string
+		showInternal bool
+		want         Output
+	}{
+		{name: "hidden by default", want: Output{Text: "Tool completed", Verbose: true}},
+		{name: "shown and tagged with --show-internal", showInternal: true, want: Output{Text: "[internal] Tool completed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.ShowInternal = tt.showInternal
+			eventsCh := make(chan Event, 10)
+			exec := NewExecutor(cfg, eventsCh)
+
+			outputCh := make(chan runner.OutputLine, 2)
+			outputCh <- runner.OutputLine{Text: "Tool completed", Verbose: true}
+			outputCh <- runner.OutputLine{Text: "Editing main.go"}
+			close(outputCh)
+			exec.forwardOutput(outputCh)
+
+			internal := (<-eventsCh).(EventOutput)
+			if internal.Output != tt.want {
+				t.Errorf("internal line = %+v, want %+v", internal.Output, tt.want)
+			}
+			if regular := (<-eventsCh).(EventOutput); regular.Text != "Editing main.go" || regular.Verbose {
+				t.Errorf("regular line = %+v, want it forwarded unchanged", regular.Output)
+			}
+		})
+	}
+}
+
+func TestForwardObservedOutputHidesRawLinesButObservesThem(t *testing.T) {
+	eventsCh := make(chan Event, 10)
+	exec := NewExecutor(config.DefaultConfig(), eventsCh)
+
+	outputCh := make(chan runner.OutputLine, 2)
+	outputCh <- runner.OutputLine{Text: "Starting Claude Code..."}
+	outputCh <- runner.OutputLine{Text: "COMPLETED: all criteria met", Raw: true}
+	close(outputCh)
+
+	var observed []string
+	exec.forwardObservedOutput(context.Background(), outputCh, func(line runner.OutputLine) { observed = append(observed, line.Text) })
+	close(eventsCh)
+
+	if len(observed) != 2 || observed[1] != "COMPLETED: all criteria met" {
+		t.Errorf("observed = %q, want both lines including the raw marker", observed)
+	}
+	var shown []string
+	for ev := range eventsCh {
+		shown = append(shown, ev.(EventOutput).Text)
+	}
+	if len(shown) != 1 || shown[0] != "Starting Claude Code..." {
+		t.Errorf("emitted = %q, want only the non-raw line", shown)
+	}
+}
+
 func TestEventOutputEmbedding(t *testing.T) {
 	e := EventOutput{Output: Output{Text: "hello", IsErr: true}}
 	if e.Text != "hello" {
@@ -198,6 +259,135 @@ func TestRunRecoveryPromptRetriesFastFailure(t *testing.T) {
 	}
 }
 
+func TestRunRecoveryPromptBackoffUsesClock(t *testing.T) {
+	cfg := config.DefaultConfig()
+	mock := newMockRunner()
+	calls := 0
+	mock.runFunc = func(_ context.Context, _ string, _ chan<- runner.OutputLine) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("cursor-agent exited with code 1")
+		}
+		return nil
+	}
+	exec := NewExecutorWithRunner(cfg, make(chan Event, 10), mock)
+	fake := clocktest.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	exec.clock = fake
+
+	start := time.Now()
+	if err := exec.runRecoveryPrompt(context.Background(), "recover"); err != nil {
+		t.Fatalf("runRecoveryPrompt() error = %v, want nil after retry", err)
+	}
+	if calls != 2 {
+		t.Fatalf("runner calls = %d, want 2", calls)
+	}
+	if want := constants.RunnerRecoveryCooldown + constants.RunnerFastFailRetryDelay; fake.Slept() != want {
+		t.Fatalf("virtual wait = %v, want %v", fake.Slept(), want)
+	}
+	if elapsed := time.Since(start); elapsed >= constants.RunnerRecoveryCooldown {
+		t.Fatalf("real elapsed = %v, want no real sleeping with a fake clock", elapsed)
+	}
+}
+
+func TestRunRecoveryPromptRateLimitUsesExtendedCooldown(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.RateLimitCooldown = 30 * time.Second
+	mock := newMockRunner()
+	calls := 0
+	mock.runFunc = func(_ context.Context, _ string, outputCh chan<- runner.OutputLine) error {
+		calls++
+		if calls <= 2 {
+			outputCh <- runner.OutputLine{Text: "API Error: 429 rate limit exceeded", IsErr: true}
+			return fmt.Errorf("claude exited with code 1")
+		}
+		return nil
+	}
+	ch := make(chan Event, 20)
+	exec := NewExecutorWithRunner(cfg, ch, mock)
+	fake := clocktest.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	exec.clock = fake
+
+	if err := exec.runWithForwardedOutput(context.Background(), "story"); err == nil {
+		t.Fatal("story run should fail")
+	}
+	if err := exec.runRecoveryPrompt(context.Background(), "recover"); err != nil {
+		t.Fatalf("runRecoveryPrompt() error = %v, want nil after rate-limited retry", err)
+	}
+	if calls != 3 {
+		t.Fatalf("runner calls = %d, want 3", calls)
+	}
+	if want := 2 * cfg.RateLimitCooldown; fake.Slept() != want {
+		t.Fatalf("virtual wait = %v, want %v (rate-limit cooldown before each retry)", fake.Slept(), want)
+	}
+
+	waits := 0
+	for _, e := range drainEvents(ch) {
+		if out, ok := e.(EventOutput); ok && out.Text == "Rate limited, waiting 30s before retrying" {
+			waits++
+		}
+	}
+	if waits != 2 {
+		t.Fatalf("rate-limit wait events = %d, want 2", waits)
+	}
+}
+
+func TestRunWithForwardedOutputIgnoresRateLimitTextOnStdout(t *testing.T) {
+	mock := newMockRunner()
+	mock.runFunc = func(_ context.Context, _ string, outputCh chan<- runner.OutputLine) error {
+		outputCh <- runner.OutputLine{Text: "Added a retry when the API returns 429 rate limit exceeded"}
+		return nil
+	}
+	exec := NewExecutorWithRunner(config.DefaultConfig(), make(chan Event, 10), mock)
+
+	if err := exec.runWithForwardedOutput(context.Background(), "story"); err != nil {
+		t.Fatalf("runWithForwardedOutput() error = %v", err)
+	}
+	if exec.isRateLimited(context.Background()) {
+		t.Error("rate limit flagged from a stdout line, want only stderr lines and the run error to count")
+	}
+}
+
+func TestRunRecoveryPromptCancelDuringRateLimitCooldown(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.RateLimitCooldown = time.Hour
+	mock := newMockRunner()
+	exec := NewExecutorWithRunner(cfg, make(chan Event, 10), mock)
+	exec.setRateLimited(context.Background(), true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := exec.runRecoveryPrompt(ctx, "recover")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("runRecoveryPrompt() error = %v, want context deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("runRecoveryPrompt() took %v after cancel, want a prompt return", elapsed)
+	}
+	if len(mock.calls) != 0 {
+		t.Errorf("runner calls = %d, want none once the cooldown was canceled", len(mock.calls))
+	}
+}
+
+func TestRunRecoveryPromptWithoutRateLimitKeepsNormalCooldown(t *testing.T) {
+	cfg := config.DefaultConfig()
+	mock := newMockRunner()
+	mock.runFunc = func(_ context.Context, _ string, outputCh chan<- runner.OutputLine) error {
+		outputCh <- runner.OutputLine{Text: "working"}
+		return nil
+	}
+	exec := NewExecutorWithRunner(cfg, make(chan Event, 10), mock)
+	fake := clocktest.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	exec.clock = fake
+
+	if err := exec.runRecoveryPrompt(context.Background(), "recover"); err != nil {
+		t.Fatalf("runRecoveryPrompt() error = %v", err)
+	}
+	if fake.Slept() != constants.RunnerRecoveryCooldown {
+		t.Fatalf("virtual wait = %v, want %v", fake.Slept(), constants.RunnerRecoveryCooldown)
+	}
+}
+
 func TestRunLoadSuccess(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := config.DefaultConfig()
@@ -315,7 +505,7 @@ func TestRunImplementationNoNextStory(t *testing.T) {
 func TestNewExecutorWithRunner(t *testing.T) {
 	cfg := config.DefaultConfig()
 	ch := make(chan Event, 10)
-	r := runner.New(cfg)
+	r := runner.New(cfg, clock.Real{})
 
 	exec := NewExecutorWithRunner(cfg, ch, r)
 	if exec == nil {
@@ -357,10 +547,36 @@ func TestPRDGetStory(t *testing.T) {
 
 func TestEmitChannelFull(t *testing.T) {
 	cfg := config.DefaultConfig()
+	cfg.EmitTimeout = 10 * time.Millisecond
 	ch := make(chan Event, 1)
 	exec := NewExecutor(cfg, ch)
 
 	ch <- EventCompleted{}
 
 	exec.emit(EventCompleted{})
+	exec.emit(EventCompleted{})
+	if got := exec.DroppedEvents(); got != 2 {
+		t.Fatalf("DroppedEvents() = %d, want 2", got)
+	}
+}
+
+func TestEmitWaitsForRoomBeforeDropping(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.EmitTimeout = 5 * time.Second
+	ch := make(chan Event, 1)
+	exec := NewExecutor(cfg, ch)
+	ch <- EventCompleted{}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		<-ch
+	}()
+	exec.emit(EventOutput{Output: Output{Text: "late line"}})
+
+	if got := exec.DroppedEvents(); got != 0 {
+		t.Fatalf("DroppedEvents() = %d, want 0 when the consumer catches up in time", got)
+	}
+	if ev, ok := (<-ch).(EventOutput); !ok || ev.Text != "late line" {
+		t.Fatalf("event = %#v, want the late output line", ev)
+	}
 }
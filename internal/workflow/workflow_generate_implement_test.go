@@ -8,11 +8,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"ralph/internal/prompt"
 	"ralph/internal/shared/config"
+	"ralph/internal/shared/gitdiff"
 	"ralph/internal/shared/prd"
 	"ralph/internal/shared/prd/prdtest"
 	"ralph/internal/shared/runner"
@@ -46,6 +48,52 @@ func TestRunGenerateEmptyWorkdirUsesNewProjectPrompt(t *testing.T) {
 	}
 }
 
+func TestRunGenerateFlagsNewProjectForEmptyWorkdir(t *testing.T) {
+	tests := []struct {
+		name       string
+		seedSource bool
+		want       bool
+	}{
+		{name: "empty work dir", seedSource: false, want: true},
+		{name: "existing source", seedSource: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if tt.seedSource {
+				if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = tmpDir
+			cfg.PRDFile = "prd.json"
+			loaded := &prd.PRD{
+				ProjectName: "Injected",
+				Stories:     []*prd.Story{{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+			}
+			ch := make(chan Event, 100)
+			exec := NewExecutorWithRunnerAndStore(cfg, ch, newMockRunner(), inMemoryPRDStore{p: loaded})
+
+			if _, err := exec.RunGenerate(context.Background(), "test prompt"); err != nil {
+				t.Fatalf("RunGenerate() error = %v", err)
+			}
+			var generating *EventPRDGenerating
+			for _, ev := range drainEvents(ch) {
+				if g, ok := ev.(EventPRDGenerating); ok {
+					generating = &g
+				}
+			}
+			if generating == nil {
+				t.Fatal("expected EventPRDGenerating")
+			}
+			if generating.NewProject != tt.want {
+				t.Errorf("EventPRDGenerating.NewProject = %v, want %v", generating.NewProject, tt.want)
+			}
+		})
+	}
+}
+
 func TestRunGenerateWithSourceWorkdirUsesExistingCodebasePrompt(t *testing.T) {
 	tmpDir := t.TempDir()
 	os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main"), 0644)
@@ -106,6 +154,252 @@ func TestRunGenerateSuccess(t *testing.T) {
 	}
 }
 
+func TestRunGenerateDryRunEmitsCompletionLine(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.DryRun = true
+
+	loaded := &prd.PRD{
+		ProjectName: "Injected",
+		Stories: []*prd.Story{
+			{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1},
+			{ID: "story-2", Title: "Two", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 2},
+		},
+	}
+	ch := make(chan Event, 100)
+	mock := newMockRunner()
+	exec := NewExecutorWithRunnerAndStore(cfg, ch, mock, inMemoryPRDStore{p: loaded})
+
+	if _, err := exec.RunGenerate(context.Background(), "test prompt"); err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+
+	const want = "Dry run complete: 2 stories, saved to prd.json"
+	var lines []string
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && strings.HasPrefix(out.Text, "Dry run complete") {
+			lines = append(lines, out.Text)
+		}
+	}
+	if len(lines) != 1 || lines[0] != want {
+		t.Fatalf("dry-run completion lines = %q, want exactly [%q]", lines, want)
+	}
+}
+
+func TestRunGenerateDryRunEmitsPRDStats(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	cfg.DryRun = true
+
+	loaded := &prd.PRD{
+		ProjectName: "Injected",
+		Stories: []*prd.Story{
+			{ID: "story-1", Title: "One", Description: "Adds invites", Slices: []*prd.Slice{{ID: "slice-1", Behavior: "POST /invites returns 201", RedHint: "POST test"}}, Priority: 1},
+			{ID: "story-2", Title: "Two", Slices: []*prd.Slice{{ID: "slice-1", Behavior: "works"}}, Priority: 2},
+		},
+	}
+	ch := make(chan Event, 100)
+	exec := NewExecutorWithRunnerAndStore(cfg, ch, newMockRunner(), inMemoryPRDStore{p: loaded})
+
+	if _, err := exec.RunGenerate(context.Background(), "test prompt"); err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+
+	var text []string
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok {
+			text = append(text, out.Text)
+		}
+	}
+	output := strings.Join(text, "\n")
+	for _, want := range []string{"2 stories (P1: 1, P2: 1)", "1 with a slice missing a test hint", "1 flagged as vague"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("dry-run output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunGenerateDryRunWritesMarkdownWhenRequested(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   bool
+	}{
+		{name: "markdown", format: config.PRDFormatMarkdown, want: true},
+		{name: "json only", format: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = tmpDir
+			cfg.PRDFile = "prd.json"
+			cfg.DryRun = true
+			cfg.PRDFormat = tt.format
+
+			loaded := &prd.PRD{
+				ProjectName: "Injected",
+				Stories:     []*prd.Story{{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+			}
+			exec := NewExecutorWithRunnerAndStore(cfg, make(chan Event, 100), newMockRunner(), inMemoryPRDStore{p: loaded})
+
+			if _, err := exec.RunGenerate(context.Background(), "test prompt"); err != nil {
+				t.Fatalf("RunGenerate() error = %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(tmpDir, "prd.md"))
+			if got := err == nil; got != tt.want {
+				t.Fatalf("prd.md written = %v, want %v (err %v)", got, tt.want, err)
+			}
+			if tt.want && !strings.Contains(string(data), "# Injected") {
+				t.Fatalf("prd.md = %q, want rendered PRD", data)
+			}
+		})
+	}
+}
+
+func TestRunGenerateUsesCustomPRDPromptTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		envFile     string
+		template    string
+		wantPrompt  string
+		wantWarning bool
+	}{
+		{name: "default file", template: "Plan {{.UserPrompt}} into {{.PRDFile}} on {{.BranchPrefix}}/ (empty={{.IsEmptyCodebase}})", wantPrompt: "Plan add invites into prd.json on feature/ (empty=true)"},
+		{name: "configured file", envFile: "prompts/prd.tmpl", template: "Custom {{.UserPrompt}}", wantPrompt: "Custom add invites"},
+		{name: "broken template falls back", template: "Plan {{.UserPrompt", wantPrompt: "add invites", wantWarning: true},
+		{name: "unknown field falls back", template: "Plan {{.Nope}}", wantPrompt: "add invites", wantWarning: true},
+		{name: "missing configured file falls back", envFile: "missing.tmpl", wantPrompt: "add invites", wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = tmpDir
+			cfg.PRDFile = "prd.json"
+			cfg.PRDPromptFile = tt.envFile
+			if tt.template != "" {
+				path := cfg.PRDPromptPath()
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.template), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var generatePrompt string
+			mock := newMockRunner()
+			mock.runFunc = func(_ context.Context, p string, _ chan<- runner.OutputLine) error {
+				if prompt.Kind(p) == prompt.KindPRDGenerate {
+					generatePrompt = p
+				}
+				return nil
+			}
+			loaded := &prd.PRD{
+				ProjectName: "Injected",
+				Stories:     []*prd.Story{{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+			}
+			ch := make(chan Event, 100)
+			exec := NewExecutorWithRunnerAndStore(cfg, ch, mock, inMemoryPRDStore{p: loaded})
+
+			if _, err := exec.RunGenerate(context.Background(), "add invites"); err != nil {
+				t.Fatalf("RunGenerate() error = %v", err)
+			}
+			if !strings.Contains(generatePrompt, tt.wantPrompt) {
+				t.Fatalf("generate prompt = %q, want containing %q", generatePrompt, tt.wantPrompt)
+			}
+			if builtin := strings.Contains(generatePrompt, "context:"); builtin != tt.wantWarning {
+				t.Errorf("built-in prompt used = %v, want %v", builtin, tt.wantWarning)
+			}
+			warned := false
+			for _, ev := range drainEvents(ch) {
+				if out, ok := ev.(EventOutput); ok && strings.Contains(out.Text, "using the built-in prompt") {
+					warned = true
+				}
+			}
+			if warned != tt.wantWarning {
+				t.Errorf("fallback warning emitted = %v, want %v", warned, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestRunGenerateTruncatesRequestOverMaxPromptBytes(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = t.TempDir()
+	cfg.PRDFile = "prd.json"
+	instructions := prompt.PRDGenerationWithAnswers("", cfg.PRDFile, cfg.BranchPrefix, true, nil)
+	cfg.MaxPromptBytes = len(instructions) + 200
+
+	var generatePrompt string
+	mock := newMockRunner()
+	mock.runFunc = func(_ context.Context, p string, _ chan<- runner.OutputLine) error {
+		if prompt.Kind(p) == prompt.KindPRDGenerate {
+			generatePrompt = p
+		}
+		return nil
+	}
+	loaded := &prd.PRD{
+		ProjectName: "Injected",
+		Stories:     []*prd.Story{{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+	}
+	ch := make(chan Event, 100)
+	exec := NewExecutorWithRunnerAndStore(cfg, ch, mock, inMemoryPRDStore{p: loaded})
+
+	request := "START " + strings.Repeat("x", 5000) + " END"
+	if _, err := exec.RunGenerate(context.Background(), request); err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+
+	if len(generatePrompt) > cfg.MaxPromptBytes {
+		t.Fatalf("prompt is %d bytes, want at most %d", len(generatePrompt), cfg.MaxPromptBytes)
+	}
+	if !strings.Contains(generatePrompt, "START") || strings.Contains(generatePrompt, "END") || !strings.Contains(generatePrompt, "…\n[request truncated to fit RALPH_MAX_PROMPT_BYTES]") {
+		t.Fatalf("request not truncated with the ellipsis note:\n%s", generatePrompt)
+	}
+	for _, line := range strings.Split(instructions, "\n") {
+		if !strings.Contains(generatePrompt, line) {
+			t.Fatalf("template instruction %q lost in truncated prompt", line)
+		}
+	}
+	warned := false
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && out.IsErr && strings.Contains(out.Text, "RALPH_MAX_PROMPT_BYTES") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Fatal("expected a truncation warning")
+	}
+}
+
+func TestRunGenerateWithoutDryRunOmitsCompletionLine(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+
+	loaded := &prd.PRD{
+		ProjectName: "Injected",
+		Stories:     []*prd.Story{{ID: "story-1", Title: "One", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+	}
+	ch := make(chan Event, 100)
+	exec := NewExecutorWithRunnerAndStore(cfg, ch, newMockRunner(), inMemoryPRDStore{p: loaded})
+
+	if _, err := exec.RunGenerate(context.Background(), "test prompt"); err != nil {
+		t.Fatalf("RunGenerate() error = %v", err)
+	}
+	for _, ev := range drainEvents(ch) {
+		if out, ok := ev.(EventOutput); ok && strings.HasPrefix(out.Text, "Dry run complete") {
+			t.Fatalf("unexpected dry-run completion line %q outside dry run", out.Text)
+		}
+	}
+}
+
 func TestRunGenerateWithAnswersLoadsFromInjectedStore(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := config.DefaultConfig()
@@ -234,6 +528,43 @@ func TestRunImplementationStorySuccess(t *testing.T) {
 	}
 }
 
+func TestRunImplementationPersistsCompletionSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+
+	testPRD := &prd.PRD{
+		ProjectName: "Test",
+		Stories:     []*prd.Story{{ID: "1", Title: "Story", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1, Passes: false}},
+	}
+	if err := prd.Save(cfg, testPRD); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 100)
+	mock := newMockRunner()
+	mock.runFunc = func(ctx context.Context, prompt string, outputCh chan<- runner.OutputLine) error {
+		return nil
+	}
+
+	exec := NewExecutorWithRunner(cfg, ch, mock)
+	if err := exec.RunImplementation(context.Background(), testPRD); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	p, err := prd.Load(cfg)
+	if err != nil {
+		t.Fatalf("prd.Load() error = %v", err)
+	}
+	if !strings.Contains(p.Summary, "Completed 1/1 stories.") || !strings.Contains(p.Summary, "- 1 Story (done, 1/1 slices)") {
+		t.Fatalf("Summary = %q, want per-story completion summary", p.Summary)
+	}
+}
+
 func TestRunImplementationIteratesSlicesBeforeMarkingStoryDone(t *testing.T) {
 	tmpDir := t.TempDir()
 	testgit.InitRepo(t, tmpDir)
@@ -441,9 +772,9 @@ func TestRunImplementationPromptsOnlyCurrentSlice(t *testing.T) {
 	ch := make(chan Event, 100)
 	mock := newMockRunner()
 	var commitMessages []string
-	originalCommitChangedFiles := commitChangedFiles
-	t.Cleanup(func() { commitChangedFiles = originalCommitChangedFiles })
-	commitChangedFiles = func(workDir, message string) (bool, error) {
+	originalCommitStory := commitStory
+	t.Cleanup(func() { commitStory = originalCommitStory })
+	commitStory = func(workDir, message string, opts gitdiff.StoryCommitOptions) (bool, error) {
 		commitMessages = append(commitMessages, message)
 		return true, nil
 	}
@@ -551,9 +882,9 @@ func TestRunImplementationCommitsEachSliceWithSliceScopedMessage(t *testing.T) {
 	ch := make(chan Event, 100)
 	mock := newMockRunner()
 	var commitMessages []string
-	originalCommitChangedFiles := commitChangedFiles
-	t.Cleanup(func() { commitChangedFiles = originalCommitChangedFiles })
-	commitChangedFiles = func(workDir, message string) (bool, error) {
+	originalCommitStory := commitStory
+	t.Cleanup(func() { commitStory = originalCommitStory })
+	commitStory = func(workDir, message string, opts gitdiff.StoryCommitOptions) (bool, error) {
 		commitMessages = append(commitMessages, message)
 		return true, nil
 	}
@@ -625,6 +956,61 @@ func TestRunImplementationCommitsEachSliceWithSliceScopedMessage(t *testing.T) {
 	}
 }
 
+func TestRunImplementationCommitsPRDWithSliceAndCompletion(t *testing.T) {
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+	cfg.CommitCoauthor = true
+
+	testPRD := &prd.PRD{
+		ProjectName: "Test",
+		Stories:     []*prd.Story{{ID: "story-1", Title: "Story", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+	}
+	if err := prd.Save(cfg, testPRD); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	mock := newMockRunner()
+	mock.runFunc = func(context.Context, string, chan<- runner.OutputLine) error {
+		return os.WriteFile(filepath.Join(tmpDir, "feature.go"), []byte("package main\n"), 0o644)
+	}
+	runnerExec := NewExecutorWithRunner(cfg, make(chan Event, 100), mock)
+	if err := runnerExec.RunImplementation(context.Background(), testPRD); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	gitOutput := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", tmpDir}, args...)...).Output()
+		if err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+		return string(out)
+	}
+	subjects := strings.Split(strings.TrimSpace(gitOutput("log", "--format=%s", "-2")), "\n")
+	if len(subjects) != 2 || subjects[0] != "ralph: story-1 complete" || !strings.HasPrefix(subjects[1], "ralph: story-1/") {
+		t.Fatalf("latest commits = %q, want the slice commit then the completion commit", subjects)
+	}
+
+	var sliceCommit, completionCommit prd.PRD
+	if err := json.Unmarshal([]byte(gitOutput("show", "HEAD~1:prd.json")), &sliceCommit); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(gitOutput("show", "HEAD:prd.json")), &completionCommit); err != nil {
+		t.Fatal(err)
+	}
+	if story := sliceCommit.GetStory("story-1"); !story.AllSlicesPassed() || story.Passes {
+		t.Errorf("slice commit PRD: slices passed = %v, story passes = %v, want true and false", story.AllSlicesPassed(), story.Passes)
+	}
+	if story := completionCommit.GetStory("story-1"); !story.Passes || story.CompletedAt.IsZero() {
+		t.Errorf("completion commit PRD: story passes = %v, completed at = %v, want passing with a completion time", story.Passes, story.CompletedAt)
+	}
+}
+
 func TestRunImplementationOmitsPassedSlicesFromPrompt(t *testing.T) {
 	tmpDir := t.TempDir()
 	testgit.InitRepo(t, tmpDir)
@@ -744,6 +1130,229 @@ func TestRunImplementationRunnerFailureReturnsError(t *testing.T) {
 	}
 }
 
+func TestRunImplementationCancelCurrentStoryRequeuesStory(t *testing.T) {
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+
+	testPRD := &prd.PRD{
+		ProjectName: "Test",
+		Stories:     []*prd.Story{{ID: "1", Title: "Story", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1, Passes: false}},
+	}
+	if err := prd.Save(cfg, testPRD); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 100)
+	mock := newMockRunner()
+	var exec *Executor
+	storyRuns := 0
+	mock.runFunc = func(ctx context.Context, prompt string, outputCh chan<- runner.OutputLine) error {
+		storyRuns++
+		if storyRuns == 1 {
+			if !exec.CancelCurrentStory() {
+				t.Error("CancelCurrentStory() = false while a story is running")
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	exec = NewExecutorWithRunner(cfg, ch, mock)
+	if err := exec.RunImplementation(context.Background(), testPRD); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+	if storyRuns != 2 {
+		t.Fatalf("story runs = %d, want 2 (canceled attempt then requeued run)", storyRuns)
+	}
+	if exec.CancelCurrentStory() {
+		t.Error("CancelCurrentStory() = true after the run finished")
+	}
+
+	var completions []bool
+	for _, e := range drainEvents(ch) {
+		if done, ok := e.(EventStoryCompleted); ok {
+			completions = append(completions, done.Success)
+		}
+	}
+	if len(completions) != 2 || completions[0] || !completions[1] {
+		t.Fatalf("story completions = %v, want [false true]", completions)
+	}
+
+	p, _ := prd.Load(cfg)
+	if !p.Stories[0].Passes {
+		t.Error("expected requeued story to pass on the next attempt")
+	}
+}
+
+func TestRunImplementationCancelStoryRecordsAttempt(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxFails      int
+		wantErr       bool
+		wantStoryRuns int
+	}{
+		{name: "requeued with the attempt saved", maxFails: 3, wantStoryRuns: 2},
+		{name: "counts toward consecutive failures", maxFails: 1, wantErr: true, wantStoryRuns: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			testgit.InitRepo(t, tmpDir)
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = tmpDir
+			cfg.PRDFile = "prd.json"
+			cfg.SkipCleanup = true
+			cfg.MaxConsecutiveFails = tt.maxFails
+
+			testPRD := &prd.PRD{
+				ProjectName: "Test",
+				Stories:     []*prd.Story{{ID: "1", Title: "Story", Description: "Desc", Slices: prdtest.Slices("AC"), Priority: 1}},
+			}
+			if err := prd.Save(cfg, testPRD); err != nil {
+				t.Fatalf("failed to save test PRD: %v", err)
+			}
+			commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+			mock := newMockRunner()
+			var exec *Executor
+			storyRuns := 0
+			interruptionsOnRequeue := -1
+			mock.runFunc = func(ctx context.Context, prompt string, outputCh chan<- runner.OutputLine) error {
+				storyRuns++
+				if storyRuns == 1 {
+					exec.CancelCurrentStory()
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				if p, err := prd.Load(cfg); err == nil {
+					interruptionsOnRequeue = p.GetStory("1").Interruptions
+				}
+				return nil
+			}
+			exec = NewExecutorWithRunner(cfg, make(chan Event, 100), mock)
+
+			err := exec.RunImplementation(context.Background(), testPRD)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunImplementation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if storyRuns != tt.wantStoryRuns {
+				t.Fatalf("story runs = %d, want %d", storyRuns, tt.wantStoryRuns)
+			}
+			if tt.wantStoryRuns > 1 && interruptionsOnRequeue != 1 {
+				t.Errorf("story interruptions when requeued = %d, want 1", interruptionsOnRequeue)
+			}
+			if tt.wantErr {
+				p, _ := prd.Load(cfg)
+				if got := p.GetStory("1").Interruptions; got != 1 {
+					t.Errorf("saved story interruptions = %d, want 1", got)
+				}
+			}
+		})
+	}
+}
+
+func TestRunImplementationReportsSkippedStories(t *testing.T) {
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+
+	testPRD := &prd.PRD{
+		ProjectName: "Test",
+		Stories: []*prd.Story{
+			{ID: "1", Title: "Story 1", Description: "Desc 1", Slices: prdtest.Slices("AC1"), Priority: 1, Skip: true},
+			{ID: "2", Title: "Story 2", Description: "Desc 2", Slices: prdtest.Slices("AC2"), Priority: 2},
+		},
+	}
+	if err := prd.Save(cfg, testPRD); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 100)
+	exec := NewExecutorWithRunner(cfg, ch, newMockRunner())
+	if err := exec.RunImplementation(context.Background(), testPRD); err != nil {
+		t.Fatalf("RunImplementation() error = %v", err)
+	}
+
+	results := map[string]events.StoryResult{}
+	for _, e := range drainEvents(ch) {
+		if done, ok := e.(EventStoryCompleted); ok {
+			results[done.Story.ID] = done.Result
+		}
+	}
+	if results["1"] != events.StorySkipped || results["2"] != events.StoryPassed {
+		t.Errorf("story results = %v, want 1 skipped and 2 passed", results)
+	}
+}
+
+func TestRunImplementationPauseHoldsNextStory(t *testing.T) {
+	tmpDir := t.TempDir()
+	testgit.InitRepo(t, tmpDir)
+	cfg := config.DefaultConfig()
+	cfg.WorkDir = tmpDir
+	cfg.PRDFile = "prd.json"
+	cfg.SkipCleanup = true
+
+	testPRD := &prd.PRD{
+		ProjectName: "Test",
+		Stories: []*prd.Story{
+			{ID: "1", Title: "Story 1", Description: "Desc 1", Slices: prdtest.Slices("AC1"), Priority: 1},
+			{ID: "2", Title: "Story 2", Description: "Desc 2", Slices: prdtest.Slices("AC2"), Priority: 2},
+		},
+	}
+	if err := prd.Save(cfg, testPRD); err != nil {
+		t.Fatalf("failed to save test PRD: %v", err)
+	}
+	commitPRDFile(t, tmpDir, cfg.PRDFile)
+
+	ch := make(chan Event, 100)
+	mock := newMockRunner()
+	var exec *Executor
+	var storyRuns atomic.Int32
+	mock.runFunc = func(ctx context.Context, prompt string, outputCh chan<- runner.OutputLine) error {
+		if storyRuns.Add(1) == 1 {
+			exec.SetPaused(true)
+		}
+		return nil
+	}
+	exec = NewExecutorWithRunner(cfg, ch, mock)
+
+	done := make(chan error, 1)
+	go func() { done <- exec.RunImplementation(context.Background(), testPRD) }()
+
+	time.Sleep(100 * time.Millisecond)
+	if got := storyRuns.Load(); got != 1 {
+		t.Fatalf("story runs while paused = %d, want 1 (current story finishes, next is held)", got)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("RunImplementation() returned while paused: %v", err)
+	default:
+	}
+
+	exec.SetPaused(false)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RunImplementation() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the run to resume")
+	}
+	if got := storyRuns.Load(); got != 2 {
+		t.Fatalf("story runs = %d, want 2", got)
+	}
+}
+
 func TestRunImplementationMultipleStories(t *testing.T) {
 	tmpDir := t.TempDir()
 	testgit.InitRepo(t, tmpDir)
@@ -1274,4 +1883,71 @@ func TestRunGenerateNoPRDFile(t *testing.T) {
 	if !strings.Contains(err.Error(), "did not generate") {
 		t.Errorf("error should mention 'did not generate', got: %v", err)
 	}
+	var notGenerated *PRDNotGeneratedError
+	if !errors.As(err, &notGenerated) || notGenerated.File != "prd.json" {
+		t.Errorf("RunGenerate() error = %#v, want *PRDNotGeneratedError for prd.json", err)
+	}
+}
+
+func TestRunGenerateBlankPRDFile(t *testing.T) {
+	for _, content := range []string{"", " \n\t\n"} {
+		cfg := config.DefaultConfig()
+		cfg.WorkDir = t.TempDir()
+		cfg.PRDFile = "prd.json"
+
+		ch := make(chan Event, 100)
+		mock := newMockRunner()
+		mock.runFunc = func(context.Context, string, chan<- runner.OutputLine) error {
+			return os.WriteFile(cfg.PRDPath(), []byte(content), 0o644)
+		}
+
+		_, err := NewExecutorWithRunner(cfg, ch, mock).RunGenerate(context.Background(), "test prompt")
+		var empty *PRDEmptyError
+		if !errors.As(err, &empty) || empty.File != "prd.json" {
+			t.Fatalf("RunGenerate() with %q error = %v, want *PRDEmptyError for prd.json", content, err)
+		}
+		var reported bool
+		for _, ev := range drainEvents(ch) {
+			if errEv, ok := ev.(EventError); ok && strings.Contains(errEv.Err.Error(), "model returned no output") {
+				reported = true
+			}
+		}
+		if !reported {
+			t.Errorf("no EventError saying the model returned no output for %q", content)
+		}
+	}
+}
+
+func TestRunGenerateUnloadablePRDFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		contents    string
+		wantInvalid bool
+	}{
+		{name: "not JSON", contents: `{"project_name":`},
+		{name: "invalid PRD", contents: `{"project_name":"X","stories":[{"id":"story-1","title":"One","priority":1}]}`, wantInvalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.WorkDir = t.TempDir()
+			cfg.PRDFile = "prd.json"
+
+			mock := newMockRunner()
+			mock.runFunc = func(context.Context, string, chan<- runner.OutputLine) error {
+				return os.WriteFile(cfg.PRDPath(), []byte(tt.contents), 0o644)
+			}
+			exec := NewExecutorWithRunner(cfg, make(chan Event, 100), mock)
+			_, err := exec.RunGenerate(context.Background(), "test prompt")
+
+			var loadErr *PRDLoadError
+			if !errors.As(err, &loadErr) || !strings.HasPrefix(err.Error(), "failed to load generated PRD prd.json: ") {
+				t.Fatalf("RunGenerate() error = %v, want *PRDLoadError", err)
+			}
+			var invalid *prd.ValidationError
+			if got := errors.As(err, &invalid); got != tt.wantInvalid {
+				t.Fatalf("errors.As(*prd.ValidationError) = %v, want %v (err: %v)", got, tt.wantInvalid, err)
+			}
+		})
+	}
 }